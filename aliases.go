@@ -0,0 +1,301 @@
+package objects
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/unrolled/render"
+	"net/http"
+	"strings"
+	"text/template"
+)
+
+var (
+	ErrInvalidAlias  = errors.New("invalid alias")
+	ErrAliasNotFound = errors.New("alias not found")
+)
+
+// aliasKinds are the taxonomies items of which may have aliases.
+var aliasKinds = []string{"brand", "component", "note"}
+
+// AliasReq names the Kind item Uuid by Alias as well, e.g. the brand
+// "Yves Saint Laurent" by "YSL" or the component "Bergamot" by "бергамот".
+type AliasReq struct {
+	Kind  string `json:"kind"`
+	Uuid  string `json:"id"`
+	Alias string `json:"alias"`
+}
+
+// AliasV1 ...
+type AliasV1 struct {
+	Uuid       string   `db:"uuid" json:"id"`
+	Kind       string   `db:"kind" json:"kind"`
+	EntityUuid string   `db:"entity_uuid" json:"entity_id"`
+	Alias      string   `db:"alias" json:"alias"`
+	Links      []LinkV1 `db:"-" json:"links"`
+}
+
+// AliasesV1 lists the aliases of one item.
+type AliasesV1 struct {
+	ObjList []AliasV1 `db:"-" json:"aliases_list"`
+	Total   int64     `db:"-" json:"total"`
+	Offset  int64     `db:"-" json:"offset"`
+	Amount  int64     `db:"-" json:"amount"`
+}
+
+// AliasMatchV1 reports the alias a searched name was resolved by to the
+// item EntityUuid.
+type AliasMatchV1 struct {
+	Kind       string `db:"kind" json:"kind"`
+	EntityUuid string `db:"entity_uuid" json:"entity_id"`
+	Alias      string `db:"alias" json:"alias"`
+}
+
+// AliasQueryParams ...
+type AliasQueryParams struct {
+	WhereConditionString string
+}
+
+func init() {
+	template.Must(queries.Parse(`
+{{define "upsert_alias"}}
+INSERT INTO aliases (uuid, kind, entity_uuid, alias, normalized)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (kind, normalized)
+DO UPDATE SET entity_uuid = EXCLUDED.entity_uuid, alias = EXCLUDED.alias
+RETURNING uuid
+{{end}}
+
+{{define "delete_alias"}}
+DELETE FROM aliases WHERE uuid = $1
+{{end}}
+
+{{define "select_aliases"}}
+SELECT uuid, kind, entity_uuid, alias FROM aliases
+WHERE {{.WhereConditionString}}
+ORDER BY aliases.alias
+{{end}}
+
+{{define "select_aliases_count"}}
+SELECT COUNT(*) FROM aliases
+WHERE {{.WhereConditionString}}
+{{end}}
+
+{{define "select_alias_match"}}
+SELECT kind, entity_uuid, alias FROM aliases
+WHERE aliases.kind = $1 AND aliases.normalized = $2
+{{end}}
+`))
+}
+
+// normalizeAlias is the form aliases are matched in, so "Bergamotte" and
+// " bergamotte" are the same alias.
+func normalizeAlias(alias string) string {
+	return strings.ToLower(strings.TrimSpace(alias))
+}
+
+// SetAlias stores req, moving its alias to req.Uuid if another item of the
+// kind had it, and returns the id of the alias.
+func SetAlias(ctx context.Context, req *AliasReq) (string, error) {
+	if req == nil || req.Uuid == "" || normalizeAlias(req.Alias) == "" {
+		return "", ErrInvalidAlias
+	}
+	if !containsString(aliasKinds, req.Kind) {
+		return "", ErrInvalidAlias
+	}
+
+	uuid, err := newUuid()
+	if err != nil {
+		return "", err
+	}
+	query, err := renderQuery("upsert_alias", nil)
+	if err != nil {
+		return "", err
+	}
+
+	tx, err := dbmap.Begin()
+	if err != nil {
+		return "", err
+	}
+	aliasUuid, err := tx.SelectStr(query, uuid, req.Kind, req.Uuid, strings.TrimSpace(req.Alias), normalizeAlias(req.Alias))
+	if err != nil {
+		tx.Rollback()
+		return "", err
+	}
+	after := &AliasV1{Uuid: aliasUuid, Kind: req.Kind, EntityUuid: req.Uuid, Alias: strings.TrimSpace(req.Alias)}
+	if err := recordAudit(tx, ctx, AuditAlias, req.Kind, req.Uuid, nil, after); err != nil {
+		tx.Rollback()
+		return "", err
+	}
+	return aliasUuid, tx.Commit()
+}
+
+// DeleteAlias drops the alias uuid, or returns ErrAliasNotFound when there
+// is none.
+func DeleteAlias(ctx context.Context, uuid string) error {
+	selectQuery, err := renderQuery("select_aliases", AliasQueryParams{WhereConditionString: "aliases.uuid = $1"})
+	if err != nil {
+		return err
+	}
+	query, err := renderQuery("delete_alias", nil)
+	if err != nil {
+		return err
+	}
+
+	tx, err := dbmap.Begin()
+	if err != nil {
+		return err
+	}
+	var before []AliasV1
+	if _, err := tx.Select(&before, selectQuery, uuid); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if len(before) == 0 {
+		tx.Rollback()
+		return ErrAliasNotFound
+	}
+	if _, err := tx.Exec(query, uuid); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := recordAudit(tx, ctx, AuditAlias, before[0].Kind, before[0].EntityUuid, &before[0], nil); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// resolveAlias returns the item of kind named name by an alias, or nil.
+func resolveAlias(kind, name string) (*AliasMatchV1, error) {
+	if normalizeAlias(name) == "" {
+		return nil, nil
+	}
+	query, err := renderQuery("select_alias_match", nil)
+	if err != nil {
+		return nil, err
+	}
+	var match AliasMatchV1
+	if err := dbmap.SelectOne(&match, query, kind, normalizeAlias(name)); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &match, nil
+}
+
+// resolveSearchAliases searches the brand and component of search named by
+// an alias by the uuid of their item instead, and returns the aliases
+// matched.
+func resolveSearchAliases(search *SearchQueryTemplateParams) ([]AliasMatchV1, error) {
+	matches := []AliasMatchV1{}
+	if search.BrandUid == "" {
+		match, err := resolveAlias("brand", search.Brand)
+		if err != nil {
+			return nil, err
+		}
+		if match != nil {
+			search.BrandUid, search.Brand = match.EntityUuid, ""
+			matches = append(matches, *match)
+		}
+	}
+	if search.ComponentUid == "" {
+		match, err := resolveAlias("component", search.Component)
+		if err != nil {
+			return nil, err
+		}
+		if match != nil {
+			search.ComponentUid, search.Component = match.EntityUuid, ""
+			matches = append(matches, *match)
+		}
+	}
+	return matches, nil
+}
+
+// searchAlias returns the item of kind named name by an alias, or nil, for
+// the kinds that have aliases.
+func searchAlias(kind, name string) (*AliasMatchV1, error) {
+	if !containsString(aliasKinds, kind) {
+		return nil, nil
+	}
+	return resolveAlias(kind, name)
+}
+
+func NewAliasesFactory(version string) (Objecter, error) {
+	return NewObjecter("aliases", version)
+}
+
+// MakeObj lists the aliases of the item params.Id.
+func (obj *AliasesV1) MakeObj(pParams interface{}) (Objecter, error) {
+	if pParams == nil {
+		return nil, errors.New("invalid args")
+	}
+	params := pParams.(*MakeObjParams)
+	if params.Id == "" {
+		return nil, errors.New("invalid args")
+	}
+
+	args := bindArgs{}
+	queryParams := AliasQueryParams{WhereConditionString: "aliases.entity_uuid = " + args.add(params.Id)}
+	query, err := renderQuery("select_aliases", queryParams)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := dbmap.Select(&obj.ObjList, query, args...); err != nil {
+		return nil, err
+	}
+
+	for i := range obj.ObjList {
+		alias := &obj.ObjList[i]
+		alias.Links = []LinkV1{
+			LinkV1{
+				Href:   baseUrl + "/" + alias.Kind + "/" + alias.EntityUuid,
+				Rel:    strings.ToUpper(alias.Kind[:1]) + alias.Kind[1:] + "Info",
+				Method: "GET",
+			},
+			LinkV1{
+				Href:   baseUrl + "/alias/" + alias.Uuid,
+				Rel:    "AliasDelete",
+				Method: "DELETE",
+			},
+		}
+	}
+
+	obj.Total = int64(len(obj.ObjList))
+	obj.Amount = int64(len(obj.ObjList))
+
+	return obj, nil
+}
+
+func (obj *AliasesV1) MakeExtraObj(params *MakeObjParams, uids []string) (Objecter, error) {
+	return nil, errors.New("not supported")
+}
+
+// Count counts the aliases of the item params.Id.
+func (obj *AliasesV1) Count(pParams interface{}) (int64, error) {
+	if pParams == nil {
+		return 0, errors.New("invalid args")
+	}
+	params := pParams.(*MakeObjParams)
+	if params.Id == "" {
+		return 0, errors.New("invalid args")
+	}
+
+	args := bindArgs{}
+	queryParams := AliasQueryParams{WhereConditionString: "aliases.entity_uuid = " + args.add(params.Id)}
+	query, err := renderQuery("select_aliases_count", queryParams)
+	if err != nil {
+		return 0, err
+	}
+	return dbmap.SelectInt(query, args...)
+}
+
+func (obj *AliasesV1) ExtraCount(uids []string) (int64, error) {
+	return 0, errors.New("not supported")
+}
+
+func (obj *AliasesV1) Json(w http.ResponseWriter, status int) error {
+	render := render.New()
+	return render.JSON(w, status, obj)
+}
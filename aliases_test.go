@@ -0,0 +1,168 @@
+package objects
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"testing"
+)
+
+// aliasRows answers the alias lookups of a test with the alias matches of
+// matches, keyed by kind and normalized alias.
+func aliasRows(matches map[string]string) func(string, []driver.Value) (*fakeResult, error) {
+	return func(query string, args []driver.Value) (*fakeResult, error) {
+		if !strings.Contains(query, "aliases.normalized = $2") {
+			return nil, nil
+		}
+		kind, alias := args[0].(string), args[1].(string)
+		uuid, ok := matches[kind+":"+alias]
+		if !ok {
+			return nil, nil
+		}
+		return &fakeResult{
+			columns: []string{"kind", "entity_uuid", "alias"},
+			rows:    [][]driver.Value{{kind, uuid, alias}},
+		}, nil
+	}
+}
+
+func TestNormalizeAlias(t *testing.T) {
+	for alias, want := range map[string]string{
+		"YSL":          "ysl",
+		" Bergamotte ": "bergamotte",
+		"БЕРГАМОТ":     "бергамот",
+		"  ":           "",
+	} {
+		if got := normalizeAlias(alias); got != want {
+			t.Errorf("normalizeAlias(%q) = %q, want %q", alias, got, want)
+		}
+	}
+}
+
+func TestSetAlias(t *testing.T) {
+	for _, req := range []*AliasReq{
+		nil,
+		{Kind: "brand", Alias: "YSL"},
+		{Kind: "brand", Uuid: "b1", Alias: " "},
+		{Kind: "country", Uuid: "c1", Alias: "USA"},
+	} {
+		if _, err := SetAlias(testClaimsContext(), req); err != ErrInvalidAlias {
+			t.Errorf("SetAlias(%+v) = %v, want ErrInvalidAlias", req, err)
+		}
+	}
+
+	fake := useFakeDB(t, func(query string, args []driver.Value) (*fakeResult, error) {
+		if strings.Contains(query, "INSERT INTO aliases") {
+			return fakeColumn("uuid", "a1"), nil
+		}
+		return nil, nil
+	})
+	uuid, err := SetAlias(testClaimsContext(), &AliasReq{Kind: "brand", Uuid: "b1", Alias: " YSL "})
+	if err != nil || uuid != "a1" {
+		t.Fatalf("SetAlias = %q, %v", uuid, err)
+	}
+	upserts := fake.ran("INSERT INTO aliases")
+	if len(upserts) != 1 || upserts[0].args[3] != "YSL" || upserts[0].args[4] != "ysl" {
+		t.Errorf("upserts %+v, want the trimmed and normalized alias", upserts)
+	}
+	if len(fake.ran("INSERT INTO audit_log")) != 1 || len(fake.ran("INSERT INTO outbox")) != 1 || fake.commits != 1 {
+		t.Errorf("alias not audited: %+v", fake.statements)
+	}
+
+	// aliases are set by an authenticated user only
+	if _, err := SetAlias(context.Background(), &AliasReq{Kind: "brand", Uuid: "b1", Alias: "YSL"}); err != ErrUnauthenticated {
+		t.Errorf("anonymous SetAlias = %v", err)
+	}
+	if fake.rollbacks != 1 {
+		t.Errorf("%d rollbacks, want 1", fake.rollbacks)
+	}
+}
+
+func TestDeleteAlias(t *testing.T) {
+	fake := useFakeDB(t, func(query string, args []driver.Value) (*fakeResult, error) {
+		if strings.Contains(query, "FROM aliases") && args[0] == "a1" {
+			return &fakeResult{
+				columns: []string{"uuid", "kind", "entity_uuid", "alias"},
+				rows:    [][]driver.Value{{"a1", "brand", "b1", "YSL"}},
+			}, nil
+		}
+		return nil, nil
+	})
+
+	if err := DeleteAlias(testClaimsContext(), "a2"); err != ErrAliasNotFound {
+		t.Errorf("DeleteAlias of a missing alias = %v, want ErrAliasNotFound", err)
+	}
+	if len(fake.ran("DELETE FROM aliases")) != 0 || fake.rollbacks != 1 {
+		t.Errorf("missing alias deleted: %+v", fake.statements)
+	}
+
+	if err := DeleteAlias(testClaimsContext(), "a1"); err != nil {
+		t.Fatal(err)
+	}
+	audits := fake.ran("INSERT INTO audit_log")
+	if len(fake.ran("DELETE FROM aliases")) != 1 || len(audits) != 1 || fake.commits != 1 {
+		t.Fatalf("alias not deleted and audited: %+v", fake.statements)
+	}
+	if audits[0].args[2] != "brand" || audits[0].args[3] != "b1" {
+		t.Errorf("audit of %v %v, want the brand b1", audits[0].args[2], audits[0].args[3])
+	}
+}
+
+func TestResolveSearchAliases(t *testing.T) {
+	useFakeDB(t, aliasRows(map[string]string{"brand:ysl": "b1"}))
+
+	search := SearchQueryTemplateParams{Brand: "YSL", Component: "Iris"}
+	matches, err := resolveSearchAliases(&search)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if search.BrandUid != "b1" || search.Brand != "" {
+		t.Errorf("brand alias not resolved: %+v", search)
+	}
+	if search.ComponentUid != "" || search.Component != "Iris" {
+		t.Errorf("component name changed: %+v", search)
+	}
+	if len(matches) != 1 || matches[0].Kind != "brand" || matches[0].EntityUuid != "b1" {
+		t.Errorf("matches %+v", matches)
+	}
+
+	// kinds without aliases aren't looked up
+	if match, err := searchAlias("country", "USA"); match != nil || err != nil {
+		t.Errorf("searchAlias of a country = %+v, %v", match, err)
+	}
+}
+
+func TestNotesSearchAliases(t *testing.T) {
+	fake := useFakeDB(t, func(query string, args []driver.Value) (*fakeResult, error) {
+		if strings.Contains(query, "COUNT(") {
+			return fakeColumn("count", int64(1)), nil
+		}
+		return aliasRows(map[string]string{"note:бергамот": "n1"})(query, args)
+	})
+
+	obj := &NotesSearchResultV1{}
+	count, err := obj.Count(&NoteSearchParams{Query: "Бергамот"})
+	if err != nil || count != 1 {
+		t.Fatalf("Count = %d, %v", count, err)
+	}
+	counts := fake.ran("COUNT(")
+	if len(counts) != 1 || !strings.Contains(counts[0].query, "notes.uuid = $1") || counts[0].args[0] != "n1" {
+		t.Errorf("alias not searched by uuid: %+v", counts)
+	}
+
+	if _, err := obj.MakeObj(&NoteSearchParams{Query: "Бергамот"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(obj.Aliases) != 1 || obj.Aliases[0].EntityUuid != "n1" {
+		t.Errorf("aliases %+v", obj.Aliases)
+	}
+
+	// other names are matched by their names
+	if _, err := obj.Count(&NoteSearchParams{Query: "Iris", Locales: []string{"uk"}}); err != nil {
+		t.Fatal(err)
+	}
+	counts = fake.ran("COUNT(")
+	if len(counts) != 2 || !strings.Contains(counts[1].query, "translations.locale") || counts[1].args[0] != "%Iris%" {
+		t.Errorf("name not searched in the locale chain: %+v", counts[1])
+	}
+}
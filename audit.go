@@ -0,0 +1,540 @@
+package objects
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"github.com/unrolled/render"
+	"gopkg.in/gorp.v1"
+	"net/http"
+	"reflect"
+	"sort"
+	"text/template"
+	"time"
+)
+
+var (
+	ErrInvalidUpdate      = errors.New("invalid update")
+	ErrInvalidComposition = errors.New("invalid composition")
+	ErrAuditNotFound      = errors.New("audit entry not found")
+	ErrNotRevertible      = errors.New("change can't be reverted")
+	ErrRevertConflict     = errors.New("entity changed since")
+)
+
+// Actions recorded in the audit log, one per kind of mutation.
+const (
+	AuditUpdate      = "update"
+	AuditComposition = "composition"
+	AuditStatus      = "status"
+	AuditTranslation = "translation"
+	AuditAlias       = "alias"
+	AuditMerge       = "merge"
+	AuditImage       = "image"
+)
+
+// EntityUpdateReq renames the Kind item Uuid.
+type EntityUpdateReq struct {
+	Kind string `json:"kind"`
+	Uuid string `json:"id"`
+	Name string `json:"name"`
+}
+
+// CompositionItemReq is a component of a perfum under one of its notes.
+type CompositionItemReq struct {
+	NoteUuid      string `db:"note_uuid" json:"note_id"`
+	ComponentUuid string `db:"component_uuid" json:"component_id"`
+}
+
+// NameSnapshotV1 ...
+type NameSnapshotV1 struct {
+	Name string `json:"name"`
+}
+
+// StatusSnapshotV1 ...
+type StatusSnapshotV1 struct {
+	Status string `json:"status"`
+}
+
+// ImageSnapshotV1 ...
+type ImageSnapshotV1 struct {
+	ImageUuid string `json:"image_id"`
+}
+
+// CompositionSnapshotV1 ...
+type CompositionSnapshotV1 struct {
+	Components []CompositionItemReq `json:"components"`
+}
+
+// AuditChangeV1 is a field of an entity before and after a change.
+type AuditChangeV1 struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// AuditEntryV1 is a change of the Kind item EntityUuid made by Actor, the
+// user id of the request. Before and After hold the entity as a whole, Diff
+// the fields that changed.
+type AuditEntryV1 struct {
+	Uuid       string                   `db:"uuid" json:"id"`
+	Actor      string                   `db:"actor" json:"actor"`
+	Kind       string                   `db:"kind" json:"kind"`
+	EntityUuid string                   `db:"entity_uuid" json:"entity_id"`
+	Action     string                   `db:"action" json:"action"`
+	Before     sql.NullString           `db:"before" json:"-"`
+	After      sql.NullString           `db:"after" json:"-"`
+	RevertOf   sql.NullString           `db:"revert_of" json:"revert_of"`
+	CreatedAt  time.Time                `db:"created_at" json:"created_at"`
+	Diff       map[string]AuditChangeV1 `db:"-" json:"diff"`
+	Links      []LinkV1                 `db:"-" json:"links"`
+}
+
+// AuditLogV1 lists the changes of one entity, latest first.
+type AuditLogV1 struct {
+	ObjList []AuditEntryV1 `db:"-" json:"history_list"`
+	Total   int64          `db:"-" json:"total"`
+	Offset  int64          `db:"-" json:"offset"`
+	Amount  int64          `db:"-" json:"amount"`
+}
+
+// AuditQueryParams ...
+type AuditQueryParams struct {
+	PageQueryParams
+	WhereConditionString string
+}
+
+// revertKey marks the context of the mutations Revert makes with the
+// entry they revert.
+type revertKey struct{}
+
+func init() {
+	template.Must(queries.Parse(`
+{{define "insert_audit_entry"}}
+INSERT INTO audit_log (uuid, actor, kind, entity_uuid, action, before, after, revert_of, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+{{end}}
+
+{{define "select_audit_log"}}
+SELECT uuid, actor, kind, entity_uuid, action, before, after, revert_of, created_at FROM audit_log
+WHERE {{.WhereConditionString}}
+ORDER BY audit_log.created_at DESC, audit_log.uuid
+LIMIT {{.Limit}} OFFSET {{.Offset}}
+{{end}}
+
+{{define "select_audit_log_count"}}
+SELECT COUNT(*) FROM audit_log WHERE {{.WhereConditionString}}
+{{end}}
+
+{{define "select_snapshot_name"}}
+SELECT {{.Table}}.name FROM {{.Table}} WHERE {{.Table}}.uuid = $1
+{{end}}
+
+{{define "select_snapshot_status"}}
+SELECT COALESCE({{.Table}}.status, 'active') FROM {{.Table}} WHERE {{.Table}}.uuid = $1
+{{end}}
+
+{{define "select_snapshot_image"}}
+SELECT COALESCE(images.uuid, '') FROM {{.Table}}
+LEFT JOIN images ON images.id = {{.Table}}.img_id
+WHERE {{.Table}}.uuid = $1
+{{end}}
+
+{{define "select_snapshot_composition"}}
+SELECT notes.uuid AS note_uuid, components.uuid AS component_uuid FROM parfums
+JOIN parfum_info ON parfum_info.id = parfums.parfum_info_id
+JOIN notes ON notes.id = parfums.note_id
+JOIN components ON components.id = parfums.component_id
+WHERE parfum_info.uuid = $1
+ORDER BY notes.uuid, components.uuid
+{{end}}
+
+{{define "update_name"}}
+UPDATE {{.Table}} SET name = $2 WHERE {{.Table}}.uuid = $1
+{{end}}
+
+{{define "delete_composition"}}
+DELETE FROM parfums WHERE parfums.parfum_info_id = (SELECT parfum_info.id FROM parfum_info WHERE parfum_info.uuid = $1)
+{{end}}
+
+{{define "insert_composition_item"}}
+INSERT INTO parfums (parfum_info_id, note_id, component_id)
+SELECT parfum_info.id, notes.id, components.id FROM parfum_info, notes, components
+WHERE parfum_info.uuid = $1 AND notes.uuid = $2 AND components.uuid = $3
+{{end}}
+`))
+}
+
+// snapshot returns the part of the kind item uuid action changes, or nil if
+// there is no such item.
+func snapshot(exec gorp.SqlExecutor, action, kind, uuid string) (interface{}, error) {
+	queryParams := StatusQueryParams{Table: kindTables[kind]}
+	switch action {
+	case AuditUpdate:
+		query, err := renderQuery("select_snapshot_name", queryParams)
+		if err != nil {
+			return nil, err
+		}
+		name, err := exec.SelectNullStr(query, uuid)
+		if err != nil || !name.Valid {
+			return nil, err
+		}
+		return &NameSnapshotV1{Name: name.String}, nil
+	case AuditStatus:
+		query, err := renderQuery("select_snapshot_status", queryParams)
+		if err != nil {
+			return nil, err
+		}
+		status, err := exec.SelectNullStr(query, uuid)
+		if err != nil || !status.Valid {
+			return nil, err
+		}
+		return &StatusSnapshotV1{Status: status.String}, nil
+	case AuditImage:
+		query, err := renderQuery("select_snapshot_image", queryParams)
+		if err != nil {
+			return nil, err
+		}
+		var images []string
+		if _, err := exec.Select(&images, query, uuid); err != nil || len(images) == 0 {
+			return nil, err
+		}
+		return &ImageSnapshotV1{ImageUuid: images[0]}, nil
+	case AuditComposition:
+		query, err := renderQuery("select_snapshot_composition", queryParams)
+		if err != nil {
+			return nil, err
+		}
+		res := &CompositionSnapshotV1{Components: []CompositionItemReq{}}
+		if _, err := exec.Select(&res.Components, query, uuid); err != nil {
+			return nil, err
+		}
+		return res, nil
+	}
+	return nil, ErrNotRevertible
+}
+
+// recordAudit appends a change of the kind item uuid to the audit log and
+// announces it in the outbox, in the transaction of the change. The actor is
+// the user of ctx.
+func recordAudit(exec gorp.SqlExecutor, ctx context.Context, action, kind, uuid string, before, after interface{}) error {
+	claims, ok := ClaimsFromContext(ctx)
+	if !ok || claims.UserId == "" {
+		return ErrUnauthenticated
+	}
+	beforeJson, err := auditJson(before)
+	if err != nil {
+		return err
+	}
+	afterJson, err := auditJson(after)
+	if err != nil {
+		return err
+	}
+	var revertOf sql.NullString
+	if entry, ok := ctx.Value(revertKey{}).(string); ok {
+		revertOf.String, revertOf.Valid = entry, true
+	}
+	entryUuid, err := newUuid()
+	if err != nil {
+		return err
+	}
+
+	query, err := renderQuery("insert_audit_entry", nil)
+	if err != nil {
+		return err
+	}
+	if _, err := exec.Exec(query, entryUuid, claims.UserId, kind, uuid, action, beforeJson, afterJson, revertOf, time.Now().UTC()); err != nil {
+		return err
+	}
+	return recordOutbox(exec, kind, uuid, action)
+}
+
+func auditJson(v interface{}) (sql.NullString, error) {
+	if v == nil || reflect.ValueOf(v).Kind() == reflect.Ptr && reflect.ValueOf(v).IsNil() {
+		return sql.NullString{}, nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return sql.NullString{}, err
+	}
+	return sql.NullString{String: string(b), Valid: true}, nil
+}
+
+// auditDiff lists the top level fields that differ between two snapshots.
+func auditDiff(before, after sql.NullString) map[string]AuditChangeV1 {
+	fields := func(s sql.NullString) map[string]interface{} {
+		res := map[string]interface{}{}
+		if s.Valid {
+			json.Unmarshal([]byte(s.String), &res)
+		}
+		return res
+	}
+	b, a := fields(before), fields(after)
+
+	keys := []string{}
+	for k := range b {
+		keys = append(keys, k)
+	}
+	for k := range a {
+		if _, ok := b[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	diff := make(map[string]AuditChangeV1)
+	for _, k := range keys {
+		if !reflect.DeepEqual(b[k], a[k]) {
+			diff[k] = AuditChangeV1{Before: b[k], After: a[k]}
+		}
+	}
+	return diff
+}
+
+// UpdateEntity renames the item of req.
+func UpdateEntity(ctx context.Context, req *EntityUpdateReq) error {
+	if req == nil || req.Uuid == "" || req.Name == "" {
+		return ErrInvalidUpdate
+	}
+	table, ok := kindTables[req.Kind]
+	if !ok {
+		return ErrInvalidUpdate
+	}
+	query, err := renderQuery("update_name", StatusQueryParams{Table: table})
+	if err != nil {
+		return err
+	}
+
+	tx, err := dbmap.Begin()
+	if err != nil {
+		return err
+	}
+	before, err := snapshot(tx, AuditUpdate, req.Kind, req.Uuid)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if before == nil {
+		tx.Rollback()
+		return ErrInvalidUpdate
+	}
+	if _, err := tx.Exec(query, req.Uuid, req.Name); err != nil {
+		tx.Rollback()
+		return err
+	}
+	after := &NameSnapshotV1{Name: req.Name}
+	if err := recordAudit(tx, ctx, AuditUpdate, req.Kind, req.Uuid, before, after); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// SetPerfumComposition replaces the composition of the perfum perfumUuid by
+// items.
+func SetPerfumComposition(ctx context.Context, perfumUuid string, items []CompositionItemReq) error {
+	deleteQuery, err := renderQuery("delete_composition", nil)
+	if err != nil {
+		return err
+	}
+	insertQuery, err := renderQuery("insert_composition_item", nil)
+	if err != nil {
+		return err
+	}
+
+	tx, err := dbmap.Begin()
+	if err != nil {
+		return err
+	}
+	if name, err := snapshot(tx, AuditUpdate, "perfum", perfumUuid); err != nil || name == nil {
+		tx.Rollback()
+		if err != nil {
+			return err
+		}
+		return ErrPerfumNotFound
+	}
+	before, err := snapshot(tx, AuditComposition, "perfum", perfumUuid)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if _, err := tx.Exec(deleteQuery, perfumUuid); err != nil {
+		tx.Rollback()
+		return err
+	}
+	for _, item := range items {
+		res, err := tx.Exec(insertQuery, perfumUuid, item.NoteUuid, item.ComponentUuid)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		if n, err := res.RowsAffected(); err != nil || n == 0 {
+			tx.Rollback()
+			if err != nil {
+				return err
+			}
+			return ErrInvalidComposition
+		}
+	}
+	after, err := snapshot(tx, AuditComposition, "perfum", perfumUuid)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := recordAudit(tx, ctx, AuditComposition, "perfum", perfumUuid, before, after); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// Revert undoes the change entryUuid, provided the entity is still as the
+// change left it. The revert is a change of its own, logged with revert_of
+// set.
+func Revert(ctx context.Context, entryUuid string) error {
+	args := bindArgs{}
+	queryParams := AuditQueryParams{
+		PageQueryParams:      PageQueryParams{Limit: 1},
+		WhereConditionString: "audit_log.uuid = " + args.add(entryUuid),
+	}
+	query, err := renderQuery("select_audit_log", queryParams)
+	if err != nil {
+		return err
+	}
+	var entries []AuditEntryV1
+	if _, err := dbmap.Select(&entries, query, args...); err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		return ErrAuditNotFound
+	}
+	entry := entries[0]
+	if !entry.Before.Valid {
+		return ErrNotRevertible
+	}
+
+	current, err := snapshot(dbmap, entry.Action, entry.Kind, entry.EntityUuid)
+	if err != nil {
+		return err
+	}
+	currentJson, err := auditJson(current)
+	if err != nil {
+		return err
+	}
+	if len(auditDiff(currentJson, entry.After)) > 0 {
+		return ErrRevertConflict
+	}
+
+	ctx = context.WithValue(ctx, revertKey{}, entry.Uuid)
+	switch entry.Action {
+	case AuditUpdate:
+		var before NameSnapshotV1
+		if err := json.Unmarshal([]byte(entry.Before.String), &before); err != nil {
+			return err
+		}
+		return UpdateEntity(ctx, &EntityUpdateReq{Kind: entry.Kind, Uuid: entry.EntityUuid, Name: before.Name})
+	case AuditStatus:
+		var before StatusSnapshotV1
+		if err := json.Unmarshal([]byte(entry.Before.String), &before); err != nil {
+			return err
+		}
+		return SetStatus(ctx, entry.Kind, entry.EntityUuid, before.Status)
+	case AuditComposition:
+		var before CompositionSnapshotV1
+		if err := json.Unmarshal([]byte(entry.Before.String), &before); err != nil {
+			return err
+		}
+		return SetPerfumComposition(ctx, entry.EntityUuid, before.Components)
+	}
+	return ErrNotRevertible
+}
+
+func NewAuditLogFactory(version string) (Objecter, error) {
+	return NewObjecter("history", version)
+}
+
+// MakeObj lists the changes of the entity params.Id, latest first.
+func (obj *AuditLogV1) MakeObj(pParams interface{}) (Objecter, error) {
+	if pParams == nil {
+		return nil, errors.New("invalid args")
+	}
+	params := pParams.(*MakeObjParams)
+	if params.Id == "" {
+		return nil, errors.New("invalid args")
+	}
+
+	args := bindArgs{}
+	queryParams := AuditQueryParams{
+		PageQueryParams:      newPageQueryParams("audit_log", &params.Base),
+		WhereConditionString: "audit_log.entity_uuid = " + args.add(params.Id),
+	}
+	query, err := renderQuery("select_audit_log", queryParams)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := dbmap.Select(&obj.ObjList, query, args...); err != nil {
+		return nil, err
+	}
+
+	for i := range obj.ObjList {
+		entry := &obj.ObjList[i]
+		entry.Diff = auditDiff(entry.Before, entry.After)
+		entry.Links = []LinkV1{
+			LinkV1{
+				Href:   baseUrl + "/" + entry.Kind + "/" + entry.EntityUuid,
+				Rel:    "EntityInfo",
+				Method: "GET",
+			},
+		}
+		if entry.Before.Valid && (entry.Action == AuditUpdate || entry.Action == AuditStatus || entry.Action == AuditComposition) {
+			entry.Links = append(entry.Links, LinkV1{
+				Href:   baseUrl + "/history/" + entry.Uuid + "/revert",
+				Rel:    "HistoryRevert",
+				Method: "POST",
+			})
+		}
+	}
+
+	obj.Total = params.Total
+	obj.Offset = queryParams.Offset
+	obj.Amount = int64(len(obj.ObjList))
+
+	return obj, nil
+}
+
+func (obj *AuditLogV1) MakeExtraObj(params *MakeObjParams, uids []string) (Objecter, error) {
+	return nil, errors.New("not supported")
+}
+
+// Count counts the changes of the entity params.Id.
+func (obj *AuditLogV1) Count(pParams interface{}) (int64, error) {
+	if pParams == nil {
+		return 0, errors.New("invalid args")
+	}
+	params := pParams.(*MakeObjParams)
+	if params.Id == "" {
+		return 0, errors.New("invalid args")
+	}
+
+	args := bindArgs{}
+	queryParams := AuditQueryParams{WhereConditionString: "audit_log.entity_uuid = " + args.add(params.Id)}
+	query, err := renderQuery("select_audit_log_count", queryParams)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := dbmap.SelectInt(query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (obj *AuditLogV1) ExtraCount(uids []string) (int64, error) {
+	return 0, errors.New("not supported")
+}
+
+func (obj *AuditLogV1) Json(w http.ResponseWriter, status int) error {
+	render := render.New()
+	return render.JSON(w, status, obj)
+}
@@ -0,0 +1,107 @@
+package objects
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAuditDiff(t *testing.T) {
+	null := sql.NullString{}
+	valid := func(s string) sql.NullString { return sql.NullString{String: s, Valid: true} }
+
+	diff := auditDiff(valid(`{"name":"Chanel","status":"active"}`), valid(`{"name":"CHANEL","status":"active"}`))
+	if len(diff) != 1 || diff["name"].Before != "Chanel" || diff["name"].After != "CHANEL" {
+		t.Errorf("rename diff %+v", diff)
+	}
+
+	// a created or dropped entity differs in every field
+	diff = auditDiff(null, valid(`{"alias":"YSL"}`))
+	if len(diff) != 1 || diff["alias"].Before != nil || diff["alias"].After != "YSL" {
+		t.Errorf("creation diff %+v", diff)
+	}
+	if diff := auditDiff(valid(`{"alias":"YSL"}`), null); len(diff) != 1 || diff["alias"].After != nil {
+		t.Errorf("removal diff %+v", diff)
+	}
+
+	// nested values are compared as a whole
+	composition := `{"components":[{"note_id":"n1","component_id":"c1"}]}`
+	if diff := auditDiff(valid(composition), valid(composition)); len(diff) != 0 {
+		t.Errorf("unchanged composition diff %+v", diff)
+	}
+	if diff := auditDiff(valid(composition), valid(`{"components":[]}`)); len(diff) != 1 {
+		t.Errorf("composition diff %+v", diff)
+	}
+}
+
+// auditRows answers the audit log lookup of Revert with entry and the name
+// snapshots of the brand b1 with name.
+func auditRows(entry []driver.Value, name string) func(string, []driver.Value) (*fakeResult, error) {
+	return func(query string, args []driver.Value) (*fakeResult, error) {
+		switch {
+		case strings.Contains(query, "FROM audit_log"):
+			if entry == nil {
+				return nil, nil
+			}
+			return &fakeResult{
+				columns: []string{"uuid", "actor", "kind", "entity_uuid", "action", "before", "after", "revert_of", "created_at"},
+				rows:    [][]driver.Value{entry},
+			}, nil
+		case strings.Contains(query, "SELECT brands.name"):
+			return fakeColumn("name", name), nil
+		}
+		return nil, nil
+	}
+}
+
+func TestRevert(t *testing.T) {
+	rename := []driver.Value{"e1", "u2", "brand", "b1", AuditUpdate, `{"name":"Chanel"}`, `{"name":"CHANEL"}`, nil, time.Now()}
+
+	fake := useFakeDB(t, auditRows(rename, "CHANEL"))
+	if err := Revert(testClaimsContext(), "e1"); err != nil {
+		t.Fatal(err)
+	}
+	updates := fake.ran("UPDATE brands SET name")
+	if len(updates) != 1 || updates[0].args[1] != "Chanel" {
+		t.Errorf("updates %+v, want the name before", updates)
+	}
+	audits := fake.ran("INSERT INTO audit_log")
+	if len(audits) != 1 || audits[0].args[7] != "e1" || audits[0].args[1] != "u1" {
+		t.Errorf("revert not audited as one: %+v", audits)
+	}
+}
+
+func TestRevertConflict(t *testing.T) {
+	rename := []driver.Value{"e1", "u2", "brand", "b1", AuditUpdate, `{"name":"Chanel"}`, `{"name":"CHANEL"}`, nil, time.Now()}
+
+	// renamed again since
+	fake := useFakeDB(t, auditRows(rename, "Chanel Paris"))
+	if err := Revert(testClaimsContext(), "e1"); err != ErrRevertConflict {
+		t.Errorf("Revert of a changed entity = %v, want ErrRevertConflict", err)
+	}
+	if len(fake.ran("UPDATE")) != 0 || len(fake.ran("INSERT")) != 0 {
+		t.Errorf("conflicting revert changed %+v", fake.statements)
+	}
+}
+
+func TestRevertNotRevertible(t *testing.T) {
+	useFakeDB(t, auditRows(nil, ""))
+	if err := Revert(testClaimsContext(), "e1"); err != ErrAuditNotFound {
+		t.Errorf("Revert of no entry = %v, want ErrAuditNotFound", err)
+	}
+
+	// nothing to go back to
+	alias := []driver.Value{"e2", "u2", "brand", "b1", AuditAlias, nil, `{"alias":"YSL"}`, nil, time.Now()}
+	useFakeDB(t, auditRows(alias, ""))
+	if err := Revert(testClaimsContext(), "e2"); err != ErrNotRevertible {
+		t.Errorf("Revert of a creation = %v, want ErrNotRevertible", err)
+	}
+
+	merge := []driver.Value{"e3", "u2", "brand", "b1", AuditMerge, `{}`, `{}`, nil, time.Now()}
+	useFakeDB(t, auditRows(merge, ""))
+	if err := Revert(testClaimsContext(), "e3"); err != ErrNotRevertible {
+		t.Errorf("Revert of a merge = %v, want ErrNotRevertible", err)
+	}
+}
@@ -0,0 +1,565 @@
+package objects
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"github.com/golang-jwt/jwt"
+	"github.com/unrolled/render"
+	"net/http"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+)
+
+var (
+	ErrUserNotFound        = errors.New("user not found")
+	ErrInvalidAuthCode     = errors.New("invalid auth code")
+	ErrInvalidToken        = errors.New("invalid token")
+	ErrTokenExpired        = errors.New("token expired")
+	ErrRefreshTokenReused  = errors.New("refresh token reused")
+	ErrMissingBearerToken  = errors.New("missing bearer token")
+	errRefreshTokenMissing = errors.New("refresh token not found")
+)
+
+const (
+	defaultAccessTokenTTL  = 15 * time.Minute
+	defaultRefreshTokenTTL = 30 * 24 * time.Hour
+)
+
+// Identity is the external account an auth_code or id token was issued for.
+type Identity struct {
+	Issuer  string
+	Subject string
+}
+
+// AuthCodeExchanger redeems an auth_code at the identity provider.
+type AuthCodeExchanger interface {
+	Exchange(ctx context.Context, code string) (Identity, error)
+}
+
+// User ...
+type User struct {
+	UserId    string    `db:"uuid"`
+	Issuer    string    `db:"issuer"`
+	Subject   string    `db:"subject"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// RefreshToken is stored by hash only, the token itself is handed out once.
+type RefreshToken struct {
+	Hash      string    `db:"token_hash"`
+	UserId    string    `db:"user_uuid"`
+	ExpiresAt time.Time `db:"expires_at"`
+	Revoked   bool      `db:"revoked"`
+}
+
+// UserStore keeps the local users and their refresh tokens.
+type UserStore interface {
+	UserById(userId string) (*User, error)
+	UserByIdentity(identity Identity) (*User, error)
+	CreateUser(user *User) error
+	SaveRefreshToken(token *RefreshToken) error
+	RefreshToken(hash string) (*RefreshToken, error)
+	// RevokeRefreshToken reports false if the token was already revoked.
+	RevokeRefreshToken(hash string) (bool, error)
+	RevokeUserRefreshTokens(userId string) error
+}
+
+// AuthConfig ...
+type AuthConfig struct {
+	Issuer     string
+	Audience   string
+	KeyId      string
+	PrivateKey *rsa.PrivateKey
+	AccessTTL  time.Duration
+	RefreshTTL time.Duration
+	// Now is used in place of time.Now when set.
+	Now func() time.Time
+}
+
+// AuthService exchanges auth codes for access and refresh tokens and
+// verifies the access tokens it signed.
+type AuthService struct {
+	config AuthConfig
+	users  UserStore
+	codes  AuthCodeExchanger
+}
+
+func NewAuthService(config AuthConfig, users UserStore, codes AuthCodeExchanger) (*AuthService, error) {
+	if config.PrivateKey == nil || users == nil || codes == nil {
+		return nil, errors.New("invalid args")
+	}
+	if config.AccessTTL == 0 {
+		config.AccessTTL = defaultAccessTokenTTL
+	}
+	if config.RefreshTTL == 0 {
+		config.RefreshTTL = defaultRefreshTokenTTL
+	}
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+
+	return &AuthService{config: config, users: users, codes: codes}, nil
+}
+
+// Login redeems req.AuthcodeString, creating the local user on first login.
+func (a *AuthService) Login(ctx context.Context, req *LoginReq) (*LoginResp, error) {
+	if req == nil || req.AuthcodeString == "" {
+		return nil, ErrInvalidAuthCode
+	}
+
+	identity, err := a.codes.Exchange(ctx, req.AuthcodeString)
+	if err != nil {
+		return nil, err
+	}
+	user, err := a.userFor(identity)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.issue(user)
+}
+
+// Refresh rotates refreshToken: it is revoked and a new pair is issued.
+// Presenting a revoked token again revokes every token of its user.
+func (a *AuthService) Refresh(ctx context.Context, refreshToken string) (*LoginResp, error) {
+	hash := hashRefreshToken(refreshToken)
+	token, err := a.users.RefreshToken(hash)
+	if err == errRefreshTokenMissing {
+		return nil, ErrInvalidToken
+	} else if err != nil {
+		return nil, err
+	}
+
+	if token.Revoked {
+		if err := a.users.RevokeUserRefreshTokens(token.UserId); err != nil {
+			return nil, err
+		}
+		return nil, ErrRefreshTokenReused
+	}
+	if !a.config.Now().Before(token.ExpiresAt) {
+		return nil, ErrTokenExpired
+	}
+
+	revoked, err := a.users.RevokeRefreshToken(hash)
+	if err != nil {
+		return nil, err
+	}
+	if !revoked {
+		// lost a race against another refresh with the same token
+		if err := a.users.RevokeUserRefreshTokens(token.UserId); err != nil {
+			return nil, err
+		}
+		return nil, ErrRefreshTokenReused
+	}
+
+	user, err := a.users.UserById(token.UserId)
+	if err != nil {
+		return nil, err
+	}
+	return a.issue(user)
+}
+
+// VerifyAccessToken checks the signature, issuer, audience and lifetime of
+// an access token issued by Login or Refresh.
+func (a *AuthService) VerifyAccessToken(accessToken string) (*IdTokenClaims, error) {
+	claims := &IdTokenClaims{}
+	parser := jwt.Parser{
+		ValidMethods:         []string{jwt.SigningMethodRS256.Alg()},
+		SkipClaimsValidation: true,
+	}
+	_, err := parser.ParseWithClaims(accessToken, claims, func(*jwt.Token) (interface{}, error) {
+		return &a.config.PrivateKey.PublicKey, nil
+	})
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	if claims.Iss != a.config.Issuer || !claims.Aud.Has(a.config.Audience) || claims.UserId == "" {
+		return nil, ErrInvalidToken
+	}
+	if err := claims.validAt(a.config.Now(), 0); err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+// UserInfo ...
+func (a *AuthService) UserInfo(userId string) (*UserResp, error) {
+	user, err := a.users.UserById(userId)
+	if err != nil {
+		return nil, err
+	}
+	return NewUserResp(user), nil
+}
+
+func (a *AuthService) userFor(identity Identity) (*User, error) {
+	return userForIdentity(a.users, identity, "", a.config.Now())
+}
+
+// userForIdentity finds the local user of identity, creating it if needed.
+// A new user gets userId if one is given, a fresh uuid otherwise.
+func userForIdentity(users UserStore, identity Identity, userId string, now time.Time) (*User, error) {
+	user, err := users.UserByIdentity(identity)
+	if err != ErrUserNotFound {
+		return user, err
+	}
+
+	if userId == "" {
+		if userId, err = newUuid(); err != nil {
+			return nil, err
+		}
+	}
+	user = &User{
+		UserId:    userId,
+		Issuer:    identity.Issuer,
+		Subject:   identity.Subject,
+		CreatedAt: now.UTC(),
+		UpdatedAt: now.UTC(),
+	}
+	if err := users.CreateUser(user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (a *AuthService) issue(user *User) (*LoginResp, error) {
+	now := a.config.Now()
+	claims := &IdTokenClaims{
+		Iss:    a.config.Issuer,
+		Sub:    user.Subject,
+		Aud:    Audience{a.config.Audience},
+		Iat:    float64(now.Unix()),
+		Exp:    float64(now.Add(a.config.AccessTTL).Unix()),
+		UserId: user.UserId,
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	if a.config.KeyId != "" {
+		token.Header["kid"] = a.config.KeyId
+	}
+	accessToken, err := token.SignedString(a.config.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	refreshToken := base64.RawURLEncoding.EncodeToString(b)
+	err = a.users.SaveRefreshToken(&RefreshToken{
+		Hash:      hashRefreshToken(refreshToken),
+		UserId:    user.UserId,
+		ExpiresAt: now.Add(a.config.RefreshTTL).UTC(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &LoginResp{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		UserId:       user.UserId,
+	}, nil
+}
+
+func hashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// Valid implements jwt.Claims.
+func (c *IdTokenClaims) Valid() error {
+	return c.validAt(jwt.TimeFunc(), 0)
+}
+
+// validAt checks exp and iat against now, allowing for skew either way.
+func (c *IdTokenClaims) validAt(now time.Time, skew time.Duration) error {
+	t := float64(now.Unix())
+	if c.Exp == 0 || t >= c.Exp+skew.Seconds() {
+		return ErrTokenExpired
+	}
+	if c.Iat > t+skew.Seconds() {
+		return ErrInvalidToken
+	}
+	return nil
+}
+
+func NewUserResp(user *User) *UserResp {
+	return &UserResp{
+		UserId:    user.UserId,
+		CreatedAt: user.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt: user.UpdatedAt.UTC().Format(time.RFC3339),
+		Links: []LinkV1{
+			LinkV1{
+				Href:   baseUrl + "/user/" + user.UserId,
+				Rel:    "UserInfo",
+				Method: "GET",
+			},
+			LinkV1{
+				Href:   baseUrl + "/user/" + user.UserId + "/perfums",
+				Rel:    "UserPerfums",
+				Method: "GET",
+			},
+		},
+	}
+}
+
+type claimsKey struct{}
+
+// ClaimsFromContext returns the claims Middleware stored for the request.
+func ClaimsFromContext(ctx context.Context) (*IdTokenClaims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(*IdTokenClaims)
+	return claims, ok
+}
+
+// ContextWithClaims ...
+func ContextWithClaims(ctx context.Context, claims *IdTokenClaims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+// Middleware rejects requests without a valid "Authorization: Bearer"
+// access token and passes the claims on in the request context.
+func (a *AuthService) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, err := a.requestClaims(r)
+		if err != nil {
+			render := render.New()
+			render.JSON(w, http.StatusUnauthorized, map[string]string{"error": err.Error()})
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(ContextWithClaims(r.Context(), claims)))
+	})
+}
+
+func (a *AuthService) requestClaims(r *http.Request) (*IdTokenClaims, error) {
+	header := r.Header.Get("Authorization")
+	if !strings.HasPrefix(header, "Bearer ") {
+		return nil, ErrMissingBearerToken
+	}
+	return a.VerifyAccessToken(strings.TrimSpace(header[len("Bearer "):]))
+}
+
+// MemoryUserStore is a UserStore kept in memory, for tests and single
+// process setups.
+type MemoryUserStore struct {
+	mu     sync.Mutex
+	users  map[string]User
+	tokens map[string]RefreshToken
+}
+
+func NewMemoryUserStore() *MemoryUserStore {
+	return &MemoryUserStore{
+		users:  make(map[string]User),
+		tokens: make(map[string]RefreshToken),
+	}
+}
+
+func (s *MemoryUserStore) UserById(userId string) (*User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user, ok := s.users[userId]
+	if !ok {
+		return nil, ErrUserNotFound
+	}
+	return &user, nil
+}
+
+func (s *MemoryUserStore) UserByIdentity(identity Identity) (*User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, user := range s.users {
+		if user.Issuer == identity.Issuer && user.Subject == identity.Subject {
+			return &user, nil
+		}
+	}
+	return nil, ErrUserNotFound
+}
+
+func (s *MemoryUserStore) CreateUser(user *User) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.users[user.UserId]; ok {
+		return errors.New("user exists")
+	}
+	s.users[user.UserId] = *user
+	return nil
+}
+
+func (s *MemoryUserStore) SaveRefreshToken(token *RefreshToken) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokens[token.Hash] = *token
+	return nil
+}
+
+func (s *MemoryUserStore) RefreshToken(hash string) (*RefreshToken, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	token, ok := s.tokens[hash]
+	if !ok {
+		return nil, errRefreshTokenMissing
+	}
+	return &token, nil
+}
+
+func (s *MemoryUserStore) RevokeRefreshToken(hash string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	token, ok := s.tokens[hash]
+	if !ok || token.Revoked {
+		return false, nil
+	}
+	token.Revoked = true
+	s.tokens[hash] = token
+	return true, nil
+}
+
+func (s *MemoryUserStore) RevokeUserRefreshTokens(userId string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for hash, token := range s.tokens {
+		if token.UserId == userId {
+			token.Revoked = true
+			s.tokens[hash] = token
+		}
+	}
+	return nil
+}
+
+// DbUserStore keeps users in the users and refresh_tokens tables.
+type DbUserStore struct{}
+
+func init() {
+	template.Must(queries.Parse(`
+{{define "select_user_by_uuid"}}
+SELECT uuid, issuer, subject, created_at, updated_at FROM users
+WHERE uuid = $1
+{{end}}
+
+{{define "select_user_by_identity"}}
+SELECT uuid, issuer, subject, created_at, updated_at FROM users
+WHERE issuer = $1 AND subject = $2
+{{end}}
+
+{{define "insert_user"}}
+INSERT INTO users (uuid, issuer, subject, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5)
+{{end}}
+
+{{define "insert_refresh_token"}}
+INSERT INTO refresh_tokens (token_hash, user_uuid, expires_at, revoked)
+VALUES ($1, $2, $3, $4)
+{{end}}
+
+{{define "select_refresh_token"}}
+SELECT token_hash, user_uuid, expires_at, revoked FROM refresh_tokens
+WHERE token_hash = $1
+{{end}}
+
+{{define "revoke_refresh_token"}}
+UPDATE refresh_tokens SET revoked = true
+WHERE token_hash = $1 AND NOT revoked
+{{end}}
+
+{{define "revoke_user_refresh_tokens"}}
+UPDATE refresh_tokens SET revoked = true
+WHERE user_uuid = $1
+{{end}}
+`))
+}
+
+func (s DbUserStore) UserById(userId string) (*User, error) {
+	return s.selectUser("select_user_by_uuid", userId)
+}
+
+func (s DbUserStore) UserByIdentity(identity Identity) (*User, error) {
+	return s.selectUser("select_user_by_identity", identity.Issuer, identity.Subject)
+}
+
+func (s DbUserStore) selectUser(name string, args ...interface{}) (*User, error) {
+	query, err := renderQuery(name, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	user := &User{}
+	if err := dbmap.SelectOne(user, query, args...); err == sql.ErrNoRows {
+		return nil, ErrUserNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (s DbUserStore) CreateUser(user *User) error {
+	query, err := renderQuery("insert_user", nil)
+	if err != nil {
+		return err
+	}
+	_, err = dbmap.Exec(query, user.UserId, user.Issuer, user.Subject, user.CreatedAt, user.UpdatedAt)
+	return err
+}
+
+func (s DbUserStore) SaveRefreshToken(token *RefreshToken) error {
+	query, err := renderQuery("insert_refresh_token", nil)
+	if err != nil {
+		return err
+	}
+	_, err = dbmap.Exec(query, token.Hash, token.UserId, token.ExpiresAt, token.Revoked)
+	return err
+}
+
+func (s DbUserStore) RefreshToken(hash string) (*RefreshToken, error) {
+	query, err := renderQuery("select_refresh_token", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	token := &RefreshToken{}
+	if err := dbmap.SelectOne(token, query, hash); err == sql.ErrNoRows {
+		return nil, errRefreshTokenMissing
+	} else if err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+func (s DbUserStore) RevokeRefreshToken(hash string) (bool, error) {
+	query, err := renderQuery("revoke_refresh_token", nil)
+	if err != nil {
+		return false, err
+	}
+	res, err := dbmap.Exec(query, hash)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n == 1, err
+}
+
+func (s DbUserStore) RevokeUserRefreshTokens(userId string) error {
+	query, err := renderQuery("revoke_user_refresh_tokens", nil)
+	if err != nil {
+		return err
+	}
+	_, err = dbmap.Exec(query, userId)
+	return err
+}
@@ -0,0 +1,175 @@
+package objects
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// fakeCodes redeems the codes it knows, standing in for the identity
+// provider.
+type fakeCodes map[string]Identity
+
+func (c fakeCodes) Exchange(ctx context.Context, code string) (Identity, error) {
+	identity, ok := c[code]
+	if !ok {
+		return Identity{}, ErrInvalidAuthCode
+	}
+	return identity, nil
+}
+
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+func newTestAuth(t *testing.T) (*AuthService, *testClock) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clock := &testClock{now: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	codes := fakeCodes{"code": {Issuer: "https://idp.test", Subject: "alice"}}
+	auth, err := NewAuthService(AuthConfig{
+		Issuer:     "objects",
+		Audience:   "objects-api",
+		KeyId:      "test",
+		PrivateKey: key,
+		Now:        clock.Now,
+	}, NewMemoryUserStore(), codes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return auth, clock
+}
+
+func TestAuthLogin(t *testing.T) {
+	auth, clock := newTestAuth(t)
+
+	resp, err := auth.Login(context.Background(), &LoginReq{AuthcodeString: "code"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims, err := auth.VerifyAccessToken(resp.AccessToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claims.UserId != resp.UserId || claims.Sub != "alice" {
+		t.Fatalf("unexpected claims %+v", claims)
+	}
+
+	again, err := auth.Login(context.Background(), &LoginReq{AuthcodeString: "code"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.UserId != resp.UserId {
+		t.Fatalf("second login created user %q, want %q", again.UserId, resp.UserId)
+	}
+
+	if _, err := auth.Login(context.Background(), &LoginReq{AuthcodeString: "bad"}); err != ErrInvalidAuthCode {
+		t.Fatalf("login with bad code = %v, want %v", err, ErrInvalidAuthCode)
+	}
+
+	clock.now = clock.now.Add(defaultAccessTokenTTL)
+	if _, err := auth.VerifyAccessToken(resp.AccessToken); err != ErrTokenExpired {
+		t.Fatalf("verify after ttl = %v, want %v", err, ErrTokenExpired)
+	}
+}
+
+func TestAuthVerifyForeignToken(t *testing.T) {
+	auth, _ := newTestAuth(t)
+	other, _ := newTestAuth(t)
+
+	resp, err := other.Login(context.Background(), &LoginReq{AuthcodeString: "code"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := auth.VerifyAccessToken(resp.AccessToken); err != ErrInvalidToken {
+		t.Fatalf("verify token of another key = %v, want %v", err, ErrInvalidToken)
+	}
+}
+
+func TestAuthRefresh(t *testing.T) {
+	auth, clock := newTestAuth(t)
+
+	first, err := auth.Login(context.Background(), &LoginReq{AuthcodeString: "code"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := auth.Refresh(context.Background(), first.RefreshToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second.UserId != first.UserId || second.RefreshToken == first.RefreshToken {
+		t.Fatalf("unexpected refresh %+v", second)
+	}
+
+	// presenting the rotated token again revokes the whole family
+	if _, err := auth.Refresh(context.Background(), first.RefreshToken); err != ErrRefreshTokenReused {
+		t.Fatalf("reused refresh = %v, want %v", err, ErrRefreshTokenReused)
+	}
+	if _, err := auth.Refresh(context.Background(), second.RefreshToken); err != ErrRefreshTokenReused {
+		t.Fatalf("refresh after reuse = %v, want %v", err, ErrRefreshTokenReused)
+	}
+
+	third, err := auth.Login(context.Background(), &LoginReq{AuthcodeString: "code"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	clock.now = clock.now.Add(defaultRefreshTokenTTL)
+	if _, err := auth.Refresh(context.Background(), third.RefreshToken); err != ErrTokenExpired {
+		t.Fatalf("expired refresh = %v, want %v", err, ErrTokenExpired)
+	}
+
+	if _, err := auth.Refresh(context.Background(), "unknown"); err != ErrInvalidToken {
+		t.Fatalf("unknown refresh = %v, want %v", err, ErrInvalidToken)
+	}
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	auth, _ := newTestAuth(t)
+	resp, err := auth.Login(context.Background(), &LoginReq{AuthcodeString: "code"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var userId string
+	handler := auth.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := ClaimsFromContext(r.Context())
+		if !ok {
+			t.Error("no claims in context")
+			return
+		}
+		userId = claims.UserId
+	}))
+
+	tests := []struct {
+		header string
+		status int
+	}{
+		{"", http.StatusUnauthorized},
+		{"Bearer garbage", http.StatusUnauthorized},
+		{"Bearer " + resp.AccessToken, http.StatusOK},
+	}
+	for _, test := range tests {
+		r := httptest.NewRequest("GET", "/user", nil)
+		if test.header != "" {
+			r.Header.Set("Authorization", test.header)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		if w.Code != test.status {
+			t.Errorf("Authorization %q: status %d, want %d", test.header, w.Code, test.status)
+		}
+	}
+	if userId != resp.UserId {
+		t.Fatalf("handler saw user %q, want %q", userId, resp.UserId)
+	}
+}
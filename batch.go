@@ -0,0 +1,221 @@
+package objects
+
+import (
+	"errors"
+	"github.com/unrolled/render"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// BatchItemReq names one entity to resolve in a batch.
+type BatchItemReq struct {
+	Kind string `json:"kind"`
+	Uuid string `json:"id"`
+}
+
+// BatchParams ...
+type BatchParams struct {
+	Base    BaseParams
+	Locales []string
+	Items   []BatchItemReq
+}
+
+// BatchItemV1 carries either the resolved object or the reason it is missing.
+type BatchItemV1 struct {
+	Kind   string      `json:"kind"`
+	Uuid   string      `json:"id"`
+	Object interface{} `json:"object,omitempty"`
+	Error  string      `json:"error,omitempty"`
+}
+
+// BatchV1 ...
+type BatchV1 struct {
+	ObjList []BatchItemV1 `db:"-" json:"batch_list"`
+	Total   int64         `db:"-" json:"total"`
+	Offset  int64         `db:"-" json:"offset"`
+	Amount  int64         `db:"-" json:"amount"`
+}
+
+type batchKind struct {
+	collection string
+}
+
+// batchKinds maps the kind names used in links (/brand/{uuid}, ...) to the
+// collection that resolves them.
+var batchKinds = map[string]batchKind{
+	"perfum":      {collection: "perfums_info"},
+	"composition": {collection: "perfums_composition"},
+	"brand":       {collection: "brands"},
+	"component":   {collection: "components"},
+	"country":     {collection: "countries"},
+	"gender":      {collection: "genders"},
+	"group":       {collection: "groups"},
+	"note":        {collection: "notes"},
+	"perfumer":    {collection: "perfumers"},
+	"season":      {collection: "seasons"},
+	"tag":         {collection: "tags"},
+	"timeofday":   {collection: "times_of_day"},
+	"type":        {collection: "types"},
+}
+
+func NewBatchFactory(version string) (Objecter, error) {
+	return NewObjecter("batch", version)
+}
+
+func (obj *BatchV1) MakeObj(pParams interface{}) (Objecter, error) {
+	if pParams == nil {
+		return nil, errors.New("invalid args")
+	}
+
+	params := pParams.(*BatchParams)
+
+	found, failed, err := loadBatch(params.Base.Version, params.Locales, params.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range params.Items {
+		res := BatchItemV1{Kind: item.Kind, Uuid: item.Uuid}
+		if _, known := batchKinds[item.Kind]; !known {
+			res.Error = "unknown kind"
+		} else if err, ok := failed[item.Kind]; ok {
+			res.Error = err.Error()
+		} else if object, ok := found[item.Kind][item.Uuid]; ok {
+			res.Object = object
+		} else {
+			res.Error = "not found"
+		}
+		obj.ObjList = append(obj.ObjList, res)
+	}
+
+	obj.Total = int64(len(params.Items))
+	obj.Offset = 0
+	obj.Amount = int64(len(obj.ObjList))
+
+	return obj, nil
+}
+
+func (obj *BatchV1) MakeExtraObj(params *MakeObjParams, uids []string) (Objecter, error) {
+	return obj, nil
+}
+
+func (obj *BatchV1) Count(pParams interface{}) (int64, error) {
+	if pParams == nil {
+		return 0, errors.New("invalid args")
+	}
+
+	params := pParams.(*BatchParams)
+	return int64(len(params.Items)), nil
+}
+
+func (obj *BatchV1) ExtraCount(uids []string) (int64, error) {
+	return 0, nil
+}
+
+func (obj *BatchV1) Json(w http.ResponseWriter, status int) error {
+	render := render.New()
+	return render.JSON(w, status, obj)
+}
+
+// LoadBatch resolves items with one query per kind and returns the found
+// objects indexed by kind and uuid. Unknown kinds and missing uuids are
+// simply absent from the result.
+func LoadBatch(version string, items []BatchItemReq) (map[string]map[string]interface{}, error) {
+	return LoadBatchLocalized(version, nil, items)
+}
+
+// LoadBatchLocalized is LoadBatch with names and descriptions served in the
+// best of locales.
+func LoadBatchLocalized(version string, locales []string, items []BatchItemReq) (map[string]map[string]interface{}, error) {
+	found, _, err := loadBatch(version, locales, items)
+	return found, err
+}
+
+// loadBatch is LoadBatchLocalized also returning the kinds that are not
+// served in version, with the reason, instead of failing on them.
+func loadBatch(version string, locales []string, items []BatchItemReq) (map[string]map[string]interface{}, map[string]error, error) {
+	byKind := make(map[string][]string)
+	for _, item := range items {
+		if _, known := batchKinds[item.Kind]; !known || item.Uuid == "" {
+			continue
+		}
+		byKind[item.Kind] = appendUnique(byKind[item.Kind], item.Uuid)
+	}
+
+	kinds := make([]string, 0, len(byKind))
+	for kind := range byKind {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+
+	found := make(map[string]map[string]interface{})
+	failed := make(map[string]error)
+	for _, kind := range kinds {
+		factory, err := NewObjecter(batchKinds[kind].collection, version)
+		if err != nil {
+			failed[kind] = err
+			continue
+		}
+		objs, err := loadKind(factory, version, locales, byKind[kind])
+		if err != nil {
+			return nil, nil, err
+		}
+		found[kind] = objs
+	}
+
+	return found, failed, nil
+}
+
+func loadKind(factory Objecter, version string, locales []string, uuids []string) (map[string]interface{}, error) {
+	params := &MakeObjParams{Total: int64(len(uuids)), Locales: locales}
+	params.Base.Version = version
+	params.Base.Ids.String = strings.Join(uuids, ",")
+	params.Base.Ids.Valid = true
+	params.Base.Limit.Int64 = int64(len(uuids))
+	params.Base.Limit.Valid = true
+
+	obj, err := factory.MakeObj(params)
+	if err != nil {
+		return nil, err
+	}
+	return listItems(obj), nil
+}
+
+func appendUnique(list []string, value string) []string {
+	for _, v := range list {
+		if v == value {
+			return list
+		}
+	}
+	return append(list, value)
+}
+
+// listItems indexes the items of the ObjList of a collection by their
+// Uuid, whatever the version of the collection. Items of merged uuids are
+// indexed by the uuid they were requested by, their RedirectedFrom, too.
+func listItems(obj Objecter) map[string]interface{} {
+	res := make(map[string]interface{})
+	v := reflect.ValueOf(obj)
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return res
+	}
+	list := v.FieldByName("ObjList")
+	if list.Kind() != reflect.Slice {
+		return res
+	}
+	for i := 0; i < list.Len(); i++ {
+		item := list.Index(i)
+		if uuid := item.FieldByName("Uuid"); uuid.Kind() == reflect.String {
+			res[uuid.String()] = item.Interface()
+		}
+		if from := item.FieldByName("RedirectedFrom"); from.Kind() == reflect.String && from.String() != "" {
+			res[from.String()] = item.Interface()
+		}
+	}
+	return res
+}
@@ -0,0 +1,44 @@
+// Command openapi-gen writes the OpenAPI document of the objects package.
+// With -check it only compares the stored document with the generated one
+// and exits non-zero when they differ, so CI catches a struct change that
+// was not followed by go generate.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"github.com/rpiskun/objects"
+	"io/ioutil"
+	"os"
+)
+
+func main() {
+	out := flag.String("o", "openapi.json", "path of the generated document")
+	check := flag.Bool("check", false, "fail if the document at -o is out of date")
+	flag.Parse()
+
+	spec, err := objects.OpenApiJson()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if *check {
+		stored, err := ioutil.ReadFile(*out)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		if !bytes.Equal(stored, spec) {
+			fmt.Fprintf(os.Stderr, "%s is out of date, run go generate\n", *out)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if err := ioutil.WriteFile(*out, spec, 0644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
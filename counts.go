@@ -0,0 +1,103 @@
+package objects
+
+import (
+	"reflect"
+	"text/template"
+)
+
+// PerfumsCountDBRecordV1 ...
+type PerfumsCountDBRecordV1 struct {
+	Uuid  string `db:"uuid"`
+	Count int64  `db:"count"`
+}
+
+// PerfumsCountQueryParams ...
+type PerfumsCountQueryParams struct {
+	Table                string
+	JoinCondition        string
+	WhereConditionString string
+}
+
+// perfumsJoins joins the items of each taxonomy table to their perfums.
+var perfumsJoins = map[string]string{
+	"brands":    "parfum_info.brand_id = brands.id",
+	"countries": "parfum_info.country_id = countries.id",
+	"gender":    "parfum_info.gender_id = gender.id",
+	"groups":    "parfum_info.group_id = groups.id",
+	"types":     "parfum_info.type_id = types.id",
+	"components": "parfum_info.id IN (SELECT parfums.parfum_info_id FROM parfums" +
+		" WHERE parfums.component_id = components.id)",
+	"notes": "parfum_info.id IN (SELECT parfums.parfum_info_id FROM parfums" +
+		" WHERE parfums.note_id = notes.id)",
+	"seasons":      seasonsTaxonomy.joinCondition(),
+	"times_of_day": timesOfDayTaxonomy.joinCondition(),
+}
+
+func init() {
+	template.Must(queries.Parse(`
+{{define "select_perfums_counts"}}
+SELECT {{.Table}}.uuid, COUNT(DISTINCT parfum_info.id) AS count FROM {{.Table}}
+JOIN parfum_info ON {{.JoinCondition}}
+WHERE {{.WhereConditionString}}
+GROUP BY {{.Table}}.uuid
+{{end}}
+`))
+}
+
+// perfumsCounts counts the perfums in one of statuses of each of the items
+// uuids of table in one query. Items without such perfums are left out.
+func perfumsCounts(table string, uuids []string, statuses []string) (map[string]int64, error) {
+	res := make(map[string]int64, len(uuids))
+	if len(uuids) == 0 {
+		return res, nil
+	}
+	queryParams := PerfumsCountQueryParams{
+		Table:                table,
+		JoinCondition:        perfumsJoins[table],
+		WhereConditionString: andCondition(addIdsToQuery(uuids, table+".uuid"), statusCondition("parfum_info", statuses)),
+	}
+	query, err := renderQuery("select_perfums_counts", queryParams)
+	if err != nil {
+		return nil, err
+	}
+	var rows []PerfumsCountDBRecordV1
+	if _, err := dbmap.Select(&rows, query); err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		res[row.Uuid] = row.Count
+	}
+	return res, nil
+}
+
+// fillPerfumsCounts sets the PerfumsCount of each item of list, a pointer to
+// a slice of the items of table, to the number of its perfums in one of
+// statuses: those the perfums link of the item lists.
+func fillPerfumsCounts(table string, list interface{}, statuses []string) error {
+	counts, err := perfumsCounts(table, listUuids(list), statuses)
+	if err != nil {
+		return err
+	}
+	setPerfumsCounts(list, counts)
+	return nil
+}
+
+// listUuids returns the Uuid of each item of list, a pointer to a slice.
+func listUuids(list interface{}) []string {
+	items := reflect.ValueOf(list).Elem()
+	uuids := make([]string, 0, items.Len())
+	for i := 0; i < items.Len(); i++ {
+		uuids = append(uuids, items.Index(i).FieldByName("Uuid").String())
+	}
+	return uuids
+}
+
+// setPerfumsCounts sets the PerfumsCount of each item of list, a pointer to
+// a slice, to its count in counts.
+func setPerfumsCounts(list interface{}, counts map[string]int64) {
+	items := reflect.ValueOf(list).Elem()
+	for i := 0; i < items.Len(); i++ {
+		item := items.Index(i)
+		item.FieldByName("PerfumsCount").SetInt(counts[item.FieldByName("Uuid").String()])
+	}
+}
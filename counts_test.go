@@ -0,0 +1,45 @@
+package objects
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTreePerfumsCountsQuery(t *testing.T) {
+	query, err := renderQuery("select_tree_perfums_counts", TreeCountQueryParams{
+		TreeQueryParams:  groupsTree.queryParams("groups.uuid IN ('g1', 'g2')"),
+		JoinCondition:    perfumsJoins["groups"],
+		PerfumsCondition: statusCondition("parfum_info", nil),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"SELECT groups.uuid, groups.uuid, 0 FROM groups WHERE groups.uuid IN ('g1', 'g2')",
+		"JOIN parfum_info ON parfum_info.group_id = groups.id",
+		"COUNT(DISTINCT parfum_info.id)",
+		"GROUP BY sub.root",
+	} {
+		if !strings.Contains(query, want) {
+			t.Errorf("no %q in %s", want, query)
+		}
+	}
+}
+
+func TestPerfumsJoins(t *testing.T) {
+	for kind, table := range kindTables {
+		switch kind {
+		case "perfum", "perfumer", "tag":
+			continue
+		}
+		if perfumsJoins[table] == "" {
+			t.Errorf("no perfums join for %s", table)
+		}
+	}
+
+	want := "(parfum_info.season_id = seasons.id OR parfum_info.uuid IN (SELECT perfum_seasons.perfum_uuid" +
+		" FROM perfum_seasons WHERE perfum_seasons.season_uuid = seasons.uuid))"
+	if got := perfumsJoins["seasons"]; got != want {
+		t.Errorf("seasons join %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,198 @@
+package objects
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"gopkg.in/gorp.v1"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeResult is what a fakeDB statement returns: the rows of a query or the
+// rows an exec affected.
+type fakeResult struct {
+	columns  []string
+	rows     [][]driver.Value
+	affected int64
+}
+
+// fakeStatement is a statement a fakeDB ran.
+type fakeStatement struct {
+	query string
+	args  []driver.Value
+}
+
+// fakeDB stands in for the database in tests: every statement is recorded
+// and answered by handle, which returns nil for no rows.
+type fakeDB struct {
+	mu         sync.Mutex
+	handle     func(query string, args []driver.Value) (*fakeResult, error)
+	statements []fakeStatement
+	commits    int
+	rollbacks  int
+}
+
+// useFakeDB points dbmap at a fakeDB answering with handle until the test
+// ends.
+func useFakeDB(t *testing.T, handle func(query string, args []driver.Value) (*fakeResult, error)) *fakeDB {
+	fake := &fakeDB{handle: handle}
+	saved := dbmap
+	dbmap = &gorp.DbMap{Db: sql.OpenDB(fake), Dialect: gorp.PostgresDialect{}}
+	t.Cleanup(func() {
+		dbmap.Db.Close()
+		dbmap = saved
+	})
+	return fake
+}
+
+// ran returns the statements run so far whose query contains part.
+func (f *fakeDB) ran(part string) []fakeStatement {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	res := []fakeStatement{}
+	for _, s := range f.statements {
+		if strings.Contains(s.query, part) {
+			res = append(res, s)
+		}
+	}
+	return res
+}
+
+func (f *fakeDB) run(query string, args []driver.Value) (*fakeResult, error) {
+	f.mu.Lock()
+	f.statements = append(f.statements, fakeStatement{query, args})
+	f.mu.Unlock()
+	if f.handle == nil {
+		return nil, nil
+	}
+	return f.handle(query, args)
+}
+
+func (f *fakeDB) Connect(context.Context) (driver.Conn, error) {
+	return fakeConn{f}, nil
+}
+
+func (f *fakeDB) Driver() driver.Driver {
+	return fakeDriver{f}
+}
+
+type fakeDriver struct {
+	db *fakeDB
+}
+
+func (d fakeDriver) Open(string) (driver.Conn, error) {
+	return fakeConn{d.db}, nil
+}
+
+type fakeConn struct {
+	db *fakeDB
+}
+
+func (c fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return fakeStmt{c.db, query}, nil
+}
+
+func (c fakeConn) Close() error {
+	return nil
+}
+
+func (c fakeConn) Begin() (driver.Tx, error) {
+	return fakeTx{c.db}, nil
+}
+
+type fakeTx struct {
+	db *fakeDB
+}
+
+func (tx fakeTx) Commit() error {
+	tx.db.mu.Lock()
+	defer tx.db.mu.Unlock()
+	tx.db.commits++
+	return nil
+}
+
+func (tx fakeTx) Rollback() error {
+	tx.db.mu.Lock()
+	defer tx.db.mu.Unlock()
+	tx.db.rollbacks++
+	return nil
+}
+
+type fakeStmt struct {
+	db    *fakeDB
+	query string
+}
+
+func (s fakeStmt) Close() error {
+	return nil
+}
+
+func (s fakeStmt) NumInput() int {
+	return -1
+}
+
+func (s fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	res, err := s.db.run(s.query, args)
+	if err != nil {
+		return nil, err
+	}
+	if res == nil {
+		return driver.RowsAffected(0), nil
+	}
+	return driver.RowsAffected(res.affected), nil
+}
+
+func (s fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	res, err := s.db.run(s.query, args)
+	if err != nil {
+		return nil, err
+	}
+	if res == nil {
+		res = &fakeResult{}
+	}
+	if len(res.columns) == 0 && len(res.rows) > 0 {
+		return nil, errors.New("fake rows without columns")
+	}
+	return &fakeRows{result: res}, nil
+}
+
+type fakeRows struct {
+	result *fakeResult
+	pos    int
+}
+
+func (r *fakeRows) Columns() []string {
+	return r.result.columns
+}
+
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.result.rows) {
+		return io.EOF
+	}
+	copy(dest, r.result.rows[r.pos])
+	r.pos++
+	return nil
+}
+
+// fakeColumn answers a query with one column of values.
+func fakeColumn(name string, values ...interface{}) *fakeResult {
+	res := &fakeResult{columns: []string{name}}
+	for _, v := range values {
+		res.rows = append(res.rows, []driver.Value{v})
+	}
+	return res
+}
+
+// testClaimsContext is a context of an authenticated user, as audited
+// changes require.
+func testClaimsContext() context.Context {
+	return ContextWithClaims(context.Background(), &IdTokenClaims{UserId: "u1"})
+}
@@ -0,0 +1,281 @@
+package objects
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/unrolled/render"
+	"net/http"
+	"strings"
+	"text/template"
+)
+
+// Relations of a perfum to the parent of its family.
+const (
+	FamilyRelationFlanker = "flanker"
+	FamilyRelationEdition = "edition"
+)
+
+// maxFamilyDepth bounds the walks up and down a family tree, so a cycle
+// entered by mistake can't loop forever.
+const maxFamilyDepth = 32
+
+// FamilyLinkDBRecordV1 is a perfum_family row: PerfumUuid is a flanker or
+// an edition of ParentUuid.
+type FamilyLinkDBRecordV1 struct {
+	PerfumUuid string `db:"perfum_uuid"`
+	ParentUuid string `db:"parent_uuid"`
+	Relation   string `db:"relation"`
+}
+
+// VariantV1 is a way a perfum is sold: a concentration ("edt", "edp",
+// "parfum", ...) in a Volume of ml, released in Year (0 when unknown).
+type VariantV1 struct {
+	Uuid          string `db:"uuid" json:"id"`
+	PerfumUuid    string `db:"perfum_uuid" json:"perfum_id"`
+	Concentration string `db:"concentration" json:"concentration"`
+	Volume        int64  `db:"volume" json:"volume"`
+	Year          int64  `db:"year" json:"year"`
+}
+
+// FamilyMemberV1 is a perfum of a family; Depth is 0 for the root the others
+// descend from.
+type FamilyMemberV1 struct {
+	Uuid       string         `db:"uuid" json:"id"`
+	Name       string         `db:"name" json:"name"`
+	ParentUuid sql.NullString `db:"parent_uuid" json:"parent_id"`
+	Relation   string         `db:"relation" json:"relation"`
+	Depth      int64          `db:"depth" json:"depth"`
+	Variants   []VariantV1    `db:"-" json:"variants"`
+	Links      []LinkV1       `db:"-" json:"links"`
+}
+
+// PerfumFamilyV1 is the whole family tree of a perfum, parents before their
+// flankers and editions.
+type PerfumFamilyV1 struct {
+	RootUuid string           `db:"-" json:"root_id"`
+	ObjList  []FamilyMemberV1 `db:"-" json:"family_list"`
+	Total    int64            `db:"-" json:"total"`
+	Offset   int64            `db:"-" json:"offset"`
+	Amount   int64            `db:"-" json:"amount"`
+}
+
+// FamilyQueryParams ...
+type FamilyQueryParams struct {
+	WhereConditionString string
+	MaxDepth             int64
+}
+
+func init() {
+	template.Must(queries.Parse(`
+{{define "select_family_links"}}
+SELECT perfum_uuid, parent_uuid, relation FROM perfum_family
+WHERE {{.WhereConditionString}}
+ORDER BY perfum_family.relation, perfum_family.perfum_uuid
+{{end}}
+
+{{define "select_family_root"}}
+WITH RECURSIVE up (uuid, depth) AS (
+	SELECT parfum_info.uuid, 0 FROM parfum_info WHERE parfum_info.uuid = $1
+	UNION ALL
+	SELECT perfum_family.parent_uuid, up.depth + 1 FROM perfum_family
+	JOIN up ON perfum_family.perfum_uuid = up.uuid
+	WHERE up.depth < {{.MaxDepth}}
+)
+SELECT uuid FROM up ORDER BY depth DESC LIMIT 1
+{{end}}
+
+{{define "select_family"}}
+WITH RECURSIVE down (uuid, parent_uuid, relation, depth) AS (
+	SELECT parfum_info.uuid, perfum_family.parent_uuid, perfum_family.relation, 0 FROM parfum_info
+	LEFT JOIN perfum_family ON perfum_family.perfum_uuid = parfum_info.uuid
+	WHERE parfum_info.uuid = $1
+	UNION ALL
+	SELECT perfum_family.perfum_uuid, perfum_family.parent_uuid, perfum_family.relation, down.depth + 1 FROM perfum_family
+	JOIN down ON perfum_family.parent_uuid = down.uuid
+	WHERE down.depth < {{.MaxDepth}}
+)
+SELECT down.uuid, parfum_info.name, down.parent_uuid, COALESCE(down.relation, '') AS relation, down.depth
+FROM down
+JOIN parfum_info ON parfum_info.uuid = down.uuid
+ORDER BY down.depth, parfum_info.name
+{{end}}
+
+{{define "select_variants"}}
+SELECT uuid, perfum_uuid, concentration, volume, COALESCE(year, 0) AS year FROM perfum_variants
+WHERE {{.WhereConditionString}}
+ORDER BY perfum_variants.concentration, perfum_variants.volume
+{{end}}
+`))
+}
+
+// familyRel names the link from a parent to its flanker or edition, e.g.
+// "PerfumFlanker".
+func familyRel(relation string) string {
+	if relation == "" {
+		return "PerfumRelated"
+	}
+	return "Perfum" + strings.ToUpper(relation[:1]) + relation[1:]
+}
+
+// fillPerfumFamily links each perfum of list to its parent, its flankers and
+// editions, and to its whole family when it has one.
+func fillPerfumFamily(list []PerfumInfoV1) error {
+	if len(list) == 0 {
+		return nil
+	}
+
+	uuids := make([]string, 0, len(list))
+	for i := range list {
+		uuids = append(uuids, list[i].Uuid)
+	}
+	queryParams := FamilyQueryParams{
+		WhereConditionString: "(" + addIdsToQuery(uuids, "perfum_family.perfum_uuid") +
+			" OR " + addIdsToQuery(uuids, "perfum_family.parent_uuid") + ")",
+	}
+	query, err := renderQuery("select_family_links", queryParams)
+	if err != nil {
+		return err
+	}
+	var links []FamilyLinkDBRecordV1
+	if _, err := dbmap.Select(&links, query); err != nil {
+		return err
+	}
+
+	parents := make(map[string]FamilyLinkDBRecordV1)
+	children := make(map[string][]FamilyLinkDBRecordV1)
+	for _, link := range links {
+		parents[link.PerfumUuid] = link
+		children[link.ParentUuid] = append(children[link.ParentUuid], link)
+	}
+	for i := range list {
+		info := &list[i]
+		parent, hasParent := parents[info.Uuid]
+		if !hasParent && len(children[info.Uuid]) == 0 {
+			continue
+		}
+		if hasParent {
+			info.ParentUuid.String = parent.ParentUuid
+			info.ParentUuid.Valid = true
+			info.FamilyRelation = parent.Relation
+			info.Links = append(info.Links, LinkV1{
+				Href:   baseUrl + "/perfum/" + parent.ParentUuid,
+				Rel:    "PerfumParent",
+				Method: "GET",
+			})
+		}
+		for _, child := range children[info.Uuid] {
+			info.Links = append(info.Links, LinkV1{
+				Href:   baseUrl + "/perfum/" + child.PerfumUuid,
+				Rel:    familyRel(child.Relation),
+				Method: "GET",
+			})
+		}
+		info.Links = append(info.Links, LinkV1{
+			Href:   baseUrl + "/perfum/" + info.Uuid + "/family",
+			Rel:    "PerfumFamily",
+			Method: "GET",
+		})
+	}
+
+	return nil
+}
+
+func NewPerfumFamilyFactory(version string) (Objecter, error) {
+	return NewObjecter("perfum_family", version)
+}
+
+// MakeObj lists the family of the perfum params.Id from its root down, each
+// member with its variants.
+func (obj *PerfumFamilyV1) MakeObj(pParams interface{}) (Objecter, error) {
+	if pParams == nil {
+		return nil, errors.New("invalid args")
+	}
+	params := pParams.(*MakeObjParams)
+	if params.Id == "" {
+		return nil, errors.New("invalid args")
+	}
+
+	queryParams := FamilyQueryParams{MaxDepth: maxFamilyDepth}
+	query, err := renderQuery("select_family_root", queryParams)
+	if err != nil {
+		return nil, err
+	}
+	root, err := dbmap.SelectNullStr(query, params.Id)
+	if err != nil {
+		return nil, err
+	}
+	if !root.Valid {
+		return nil, ErrPerfumNotFound
+	}
+	obj.RootUuid = root.String
+
+	query, err = renderQuery("select_family", queryParams)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := dbmap.Select(&obj.ObjList, query, obj.RootUuid); err != nil {
+		return nil, err
+	}
+
+	uuids := make([]string, 0, len(obj.ObjList))
+	for _, member := range obj.ObjList {
+		uuids = append(uuids, member.Uuid)
+	}
+	query, err = renderQuery("select_variants",
+		FamilyQueryParams{WhereConditionString: addIdsToQuery(uuids, "perfum_variants.perfum_uuid")})
+	if err != nil {
+		return nil, err
+	}
+	var variants []VariantV1
+	if _, err := dbmap.Select(&variants, query); err != nil {
+		return nil, err
+	}
+	byPerfum := make(map[string][]VariantV1)
+	for _, v := range variants {
+		byPerfum[v.PerfumUuid] = append(byPerfum[v.PerfumUuid], v)
+	}
+
+	for i := range obj.ObjList {
+		member := &obj.ObjList[i]
+		member.Variants = byPerfum[member.Uuid]
+		if member.Variants == nil {
+			member.Variants = []VariantV1{}
+		}
+		member.Links = []LinkV1{
+			LinkV1{
+				Href:   baseUrl + "/perfum/" + member.Uuid,
+				Rel:    "PerfumInfo",
+				Method: "GET",
+			},
+		}
+		if member.ParentUuid.Valid {
+			member.Links = append(member.Links, LinkV1{
+				Href:   baseUrl + "/perfum/" + member.ParentUuid.String,
+				Rel:    "PerfumParent",
+				Method: "GET",
+			})
+		}
+	}
+
+	obj.Total = int64(len(obj.ObjList))
+	obj.Amount = int64(len(obj.ObjList))
+
+	return obj, nil
+}
+
+func (obj *PerfumFamilyV1) MakeExtraObj(params *MakeObjParams, uids []string) (Objecter, error) {
+	return nil, errors.New("not supported")
+}
+
+func (obj *PerfumFamilyV1) Count(pParams interface{}) (int64, error) {
+	return 0, errors.New("not supported")
+}
+
+func (obj *PerfumFamilyV1) ExtraCount(uids []string) (int64, error) {
+	return 0, errors.New("not supported")
+}
+
+func (obj *PerfumFamilyV1) Json(w http.ResponseWriter, status int) error {
+	render := render.New()
+	return render.JSON(w, status, obj)
+}
@@ -0,0 +1,101 @@
+package objects
+
+import (
+	"database/sql/driver"
+	"strings"
+	"testing"
+)
+
+func linkRels(links []LinkV1) string {
+	rels := []string{}
+	for _, link := range links {
+		rels = append(rels, link.Rel+" "+strings.TrimPrefix(link.Href, baseUrl))
+	}
+	return strings.Join(rels, ", ")
+}
+
+func TestFillPerfumFamily(t *testing.T) {
+	useFakeDB(t, func(query string, args []driver.Value) (*fakeResult, error) {
+		return &fakeResult{
+			columns: []string{"perfum_uuid", "parent_uuid", "relation"},
+			rows: [][]driver.Value{
+				{"p3", "p1", FamilyRelationEdition},
+				{"p2", "p1", FamilyRelationFlanker},
+			},
+		}, nil
+	})
+
+	list := []PerfumInfoV1{{Uuid: "p1"}, {Uuid: "p2"}, {Uuid: "p4"}}
+	if err := fillPerfumFamily(list); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"PerfumEdition /perfum/p3, PerfumFlanker /perfum/p2, PerfumFamily /perfum/p1/family",
+		"PerfumParent /perfum/p1, PerfumFamily /perfum/p2/family",
+		"",
+	}
+	for i := range list {
+		if got := linkRels(list[i].Links); got != want[i] {
+			t.Errorf("%s links %s, want %s", list[i].Uuid, got, want[i])
+		}
+	}
+	if !list[1].ParentUuid.Valid || list[1].ParentUuid.String != "p1" || list[1].FamilyRelation != FamilyRelationFlanker {
+		t.Errorf("p2 parent %+v %s", list[1].ParentUuid, list[1].FamilyRelation)
+	}
+	if list[0].ParentUuid.Valid {
+		t.Errorf("root p1 has a parent %+v", list[0].ParentUuid)
+	}
+}
+
+func TestPerfumFamily(t *testing.T) {
+	root := "p1"
+	fake := useFakeDB(t, func(query string, args []driver.Value) (*fakeResult, error) {
+		switch {
+		case strings.Contains(query, "ORDER BY depth DESC"):
+			if root == "" {
+				return nil, nil
+			}
+			return fakeColumn("uuid", root), nil
+		case strings.Contains(query, "FROM down"):
+			return &fakeResult{
+				columns: []string{"uuid", "name", "parent_uuid", "relation", "depth"},
+				rows: [][]driver.Value{
+					{"p1", "No 5", nil, "", int64(0)},
+					{"p2", "No 5 L'Eau", "p1", FamilyRelationFlanker, int64(1)},
+				},
+			}, nil
+		}
+		return &fakeResult{
+			columns: []string{"uuid", "perfum_uuid", "concentration", "volume", "year"},
+			rows:    [][]driver.Value{{"v1", "p1", "edp", int64(50), int64(1986)}, {"v2", "p1", "parfum", int64(15), int64(0)}},
+		}, nil
+	})
+
+	obj, err := (&PerfumFamilyV1{}).MakeObj(&MakeObjParams{Id: "p2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	family := obj.(*PerfumFamilyV1)
+	if family.RootUuid != "p1" || family.Total != 2 || len(family.ObjList) != 2 {
+		t.Fatalf("family %+v", family)
+	}
+	if statements := fake.ran("ORDER BY depth DESC"); len(statements) != 1 || statements[0].args[0] != "p2" ||
+		!strings.Contains(statements[0].query, "up.depth < 32") {
+		t.Errorf("root looked up with %v", statements)
+	}
+	if root := family.ObjList[0]; len(root.Variants) != 2 || linkRels(root.Links) != "PerfumInfo /perfum/p1" {
+		t.Errorf("root member %+v", root)
+	}
+	if flanker := family.ObjList[1]; flanker.Variants == nil || len(flanker.Variants) != 0 ||
+		linkRels(flanker.Links) != "PerfumInfo /perfum/p2, PerfumParent /perfum/p1" {
+		t.Errorf("flanker member %+v", flanker)
+	}
+
+	root = ""
+	if _, err := (&PerfumFamilyV1{}).MakeObj(&MakeObjParams{Id: "p9"}); err != ErrPerfumNotFound {
+		t.Errorf("family of an unknown perfum: %v, want %v", err, ErrPerfumNotFound)
+	}
+	if _, err := (&PerfumFamilyV1{}).MakeObj(&MakeObjParams{}); err == nil {
+		t.Error("family without an id made")
+	}
+}
@@ -0,0 +1,204 @@
+package objects
+
+import (
+	"errors"
+	"github.com/unrolled/render"
+	"net/http"
+	"strings"
+	"text/template"
+)
+
+var ErrPriceWithoutCurrency = errors.New("price bounds need a currency")
+
+// PerfumFilterParams narrows the perfums listed by PerfumsFilterV1, unset
+// fields don't filter. Price bounds apply to the offers of ShopUuid when it
+// is set, to any offer otherwise; they need a Currency since prices of
+// different currencies don't compare. Query matches names translated into
+// Locales as well as the stored ones. TagUuids match perfums having any of
+// the tags, or all of them when TagMatch is MatchAll.
+type PerfumFilterParams struct {
+	Base     BaseParams
+	Total    int64
+	Query    string
+	Locales  []string
+	TagUuids []string
+	TagMatch string
+	ShopUuid string
+	MinPrice *float64
+	MaxPrice *float64
+	Currency string
+	// Statuses are the lifecycle states of the perfums found, active only
+	// when empty.
+	Statuses []string
+}
+
+// PerfumsFilterV1 is the result of a perfum filter, shaped like
+// PerfumsSearchResultV1.
+type PerfumsFilterV1 struct {
+	Links  []LinkV1 `json:"links"`
+	Total  int64    `json:"total"`
+	Offset int64    `json:"offset"`
+	Amount int64    `json:"amount"`
+}
+
+// perfumFilterQuery collects the conditions of a filter on parfum_info
+// together with their bind arguments.
+type perfumFilterQuery struct {
+	PageQueryParams
+	Conditions []string
+	args       bindArgs
+}
+
+func init() {
+	template.Must(queries.Parse(`
+{{define "select_perfums_filter"}}
+SELECT parfum_info.uuid FROM parfum_info
+WHERE TRUE{{range .Conditions}}
+AND {{.}}{{end}}
+ORDER BY parfum_info.name, parfum_info.uuid
+LIMIT {{.Limit}} OFFSET {{.Offset}}
+{{end}}
+
+{{define "select_perfums_filter_count"}}
+SELECT COUNT(*) FROM parfum_info
+WHERE TRUE{{range .Conditions}}
+AND {{.}}{{end}}
+{{end}}
+`))
+}
+
+func newPerfumFilterQuery(params *PerfumFilterParams) (*perfumFilterQuery, error) {
+	if err := checkStatuses(params.Statuses); err != nil {
+		return nil, err
+	}
+	q := &perfumFilterQuery{PageQueryParams: newPageQueryParams("parfum_info", &params.Base)}
+	q.Conditions = append(q.Conditions, statusCondition("parfum_info", params.Statuses))
+	if params.Query != "" {
+		var chain []string
+		if len(params.Locales) > 0 {
+			chain = LocaleChain(params.Locales)
+		}
+		q.Conditions = append(q.Conditions,
+			localizedNameCondition(&q.args, "parfum_info", "%"+params.Query+"%", chain))
+	}
+	if len(params.TagUuids) > 0 {
+		condition, err := tagsFilterCondition(&q.args, params.TagUuids, params.TagMatch)
+		if err != nil {
+			return nil, err
+		}
+		q.Conditions = append(q.Conditions, condition)
+	}
+	if err := q.addOfferConditions(params); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+func (q *perfumFilterQuery) addOfferConditions(params *PerfumFilterParams) error {
+	if params.MinPrice != nil && params.MaxPrice != nil && *params.MinPrice > *params.MaxPrice {
+		return errors.New("invalid price range")
+	}
+	if (params.MinPrice != nil || params.MaxPrice != nil) && params.Currency == "" {
+		return ErrPriceWithoutCurrency
+	}
+
+	offer := []string{}
+	if params.ShopUuid != "" {
+		offer = append(offer, "offers.shop_uuid = "+q.args.add(params.ShopUuid))
+	}
+	if params.MinPrice != nil {
+		offer = append(offer, "offers.price >= "+q.args.add(*params.MinPrice))
+	}
+	if params.MaxPrice != nil {
+		offer = append(offer, "offers.price <= "+q.args.add(*params.MaxPrice))
+	}
+	if params.Currency != "" {
+		offer = append(offer, "offers.currency = "+q.args.add(params.Currency))
+	}
+	if len(offer) > 0 {
+		q.Conditions = append(q.Conditions,
+			"EXISTS (SELECT 1 FROM offers WHERE offers.perfum_uuid = parfum_info.uuid AND "+
+				strings.Join(offer, " AND ")+")")
+	}
+
+	return nil
+}
+
+func NewPerfumsFilterFactory(version string) (Objecter, error) {
+	return NewObjecter("perfums_filter", version)
+}
+
+func (obj *PerfumsFilterV1) MakeObj(pParams interface{}) (Objecter, error) {
+	if pParams == nil {
+		return nil, errors.New("invalid args")
+	}
+
+	params := pParams.(*PerfumFilterParams)
+
+	filter, err := newPerfumFilterQuery(params)
+	if err != nil {
+		return nil, err
+	}
+	query, err := renderQuery("select_perfums_filter", filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []string
+	if _, err := dbmap.Select(&results, query, filter.args...); err != nil {
+		return nil, err
+	}
+
+	obj.Total = params.Total
+	obj.Offset = filter.Offset
+	obj.Amount = int64(len(results))
+
+	for _, result := range results {
+		obj.Links = append(obj.Links,
+			LinkV1{
+				Href:   baseUrl + "/perfum/" + result,
+				Rel:    "PerfumInfo",
+				Method: "GET",
+			},
+		)
+	}
+
+	return obj, nil
+}
+
+func (obj *PerfumsFilterV1) MakeExtraObj(params *MakeObjParams, uids []string) (Objecter, error) {
+	return obj, nil
+}
+
+func (obj *PerfumsFilterV1) Count(pParams interface{}) (int64, error) {
+	if pParams == nil {
+		return 0, errors.New("invalid args")
+	}
+
+	params := pParams.(*PerfumFilterParams)
+
+	filter, err := newPerfumFilterQuery(params)
+	if err != nil {
+		return 0, err
+	}
+	query, err := renderQuery("select_perfums_filter_count", filter)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := dbmap.SelectInt(query, filter.args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (obj *PerfumsFilterV1) ExtraCount(uids []string) (int64, error) {
+	return 0, nil
+}
+
+func (obj *PerfumsFilterV1) Json(w http.ResponseWriter, status int) error {
+	render := render.New()
+	return render.JSON(w, status, obj)
+}
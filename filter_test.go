@@ -0,0 +1,51 @@
+package objects
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPerfumFilterPrice(t *testing.T) {
+	low, high := 10.0, 20.0
+	tests := []struct {
+		params PerfumFilterParams
+		err    error
+	}{
+		{PerfumFilterParams{MinPrice: &low}, ErrPriceWithoutCurrency},
+		{PerfumFilterParams{MaxPrice: &high}, ErrPriceWithoutCurrency},
+		{PerfumFilterParams{MinPrice: &low, MaxPrice: &high, Currency: "EUR"}, nil},
+		{PerfumFilterParams{Currency: "EUR"}, nil},
+	}
+	for _, test := range tests {
+		q, err := newPerfumFilterQuery(&test.params)
+		if err != test.err {
+			t.Errorf("filter %+v: %v, want %v", test.params, err, test.err)
+			continue
+		}
+		if err == nil && !strings.Contains(strings.Join(q.Conditions, " "), "offers.currency = ") {
+			t.Errorf("filter %+v: no currency condition in %v", test.params, q.Conditions)
+		}
+	}
+}
+
+func TestPerfumFilterStatuses(t *testing.T) {
+	tests := []struct {
+		statuses []string
+		want     string
+		err      error
+	}{
+		{nil, "IN ('active')", nil},
+		{[]string{StatusDeleted, StatusActive}, "IN ('active', 'deleted')", nil},
+		{[]string{"active') OR TRUE --"}, "", ErrInvalidStatus},
+	}
+	for _, test := range tests {
+		q, err := newPerfumFilterQuery(&PerfumFilterParams{Statuses: test.statuses})
+		if err != test.err {
+			t.Errorf("filter statuses %q: %v, want %v", test.statuses, err, test.err)
+			continue
+		}
+		if err == nil && !strings.Contains(strings.Join(q.Conditions, " "), test.want) {
+			t.Errorf("filter statuses %q: no %q in %v", test.statuses, test.want, q.Conditions)
+		}
+	}
+}
@@ -0,0 +1,630 @@
+package objects
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/graphql-go/graphql"
+	"github.com/unrolled/render"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+type graphqlContextKey struct{}
+
+// graphqlLoader collects the uuids requested at one level of a GraphQL query
+// and resolves them with a single LoadBatch call per kind, so nested fields
+// like perfums { brand { name } } cost one query instead of one per perfum.
+// Names are served in the best of locales, taken from Accept-Language.
+type graphqlLoader struct {
+	version string
+	locales []string
+
+	mu      sync.Mutex
+	pending map[string][]string
+	cache   map[string]map[string]interface{}
+}
+
+func newGraphqlLoader(version string, locales []string) *graphqlLoader {
+	return &graphqlLoader{
+		version: version,
+		locales: locales,
+		pending: make(map[string][]string),
+		cache:   make(map[string]map[string]interface{}),
+	}
+}
+
+func graphqlLoaderFrom(ctx context.Context) (*graphqlLoader, error) {
+	loader, ok := ctx.Value(graphqlContextKey{}).(*graphqlLoader)
+	if !ok {
+		return nil, errors.New("graphql loader is not set")
+	}
+	return loader, nil
+}
+
+// load registers uuid for the next batch of kind and returns a thunk the
+// executor calls once the whole level has been collected.
+func (l *graphqlLoader) load(kind, uuid string) func() (interface{}, error) {
+	l.mu.Lock()
+	if _, cached := l.cache[kind][uuid]; !cached {
+		l.pending[kind] = appendUnique(l.pending[kind], uuid)
+	}
+	l.mu.Unlock()
+
+	return func() (interface{}, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		if uuids := l.pending[kind]; len(uuids) > 0 {
+			delete(l.pending, kind)
+			items := make([]BatchItemReq, 0, len(uuids))
+			for _, id := range uuids {
+				items = append(items, BatchItemReq{Kind: kind, Uuid: id})
+			}
+			found, err := LoadBatchLocalized(l.version, l.locales, items)
+			if err != nil {
+				return nil, err
+			}
+			if l.cache[kind] == nil {
+				l.cache[kind] = make(map[string]interface{})
+			}
+			for id, item := range found[kind] {
+				l.cache[kind][id] = item
+			}
+		}
+
+		if item, ok := l.cache[kind][uuid]; ok {
+			return item, nil
+		}
+		return nil, nil
+	}
+}
+
+// loadAll is load for several uuids of kind; its thunk returns the items
+// found, in the order of uuids.
+func (l *graphqlLoader) loadAll(kind string, uuids []string) func() (interface{}, error) {
+	thunks := make([]func() (interface{}, error), 0, len(uuids))
+	for _, uuid := range uuids {
+		thunks = append(thunks, l.load(kind, uuid))
+	}
+
+	return func() (interface{}, error) {
+		res := []interface{}{}
+		for _, thunk := range thunks {
+			item, err := thunk()
+			if err != nil {
+				return nil, err
+			}
+			if item != nil {
+				res = append(res, item)
+			}
+		}
+		return res, nil
+	}
+}
+
+func graphqlListParams(loader *graphqlLoader, args map[string]interface{}) (*MakeObjParams, error) {
+	params := &MakeObjParams{Locales: loader.locales}
+	params.Base.Version = loader.version
+	if offset, ok := args["offset"].(int); ok {
+		params.Base.Offset.Int64 = int64(offset)
+		params.Base.Offset.Valid = true
+	}
+	if limit, ok := args["limit"].(int); ok {
+		params.Base.Limit.Int64 = int64(limit)
+		params.Base.Limit.Valid = true
+	}
+	ids, err := graphqlStrings(args, "ids")
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) > 0 {
+		params.Base.Ids.String = strings.Join(ids, ",")
+		params.Base.Ids.Valid = true
+	}
+	if params.Statuses, err = graphqlStrings(args, "statuses"); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
+// graphqlStrings returns the list argument name of args, which must hold
+// strings only: null items are rejected with ErrGraphqlArgument.
+func graphqlStrings(args map[string]interface{}, name string) ([]string, error) {
+	list, ok := args[name].([]interface{})
+	if !ok {
+		return nil, nil
+	}
+	res := make([]string, 0, len(list))
+	for _, item := range list {
+		s, ok := item.(string)
+		if !ok {
+			return nil, ErrGraphqlArgument
+		}
+		res = append(res, s)
+	}
+	return res, nil
+}
+
+var graphqlPagingArgs = graphql.FieldConfigArgument{
+	"offset":   &graphql.ArgumentConfig{Type: graphql.Int},
+	"limit":    &graphql.ArgumentConfig{Type: graphql.Int},
+	"ids":      &graphql.ArgumentConfig{Type: graphql.NewList(graphql.String)},
+	"statuses": &graphql.ArgumentConfig{Type: graphql.NewList(graphql.String)},
+}
+
+var graphqlIdArgs = graphql.FieldConfigArgument{
+	"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+}
+
+var linkGraphqlType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Link",
+	Fields: graphql.Fields{
+		"href":   &graphql.Field{Type: graphql.String},
+		"rel":    &graphql.Field{Type: graphql.String},
+		"method": &graphql.Field{Type: graphql.String},
+	},
+})
+
+// taxonomyUuid returns the public id of any taxonomy item; the default
+// resolver would pick the internal Id field instead.
+func taxonomyUuid(source interface{}) string {
+	switch item := source.(type) {
+	case BrandV1:
+		return item.Uuid
+	case ComponentV1:
+		return item.Uuid
+	case CountryV1:
+		return item.Uuid
+	case GenderV1:
+		return item.Uuid
+	case GroupV1:
+		return item.Uuid
+	case NoteV1:
+		return item.Uuid
+	case PerfumerV1:
+		return item.Uuid
+	case SeasonV1:
+		return item.Uuid
+	case TagV1:
+		return item.Uuid
+	case TimeOfDayV1:
+		return item.Uuid
+	case TypeV1:
+		return item.Uuid
+	case TaxonomyV2:
+		return item.Uuid
+	}
+	return ""
+}
+
+func newTaxonomyGraphqlType(name string) *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: name,
+		Fields: graphql.Fields{
+			"id": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return taxonomyUuid(p.Source), nil
+				},
+			},
+			"name":          &graphql.Field{Type: graphql.String},
+			"perfums_count": &graphql.Field{Type: graphql.Int},
+			"small_img_url": &graphql.Field{Type: graphql.String},
+			"large_img_url": &graphql.Field{Type: graphql.String},
+			"links":         &graphql.Field{Type: graphql.NewList(linkGraphqlType)},
+		},
+	})
+}
+
+type graphqlTaxonomy struct {
+	kind    string
+	single  string
+	plural  string
+	gqlType *graphql.Object
+	list    func(obj Objecter) []interface{}
+}
+
+// NewGraphqlSchema builds the GraphQL schema over perfums, their
+// compositions and every taxonomy. Resolvers go through the regular
+// factories, so the same query templates serve both REST and GraphQL.
+func NewGraphqlSchema() (graphql.Schema, error) {
+	taxonomies := []*graphqlTaxonomy{
+		{kind: "brand", single: "brand", plural: "brands", gqlType: newTaxonomyGraphqlType("Brand"),
+			list: func(obj Objecter) []interface{} { return listOf(obj.(*BrandsV1).ObjList) }},
+		{kind: "component", single: "component", plural: "components", gqlType: newTaxonomyGraphqlType("Component"),
+			list: func(obj Objecter) []interface{} { return listOf(obj.(*ComponentsV1).ObjList) }},
+		{kind: "country", single: "country", plural: "countries", gqlType: newTaxonomyGraphqlType("Country"),
+			list: func(obj Objecter) []interface{} { return listOf(obj.(*CountriesV1).ObjList) }},
+		{kind: "gender", single: "gender", plural: "genders", gqlType: newTaxonomyGraphqlType("Gender"),
+			list: func(obj Objecter) []interface{} { return listOf(obj.(*GendersV1).ObjList) }},
+		{kind: "group", single: "group", plural: "groups", gqlType: newTaxonomyGraphqlType("Group"),
+			list: func(obj Objecter) []interface{} { return listOf(obj.(*GroupsV1).ObjList) }},
+		{kind: "note", single: "note", plural: "notes", gqlType: newTaxonomyGraphqlType("Note"),
+			list: func(obj Objecter) []interface{} { return listOf(obj.(*NotesV1).ObjList) }},
+		{kind: "perfumer", single: "perfumer", plural: "perfumers", gqlType: newTaxonomyGraphqlType("Perfumer"),
+			list: func(obj Objecter) []interface{} { return listOf(obj.(*PerfumersV1).ObjList) }},
+		{kind: "season", single: "season", plural: "seasons", gqlType: newTaxonomyGraphqlType("Season"),
+			list: func(obj Objecter) []interface{} { return listOf(obj.(*SeasonsV1).ObjList) }},
+		{kind: "tag", single: "tag", plural: "tags", gqlType: newTaxonomyGraphqlType("Tag"),
+			list: func(obj Objecter) []interface{} { return listOf(obj.(*TagsV1).ObjList) }},
+		{kind: "timeofday", single: "timeofday", plural: "timesofday", gqlType: newTaxonomyGraphqlType("TimeOfDay"),
+			list: func(obj Objecter) []interface{} { return listOf(obj.(*TimesOfDayV1).ObjList) }},
+		{kind: "type", single: "type", plural: "types", gqlType: newTaxonomyGraphqlType("Type"),
+			list: func(obj Objecter) []interface{} { return listOf(obj.(*TypesV1).ObjList) }},
+	}
+	byKind := make(map[string]*graphqlTaxonomy)
+	for _, t := range taxonomies {
+		byKind[t.kind] = t
+	}
+
+	componentItemType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "PerfumComponent",
+		Fields: graphql.Fields{
+			"id":         &graphql.Field{Type: graphql.String},
+			"name":       &graphql.Field{Type: graphql.String},
+			"intensity":  &graphql.Field{Type: graphql.Int},
+			"percentage": &graphql.Field{Type: graphql.Float},
+			"links":      &graphql.Field{Type: graphql.NewList(linkGraphqlType)},
+			"component": &graphql.Field{
+				Type: byKind["component"].gqlType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					loader, err := graphqlLoaderFrom(p.Context)
+					if err != nil {
+						return nil, err
+					}
+					return loader.load("component", p.Source.(ComponentItemV1).Id), nil
+				},
+			},
+		},
+	})
+
+	noteItemType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "PerfumNote",
+		Fields: graphql.Fields{
+			"id":              &graphql.Field{Type: graphql.String},
+			"name":            &graphql.Field{Type: graphql.String},
+			"level":           &graphql.Field{Type: graphql.String},
+			"position":        &graphql.Field{Type: graphql.Int},
+			"component_count": &graphql.Field{Type: graphql.Int},
+			"components":      &graphql.Field{Type: graphql.NewList(componentItemType)},
+			"links":           &graphql.Field{Type: graphql.NewList(linkGraphqlType)},
+			"note": &graphql.Field{
+				Type: byKind["note"].gqlType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					loader, err := graphqlLoaderFrom(p.Context)
+					if err != nil {
+						return nil, err
+					}
+					return loader.load("note", p.Source.(NoteItemV1).Id), nil
+				},
+			},
+		},
+	})
+
+	perfumField := func(get func(info PerfumInfoV1) interface{}) graphql.FieldResolveFn {
+		return func(p graphql.ResolveParams) (interface{}, error) {
+			return get(p.Source.(PerfumInfoV1)), nil
+		}
+	}
+
+	perfumFields := graphql.Fields{
+		"id": &graphql.Field{
+			Type:    graphql.String,
+			Resolve: perfumField(func(info PerfumInfoV1) interface{} { return info.Uuid }),
+		},
+		"name":          &graphql.Field{Type: graphql.String},
+		"description":   &graphql.Field{Type: graphql.String},
+		"year":          &graphql.Field{Type: graphql.Int},
+		"status":        &graphql.Field{Type: graphql.String},
+		"small_img_url": &graphql.Field{Type: graphql.String},
+		"large_img_url": &graphql.Field{Type: graphql.String},
+		"links":         &graphql.Field{Type: graphql.NewList(linkGraphqlType)},
+		"notes": &graphql.Field{
+			Type: graphql.NewList(noteItemType),
+			Args: graphql.FieldConfigArgument{
+				"levels": &graphql.ArgumentConfig{Type: graphql.NewList(graphql.String)},
+			},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				loader, err := graphqlLoaderFrom(p.Context)
+				if err != nil {
+					return nil, err
+				}
+				levels, err := graphqlStrings(p.Args, "levels")
+				if err != nil {
+					return nil, err
+				}
+				if err := checkLevels(levels); err != nil {
+					return nil, err
+				}
+				thunk := loader.load("composition", p.Source.(PerfumInfoV1).Uuid)
+				return func() (interface{}, error) {
+					composition, err := thunk()
+					if err != nil || composition == nil {
+						return nil, err
+					}
+					notes := composition.(PerfumCompositionV1).Notes
+					if len(levels) == 0 {
+						return notes, nil
+					}
+					kept := []NoteItemV1{}
+					for _, note := range notes {
+						if containsString(levels, note.Level) {
+							kept = append(kept, note)
+						}
+					}
+					return kept, nil
+				}, nil
+			},
+		},
+	}
+
+	taxonomyUuids := map[string]func(info PerfumInfoV1) string{
+		"brand":     func(info PerfumInfoV1) string { return info.BrandUuid },
+		"country":   func(info PerfumInfoV1) string { return info.CountryUuid },
+		"gender":    func(info PerfumInfoV1) string { return info.GenderUuid },
+		"group":     func(info PerfumInfoV1) string { return info.GroupUuid },
+		"season":    func(info PerfumInfoV1) string { return info.SeasonUuid },
+		"timeofday": func(info PerfumInfoV1) string { return info.TsodUuid },
+		"type":      func(info PerfumInfoV1) string { return info.TypeUuid },
+	}
+	for kind, uuidOf := range taxonomyUuids {
+		kind, uuidOf := kind, uuidOf
+		perfumFields[byKind[kind].single] = &graphql.Field{
+			Type: byKind[kind].gqlType,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				loader, err := graphqlLoaderFrom(p.Context)
+				if err != nil {
+					return nil, err
+				}
+				return loader.load(kind, uuidOf(p.Source.(PerfumInfoV1))), nil
+			},
+		}
+	}
+
+	perfumFields["perfumers"] = &graphql.Field{
+		Type: graphql.NewList(byKind["perfumer"].gqlType),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			loader, err := graphqlLoaderFrom(p.Context)
+			if err != nil {
+				return nil, err
+			}
+			uuids := []string{}
+			for _, ref := range p.Source.(PerfumInfoV1).Perfumers {
+				uuids = append(uuids, ref.Uuid)
+			}
+			return loader.loadAll("perfumer", uuids), nil
+		},
+	}
+
+	perfumFields["tags"] = &graphql.Field{
+		Type: graphql.NewList(byKind["tag"].gqlType),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			loader, err := graphqlLoaderFrom(p.Context)
+			if err != nil {
+				return nil, err
+			}
+			uuids := []string{}
+			for _, ref := range p.Source.(PerfumInfoV1).Tags {
+				uuids = append(uuids, ref.Uuid)
+			}
+			return loader.loadAll("tag", uuids), nil
+		},
+	}
+
+	perfumType := graphql.NewObject(graphql.ObjectConfig{
+		Name:   "Perfum",
+		Fields: perfumFields,
+	})
+
+	queryFields := graphql.Fields{
+		"perfum": &graphql.Field{
+			Type: perfumType,
+			Args: graphqlIdArgs,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				loader, err := graphqlLoaderFrom(p.Context)
+				if err != nil {
+					return nil, err
+				}
+				return loader.load("perfum", p.Args["id"].(string)), nil
+			},
+		},
+		"perfums": &graphql.Field{
+			Type: graphql.NewList(perfumType),
+			Args: graphqlPagingArgs,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				loader, err := graphqlLoaderFrom(p.Context)
+				if err != nil {
+					return nil, err
+				}
+				factory, err := NewObjecter("perfums_info", loader.version)
+				if err != nil {
+					return nil, err
+				}
+				params, err := graphqlListParams(loader, p.Args)
+				if err != nil {
+					return nil, err
+				}
+				obj, err := factory.MakeObj(params)
+				if err != nil {
+					return nil, err
+				}
+				return listOf(obj.(*PerfumsInfoV1).ObjList), nil
+			},
+		},
+	}
+
+	for _, t := range taxonomies {
+		t := t
+		t.gqlType.AddFieldConfig("perfums", &graphql.Field{
+			Type: graphql.NewList(perfumType),
+			Args: graphqlPagingArgs,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				loader, err := graphqlLoaderFrom(p.Context)
+				if err != nil {
+					return nil, err
+				}
+				factory, err := NewObjecter(batchKinds[t.kind].collection, loader.version)
+				if err != nil {
+					return nil, err
+				}
+				params, err := graphqlListParams(loader, p.Args)
+				if err != nil {
+					return nil, err
+				}
+				obj, err := factory.MakeExtraObj(params, []string{taxonomyUuid(p.Source)})
+				if err != nil {
+					return nil, err
+				}
+				return listOf(obj.(*PerfumsInfoV1).ObjList), nil
+			},
+		})
+
+		queryFields[t.single] = &graphql.Field{
+			Type: t.gqlType,
+			Args: graphqlIdArgs,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				loader, err := graphqlLoaderFrom(p.Context)
+				if err != nil {
+					return nil, err
+				}
+				return loader.load(t.kind, p.Args["id"].(string)), nil
+			},
+		}
+		queryFields[t.plural] = &graphql.Field{
+			Type: graphql.NewList(t.gqlType),
+			Args: graphqlPagingArgs,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				loader, err := graphqlLoaderFrom(p.Context)
+				if err != nil {
+					return nil, err
+				}
+				factory, err := NewObjecter(batchKinds[t.kind].collection, loader.version)
+				if err != nil {
+					return nil, err
+				}
+				params, err := graphqlListParams(loader, p.Args)
+				if err != nil {
+					return nil, err
+				}
+				obj, err := factory.MakeObj(params)
+				if err != nil {
+					return nil, err
+				}
+				return t.list(obj), nil
+			},
+		}
+	}
+
+	return graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name:   "Query",
+			Fields: queryFields,
+		}),
+	})
+}
+
+func listOf(items interface{}) []interface{} {
+	var res []interface{}
+	switch list := items.(type) {
+	case []PerfumInfoV1:
+		for _, item := range list {
+			res = append(res, item)
+		}
+	case []BrandV1:
+		for _, item := range list {
+			res = append(res, item)
+		}
+	case []ComponentV1:
+		for _, item := range list {
+			res = append(res, item)
+		}
+	case []CountryV1:
+		for _, item := range list {
+			res = append(res, item)
+		}
+	case []GenderV1:
+		for _, item := range list {
+			res = append(res, item)
+		}
+	case []GroupV1:
+		for _, item := range list {
+			res = append(res, item)
+		}
+	case []NoteV1:
+		for _, item := range list {
+			res = append(res, item)
+		}
+	case []PerfumerV1:
+		for _, item := range list {
+			res = append(res, item)
+		}
+	case []SeasonV1:
+		for _, item := range list {
+			res = append(res, item)
+		}
+	case []TagV1:
+		for _, item := range list {
+			res = append(res, item)
+		}
+	case []TimeOfDayV1:
+		for _, item := range list {
+			res = append(res, item)
+		}
+	case []TypeV1:
+		for _, item := range list {
+			res = append(res, item)
+		}
+	}
+	return res
+}
+
+// GraphqlReq ...
+type GraphqlReq struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// graphqlVersion is the version of the objects the schema resolves over;
+// its resolvers read the v1 shapes.
+const graphqlVersion = "v1"
+
+var ErrGraphqlVersion = errors.New("graphql serves v1 objects only")
+
+var ErrGraphqlArgument = errors.New("graphql list arguments must not hold nulls")
+
+// GraphqlHandler serves GraphQL requests (GET ?query= or POST JSON) against
+// schema, resolving objects of the given version. Versions other than v1
+// are answered with ErrGraphqlVersion.
+func GraphqlHandler(schema graphql.Schema, version string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		render := render.New()
+
+		if version != graphqlVersion {
+			render.JSON(w, http.StatusNotImplemented, map[string]string{"error": ErrGraphqlVersion.Error()})
+			return
+		}
+
+		req := GraphqlReq{Query: r.URL.Query().Get("query")}
+		if r.Method == http.MethodPost {
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				render.JSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+				return
+			}
+		}
+
+		locales := ParseAcceptLanguage(r.Header.Get("Accept-Language"))
+		ctx := context.WithValue(r.Context(), graphqlContextKey{}, newGraphqlLoader(version, locales))
+		result := graphql.Do(graphql.Params{
+			Schema:         schema,
+			RequestString:  req.Query,
+			VariableValues: req.Variables,
+			OperationName:  req.OperationName,
+			Context:        ctx,
+		})
+
+		render.JSON(w, http.StatusOK, result)
+	})
+}
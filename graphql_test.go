@@ -0,0 +1,124 @@
+package objects
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/graphql-go/graphql"
+	"strings"
+	"testing"
+)
+
+// runGraphql runs query against the schema with a loader whose cache holds
+// cached, so resolvers never reach the database.
+func runGraphql(t *testing.T, query string, cached map[string]map[string]interface{}) string {
+	schema, err := NewGraphqlSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+	loader := newGraphqlLoader(graphqlVersion, nil)
+	loader.cache = cached
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: query,
+		Context:       context.WithValue(context.Background(), graphqlContextKey{}, loader),
+	})
+	if len(result.Errors) > 0 {
+		t.Fatalf("query %s: %v", query, result.Errors)
+	}
+	data, err := json.Marshal(result.Data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestGraphqlPerfumers(t *testing.T) {
+	cached := map[string]map[string]interface{}{
+		"perfum": {
+			"p1": PerfumInfoV1{Uuid: "p1", Name: "No 5", Perfumers: []PerfumerRefV1{
+				{Uuid: "n1", Name: "Ernest Beaux", PerfumUuid: "p1"},
+				{Uuid: "n2", Name: "Jacques Polge", PerfumUuid: "p1"},
+			}},
+		},
+		"perfumer": {
+			"n1": PerfumerV1{Uuid: "n1", Name: "Ernest Beaux", PerfumsCount: 3},
+			"n2": PerfumerV1{Uuid: "n2", Name: "Jacques Polge", PerfumsCount: 7},
+		},
+	}
+
+	got := runGraphql(t, `{ perfum(id: "p1") { name perfumers { id name perfums_count } } }`, cached)
+	want := `{"perfum":{"name":"No 5","perfumers":[` +
+		`{"id":"n1","name":"Ernest Beaux","perfums_count":3},` +
+		`{"id":"n2","name":"Jacques Polge","perfums_count":7}]}}`
+	if got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	if list := listOf([]PerfumerV1{{Uuid: "n1"}, {Uuid: "n2"}}); len(list) != 2 {
+		t.Fatalf("listOf perfumers = %v", list)
+	}
+}
+
+func TestGraphqlTags(t *testing.T) {
+	cached := map[string]map[string]interface{}{
+		"perfum": {
+			"p1": PerfumInfoV1{Uuid: "p1", Name: "No 5", Tags: []TaxonomyItemRefV1{
+				{Uuid: "t1", Name: "aldehydic", PerfumUuid: "p1"},
+				{Uuid: "t2", Name: "floral", PerfumUuid: "p1"},
+			}},
+		},
+		"tag": {
+			"t1": TagV1{Uuid: "t1", Name: "aldehydic", PerfumsCount: 12},
+			"t2": TagV1{Uuid: "t2", Name: "floral", PerfumsCount: 40},
+		},
+	}
+
+	got := runGraphql(t, `{ perfum(id: "p1") { tags { id name perfums_count } } }`, cached)
+	want := `{"perfum":{"tags":[` +
+		`{"id":"t1","name":"aldehydic","perfums_count":12},` +
+		`{"id":"t2","name":"floral","perfums_count":40}]}}`
+	if got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	if list := listOf([]TagV1{{Uuid: "t1"}}); len(list) != 1 {
+		t.Fatalf("listOf tags = %v", list)
+	}
+}
+
+func TestGraphqlNullListItems(t *testing.T) {
+	schema, err := NewGraphqlSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+	loader := newGraphqlLoader(graphqlVersion, nil)
+	// null list items reach resolvers through variables only
+	for _, query := range []string{
+		`query($list: [String]) { perfums(ids: $list) { id } }`,
+		`query($list: [String]) { brands(statuses: $list) { id } }`,
+	} {
+		result := graphql.Do(graphql.Params{
+			Schema:         schema,
+			RequestString:  query,
+			VariableValues: map[string]interface{}{"list": []interface{}{"active", nil}},
+			Context:        context.WithValue(context.Background(), graphqlContextKey{}, loader),
+		})
+		if len(result.Errors) != 1 || !strings.Contains(result.Errors[0].Message, ErrGraphqlArgument.Error()) {
+			t.Errorf("query %s: errors %v, want %v", query, result.Errors, ErrGraphqlArgument)
+		}
+	}
+}
+
+func TestGraphqlStrings(t *testing.T) {
+	args := map[string]interface{}{"ids": []interface{}{"a", "b"}, "levels": []interface{}{"top", nil}}
+	if ids, err := graphqlStrings(args, "ids"); err != nil || strings.Join(ids, ",") != "a,b" {
+		t.Errorf("ids = %v, %v", ids, err)
+	}
+	if _, err := graphqlStrings(args, "levels"); err != ErrGraphqlArgument {
+		t.Errorf("levels err = %v, want %v", err, ErrGraphqlArgument)
+	}
+	if list, err := graphqlStrings(args, "statuses"); list != nil || err != nil {
+		t.Errorf("missing argument = %v, %v", list, err)
+	}
+}
@@ -0,0 +1,444 @@
+package objects
+
+import (
+	"context"
+	"github.com/rpiskun/objects/objectspb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"strings"
+)
+
+// GrpcServer serves the objects collections over gRPC using the same
+// factories as the JSON API.
+type GrpcServer struct {
+	objectspb.UnimplementedObjectsServer
+	Version string
+}
+
+func NewGrpcServer(version string) *GrpcServer {
+	return &GrpcServer{Version: version}
+}
+
+// RegisterGrpcServer registers the objects service on s.
+func RegisterGrpcServer(s *grpc.Server, version string) {
+	objectspb.RegisterObjectsServer(s, NewGrpcServer(version))
+}
+
+func (srv *GrpcServer) factory(kind string) (Objecter, error) {
+	// the responses are built from the v1 shapes
+	if srv.Version != "v1" {
+		return nil, status.Errorf(codes.Unimplemented, "version %q is not served over gRPC", srv.Version)
+	}
+	bk, known := batchKinds[kind]
+	if !known || kind == "composition" {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown kind %q", kind)
+	}
+	factory, err := NewObjecter(bk.collection, srv.Version)
+	if err != nil {
+		return nil, status.Errorf(codes.Unimplemented, "%s: %v", kind, err)
+	}
+	return factory, nil
+}
+
+func (srv *GrpcServer) List(ctx context.Context, req *objectspb.ListRequest) (*objectspb.ListResponse, error) {
+	factory, err := srv.factory(req.Kind)
+	if err != nil {
+		return nil, err
+	}
+
+	params := &MakeObjParams{}
+	params.Base.Version = srv.Version
+	params.Base.Offset.Int64 = req.Offset
+	params.Base.Offset.Valid = true
+	if req.Limit > 0 {
+		params.Base.Limit.Int64 = req.Limit
+		params.Base.Limit.Valid = true
+	}
+	if len(req.Ids) > 0 {
+		params.Base.Ids.String = strings.Join(req.Ids, ",")
+		params.Base.Ids.Valid = true
+	}
+	if err := checkStatuses(req.Statuses); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	params.Statuses = req.Statuses
+
+	// a list of ids pages over the ids only
+	if params.Base.Ids.Valid {
+		params.Total = int64(len(req.Ids))
+	} else if params.Total, err = factory.Count(params); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	obj, err := factory.MakeObj(params)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &objectspb.ListResponse{Total: params.Total, Offset: req.Offset}
+	switch list := obj.(type) {
+	case *PerfumsInfoV1:
+		for _, item := range list.ObjList {
+			resp.PerfumsInfo = append(resp.PerfumsInfo, perfumInfoToPb(item))
+		}
+	case *BrandsV1:
+		for _, item := range list.ObjList {
+			resp.Brands = append(resp.Brands, brandToPb(item))
+		}
+	case *ComponentsV1:
+		for _, item := range list.ObjList {
+			resp.Components = append(resp.Components, componentToPb(item))
+		}
+	case *CountriesV1:
+		for _, item := range list.ObjList {
+			resp.Countries = append(resp.Countries, countryToPb(item))
+		}
+	case *GendersV1:
+		for _, item := range list.ObjList {
+			resp.Genders = append(resp.Genders, genderToPb(item))
+		}
+	case *GroupsV1:
+		for _, item := range list.ObjList {
+			resp.Groups = append(resp.Groups, groupToPb(item))
+		}
+	case *NotesV1:
+		for _, item := range list.ObjList {
+			resp.Notes = append(resp.Notes, noteToPb(item))
+		}
+	case *SeasonsV1:
+		for _, item := range list.ObjList {
+			resp.Seasons = append(resp.Seasons, seasonToPb(item))
+		}
+	case *TimesOfDayV1:
+		for _, item := range list.ObjList {
+			resp.TimesOfDay = append(resp.TimesOfDay, timeOfDayToPb(item))
+		}
+	case *TypesV1:
+		for _, item := range list.ObjList {
+			resp.Types = append(resp.Types, typeToPb(item))
+		}
+	case *PerfumersV1:
+		for _, item := range list.ObjList {
+			resp.Perfumers = append(resp.Perfumers, perfumerToPb(item))
+		}
+	case *TagsV1:
+		for _, item := range list.ObjList {
+			resp.Tags = append(resp.Tags, tagToPb(item))
+		}
+	}
+	resp.Amount = listResponseAmount(resp)
+
+	return resp, nil
+}
+
+func (srv *GrpcServer) Get(ctx context.Context, req *objectspb.GetRequest) (*objectspb.GetResponse, error) {
+	if _, err := srv.factory(req.Kind); err != nil {
+		return nil, err
+	}
+
+	kind := req.Kind
+	if kind == "perfum" {
+		kind = "composition"
+	}
+
+	found, err := LoadBatch(srv.Version, []BatchItemReq{{Kind: kind, Uuid: req.Id}})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	item, ok := found[kind][req.Id]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "%s %s not found", req.Kind, req.Id)
+	}
+
+	resp := &objectspb.GetResponse{}
+	switch obj := item.(type) {
+	case PerfumCompositionV1:
+		resp.Item = &objectspb.GetResponse_Composition{Composition: compositionToPb(obj)}
+	case BrandV1:
+		resp.Item = &objectspb.GetResponse_Brand{Brand: brandToPb(obj)}
+	case ComponentV1:
+		resp.Item = &objectspb.GetResponse_Component{Component: componentToPb(obj)}
+	case CountryV1:
+		resp.Item = &objectspb.GetResponse_Country{Country: countryToPb(obj)}
+	case GenderV1:
+		resp.Item = &objectspb.GetResponse_Gender{Gender: genderToPb(obj)}
+	case GroupV1:
+		resp.Item = &objectspb.GetResponse_Group{Group: groupToPb(obj)}
+	case NoteV1:
+		resp.Item = &objectspb.GetResponse_Note{Note: noteToPb(obj)}
+	case SeasonV1:
+		resp.Item = &objectspb.GetResponse_Season{Season: seasonToPb(obj)}
+	case TimeOfDayV1:
+		resp.Item = &objectspb.GetResponse_TimeOfDay{TimeOfDay: timeOfDayToPb(obj)}
+	case TypeV1:
+		resp.Item = &objectspb.GetResponse_Type{Type: typeToPb(obj)}
+	case PerfumerV1:
+		resp.Item = &objectspb.GetResponse_Perfumer{Perfumer: perfumerToPb(obj)}
+	case TagV1:
+		resp.Item = &objectspb.GetResponse_Tag{Tag: tagToPb(obj)}
+	}
+
+	return resp, nil
+}
+
+func (srv *GrpcServer) Search(ctx context.Context, req *objectspb.SearchRequest) (*objectspb.ListResponse, error) {
+	if _, err := srv.factory(req.Kind); err != nil {
+		return nil, err
+	}
+	if err := checkStatuses(req.Statuses); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	base := BaseParams{Version: srv.Version}
+	base.Offset.Int64 = req.Offset
+	base.Offset.Valid = true
+	if req.Limit > 0 {
+		base.Limit.Int64 = req.Limit
+		base.Limit.Valid = true
+	}
+	table := kindTables[req.Kind]
+	var chain []string
+	if len(req.Locales) > 0 {
+		chain = LocaleChain(req.Locales)
+	}
+	match, err := searchAlias(req.Kind, req.Query)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	uid := ""
+	if match != nil {
+		uid = match.EntityUuid
+	}
+	args := bindArgs{}
+	condition := andCondition(searchMatch(&args, table, uid, req.Query, chain), statusCondition(table, req.Statuses))
+	dbQuery := NameQueryParams{
+		PageQueryParams:      newPageQueryParams(table, &base),
+		WhereConditionString: condition,
+	}
+
+	query, err := renderQuery("select_count_by_name", &dbQuery)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	total, err := dbmap.SelectInt(query, args...)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	query, err = renderQuery("select_uuids_by_name", &dbQuery)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	var uuids []string
+	if _, err := dbmap.Select(&uuids, query, args...); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	items := make([]BatchItemReq, 0, len(uuids))
+	for _, id := range uuids {
+		items = append(items, BatchItemReq{Kind: req.Kind, Uuid: id})
+	}
+	found, err := LoadBatchLocalized(srv.Version, req.Locales, items)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &objectspb.ListResponse{Total: total, Offset: dbQuery.Offset}
+	for _, id := range uuids {
+		switch obj := found[req.Kind][id].(type) {
+		case PerfumInfoV1:
+			resp.PerfumsInfo = append(resp.PerfumsInfo, perfumInfoToPb(obj))
+		case BrandV1:
+			resp.Brands = append(resp.Brands, brandToPb(obj))
+		case ComponentV1:
+			resp.Components = append(resp.Components, componentToPb(obj))
+		case CountryV1:
+			resp.Countries = append(resp.Countries, countryToPb(obj))
+		case GenderV1:
+			resp.Genders = append(resp.Genders, genderToPb(obj))
+		case GroupV1:
+			resp.Groups = append(resp.Groups, groupToPb(obj))
+		case NoteV1:
+			resp.Notes = append(resp.Notes, noteToPb(obj))
+		case SeasonV1:
+			resp.Seasons = append(resp.Seasons, seasonToPb(obj))
+		case TimeOfDayV1:
+			resp.TimesOfDay = append(resp.TimesOfDay, timeOfDayToPb(obj))
+		case TypeV1:
+			resp.Types = append(resp.Types, typeToPb(obj))
+		case PerfumerV1:
+			resp.Perfumers = append(resp.Perfumers, perfumerToPb(obj))
+		case TagV1:
+			resp.Tags = append(resp.Tags, tagToPb(obj))
+		}
+	}
+	resp.Amount = listResponseAmount(resp)
+
+	return resp, nil
+}
+
+func (srv *GrpcServer) Count(ctx context.Context, req *objectspb.CountRequest) (*objectspb.CountResponse, error) {
+	factory, err := srv.factory(req.Kind)
+	if err != nil {
+		return nil, err
+	}
+
+	params := &MakeObjParams{}
+	params.Base.Version = srv.Version
+	count, err := factory.Count(params)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &objectspb.CountResponse{Count: count}, nil
+}
+
+func listResponseAmount(resp *objectspb.ListResponse) int64 {
+	return int64(len(resp.PerfumsInfo) + len(resp.Brands) + len(resp.Components) +
+		len(resp.Countries) + len(resp.Genders) + len(resp.Groups) + len(resp.Notes) +
+		len(resp.Seasons) + len(resp.TimesOfDay) + len(resp.Types) + len(resp.Perfumers) + len(resp.Tags))
+}
+
+func linksToPb(links []LinkV1) []*objectspb.Link {
+	res := make([]*objectspb.Link, 0, len(links))
+	for _, link := range links {
+		res = append(res, &objectspb.Link{Href: link.Href, Rel: link.Rel, Method: link.Method})
+	}
+	return res
+}
+
+func perfumInfoToPb(info PerfumInfoV1) *objectspb.PerfumInfo {
+	return &objectspb.PerfumInfo{
+		Id:             info.Uuid,
+		Name:           info.Name,
+		DescriptionId:  info.DescriptionUuid,
+		Description:    info.Description,
+		Year:           info.Year,
+		BrandId:        info.BrandUuid,
+		BrandName:      info.BrandName,
+		GenderId:       info.GenderUuid,
+		GenderName:     info.GenderName,
+		GroupId:        info.GroupUuid,
+		GroupName:      info.GroupName,
+		CountryId:      info.CountryUuid,
+		CountryName:    info.CountryName,
+		SeasonId:       info.SeasonUuid,
+		SeasonName:     info.SeasonName,
+		TsodId:         info.TsodUuid,
+		TsodName:       info.TsodName,
+		TypeId:         info.TypeUuid,
+		TypeName:       info.TypeName,
+		StarsId:        info.StarsUuid.String,
+		ShopId:         info.ShopUuid.String,
+		ParentId:       info.ParentUuid.String,
+		FamilyRelation: info.FamilyRelation,
+		PerfumerIds:    perfumerIds(info.Perfumers),
+		SeasonIds:      taxonomyItemIds(info.Seasons),
+		TsodIds:        taxonomyItemIds(info.TimesOfDay),
+		TagIds:         taxonomyItemIds(info.Tags),
+		Status:         info.Status,
+		Links:          linksToPb(info.Links),
+		SmallImgUrl:    info.SmallImgUrl,
+		LargeImgUrl:    info.LargeImgUrl,
+	}
+}
+
+func compositionToPb(compos PerfumCompositionV1) *objectspb.PerfumComposition {
+	res := &objectspb.PerfumComposition{
+		Info:            perfumInfoToPb(compos.PerfumInfoV1),
+		TotalComponents: compos.TotalComponents,
+	}
+	for _, note := range compos.Notes {
+		pbNote := &objectspb.NoteItem{
+			NoteId:         note.Id,
+			NoteName:       note.Name,
+			Links:          linksToPb(note.Links),
+			ComponentCount: note.ComponentCount,
+			Level:          note.Level,
+			Position:       note.Position,
+		}
+		for _, comp := range note.Components {
+			pbNote.Components = append(pbNote.Components, &objectspb.ComponentItem{
+				ComponentId:   comp.Id,
+				ComponentName: comp.Name,
+				Links:         linksToPb(comp.Links),
+				Intensity:     comp.Intensity,
+				Percentage:    comp.Percentage,
+			})
+		}
+		res.Notes = append(res.Notes, pbNote)
+	}
+	return res
+}
+
+func brandToPb(item BrandV1) *objectspb.Brand {
+	return &objectspb.Brand{Id: item.Uuid, Name: item.Name, PerfumsCount: item.PerfumsCount,
+		Links: linksToPb(item.Links), SmallImgUrl: item.SmallImgUrl, LargeImgUrl: item.LargeImgUrl}
+}
+
+func componentToPb(item ComponentV1) *objectspb.Component {
+	return &objectspb.Component{Id: item.Uuid, Name: item.Name, PerfumsCount: item.PerfumsCount,
+		Links: linksToPb(item.Links), SmallImgUrl: item.SmallImgUrl, LargeImgUrl: item.LargeImgUrl}
+}
+
+func countryToPb(item CountryV1) *objectspb.Country {
+	return &objectspb.Country{Id: item.Uuid, Name: item.Name, PerfumsCount: item.PerfumsCount,
+		Links: linksToPb(item.Links), SmallImgUrl: item.SmallImgUrl, LargeImgUrl: item.LargeImgUrl}
+}
+
+func genderToPb(item GenderV1) *objectspb.Gender {
+	return &objectspb.Gender{Id: item.Uuid, Name: item.Name, PerfumsCount: item.PerfumsCount,
+		Links: linksToPb(item.Links), SmallImgUrl: item.SmallImgUrl, LargeImgUrl: item.LargeImgUrl}
+}
+
+func groupToPb(item GroupV1) *objectspb.Group {
+	return &objectspb.Group{Id: item.Uuid, Name: item.Name, PerfumsCount: item.PerfumsCount,
+		Links: linksToPb(item.Links), SmallImgUrl: item.SmallImgUrl, LargeImgUrl: item.LargeImgUrl}
+}
+
+func noteToPb(item NoteV1) *objectspb.Note {
+	return &objectspb.Note{Id: item.Uuid, Name: item.Name, PerfumsCount: item.PerfumsCount,
+		Links: linksToPb(item.Links), SmallImgUrl: item.SmallImgUrl, LargeImgUrl: item.LargeImgUrl}
+}
+
+func seasonToPb(item SeasonV1) *objectspb.Season {
+	return &objectspb.Season{Id: item.Uuid, Name: item.Name, PerfumsCount: item.PerfumsCount,
+		Links: linksToPb(item.Links), SmallImgUrl: item.SmallImgUrl, LargeImgUrl: item.LargeImgUrl}
+}
+
+func timeOfDayToPb(item TimeOfDayV1) *objectspb.TimeOfDay {
+	return &objectspb.TimeOfDay{Id: item.Uuid, Name: item.Name, PerfumsCount: item.PerfumsCount,
+		Links: linksToPb(item.Links), SmallImgUrl: item.SmallImgUrl, LargeImgUrl: item.LargeImgUrl}
+}
+
+func typeToPb(item TypeV1) *objectspb.Type {
+	return &objectspb.Type{Id: item.Uuid, Name: item.Name, PerfumsCount: item.PerfumsCount,
+		Links: linksToPb(item.Links), SmallImgUrl: item.SmallImgUrl, LargeImgUrl: item.LargeImgUrl}
+}
+
+func perfumerToPb(item PerfumerV1) *objectspb.Perfumer {
+	return &objectspb.Perfumer{Id: item.Uuid, Name: item.Name, PerfumsCount: item.PerfumsCount,
+		Links: linksToPb(item.Links), SmallImgUrl: item.SmallImgUrl, LargeImgUrl: item.LargeImgUrl}
+}
+
+func tagToPb(item TagV1) *objectspb.Tag {
+	return &objectspb.Tag{Id: item.Uuid, Name: item.Name, Kind: item.Kind, PerfumsCount: item.PerfumsCount,
+		Links: linksToPb(item.Links)}
+}
+
+func taxonomyItemIds(items []TaxonomyItemRefV1) []string {
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.Uuid)
+	}
+	return ids
+}
+
+func perfumerIds(perfumers []PerfumerRefV1) []string {
+	ids := make([]string, 0, len(perfumers))
+	for _, perfumer := range perfumers {
+		ids = append(ids, perfumer.Uuid)
+	}
+	return ids
+}
@@ -0,0 +1,126 @@
+package objects
+
+import (
+	"context"
+	"errors"
+	"github.com/rpiskun/objects/objectspb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"net"
+	"net/http"
+	"testing"
+)
+
+// stubObjecter serves a prepared collection instead of querying the
+// database.
+type stubObjecter struct {
+	count  int64
+	obj    Objecter
+	params *MakeObjParams
+}
+
+func (s *stubObjecter) MakeObj(pParams interface{}) (Objecter, error) {
+	s.params = pParams.(*MakeObjParams)
+	return s.obj, nil
+}
+
+func (s *stubObjecter) MakeExtraObj(params *MakeObjParams, uids []string) (Objecter, error) {
+	return nil, errors.New("not supported")
+}
+
+func (s *stubObjecter) Count(pParams interface{}) (int64, error) {
+	return s.count, nil
+}
+
+func (s *stubObjecter) ExtraCount(uids []string) (int64, error) {
+	return 0, errors.New("not supported")
+}
+
+func (s *stubObjecter) Json(w http.ResponseWriter, status int) error {
+	return nil
+}
+
+// stubFactory registers stub as the collection of kind in v1 until the
+// test ends.
+func stubFactory(t *testing.T, kind string, stub Objecter) {
+	factoriesMu.Lock()
+	previous := factories[kind]["v1"]
+	factoriesMu.Unlock()
+
+	RegisterFactory(kind, "v1", func() Objecter { return stub })
+	t.Cleanup(func() { RegisterFactory(kind, "v1", previous) })
+}
+
+func dialGrpc(t *testing.T, version string) objectspb.ObjectsClient {
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	RegisterGrpcServer(s, version)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return objectspb.NewObjectsClient(conn)
+}
+
+func TestGrpcList(t *testing.T) {
+	stub := &stubObjecter{
+		count: 42,
+		obj: &BrandsV1{ObjList: []BrandV1{
+			{Uuid: "b1", Name: "Chanel"},
+			{Uuid: "b2", Name: "Dior"},
+		}},
+	}
+	stubFactory(t, "brands", stub)
+	client := dialGrpc(t, "v1")
+
+	resp, err := client.List(context.Background(), &objectspb.ListRequest{Kind: "brand", Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Total != 42 || resp.Amount != 2 || len(resp.Brands) != 2 || resp.Brands[1].Name != "Dior" {
+		t.Fatalf("unexpected list %v", resp)
+	}
+
+	resp, err = client.List(context.Background(), &objectspb.ListRequest{Kind: "brand", Ids: []string{"b1", "b2", "b3"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Total != 3 {
+		t.Fatalf("total of ids = %d, want 3", resp.Total)
+	}
+	if stub.params.Base.Ids.String != "b1,b2,b3" {
+		t.Fatalf("ids passed as %q", stub.params.Base.Ids.String)
+	}
+}
+
+func TestGrpcListErrors(t *testing.T) {
+	client := dialGrpc(t, "v1")
+	tests := []struct {
+		req  *objectspb.ListRequest
+		code codes.Code
+	}{
+		{&objectspb.ListRequest{Kind: "unknown"}, codes.InvalidArgument},
+		{&objectspb.ListRequest{Kind: "composition"}, codes.InvalidArgument},
+		{&objectspb.ListRequest{Kind: "brand", Statuses: []string{"gone"}}, codes.InvalidArgument},
+	}
+	for _, test := range tests {
+		_, err := client.List(context.Background(), test.req)
+		if status.Code(err) != test.code {
+			t.Errorf("List(%v) = %v, want %v", test.req, err, test.code)
+		}
+	}
+
+	v2 := dialGrpc(t, "v2")
+	if _, err := v2.List(context.Background(), &objectspb.ListRequest{Kind: "brand"}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("List over v2 = %v, want %v", err, codes.Unimplemented)
+	}
+}
@@ -0,0 +1,399 @@
+package objects
+
+import (
+	"errors"
+	"strings"
+	"text/template"
+)
+
+// maxTreeDepth bounds the walks down a taxonomy tree.
+const maxTreeDepth = 16
+
+// taxonomyTree is a taxonomy whose items may have a parent, e.g. the
+// "Oriental Floral" group in the "Oriental" family or the "Bergamot"
+// component in the "Citrus" category. Parents are kept in taxonomy_tree.
+type taxonomyTree struct {
+	Kind  string
+	Table string
+}
+
+var groupsTree = taxonomyTree{Kind: "group", Table: "groups"}
+
+var componentsTree = taxonomyTree{Kind: "component", Table: "components"}
+
+// TreeQueryParams ...
+type TreeQueryParams struct {
+	Kind                 string
+	Table                string
+	WhereConditionString string
+	MaxDepth             int64
+}
+
+// TreeCountQueryParams ...
+type TreeCountQueryParams struct {
+	TreeQueryParams
+	JoinCondition    string
+	PerfumsCondition string
+}
+
+// TreeLinkDBRecordV1 ...
+type TreeLinkDBRecordV1 struct {
+	Uuid       string `db:"uuid"`
+	ParentUuid string `db:"parent_uuid"`
+}
+
+func init() {
+	template.Must(queries.Parse(`
+{{define "select_tree_parents"}}
+SELECT uuid, parent_uuid FROM taxonomy_tree
+WHERE taxonomy_tree.kind = '{{.Kind}}' AND {{.WhereConditionString}}
+{{end}}
+
+{{define "tree_subtree"}}
+WITH RECURSIVE sub (uuid, depth) AS (
+	SELECT {{.Table}}.uuid, 0 FROM {{.Table}} WHERE {{.WhereConditionString}}
+	UNION ALL
+	SELECT taxonomy_tree.uuid, sub.depth + 1 FROM taxonomy_tree
+	JOIN sub ON taxonomy_tree.parent_uuid = sub.uuid
+	WHERE taxonomy_tree.kind = '{{.Kind}}' AND sub.depth < {{.MaxDepth}}
+)
+{{end}}
+
+{{define "select_tree_descendants"}}
+{{template "tree_subtree" .}}
+SELECT DISTINCT uuid FROM sub
+{{end}}
+
+{{define "select_tree_perfums_count"}}
+{{template "tree_subtree" .}}
+SELECT COUNT(DISTINCT parfum_info.id) FROM sub
+JOIN {{.Table}} ON {{.Table}}.uuid = sub.uuid
+JOIN parfum_info ON {{.JoinCondition}}
+WHERE {{.PerfumsCondition}}
+{{end}}
+
+{{define "select_tree_perfums_counts"}}
+WITH RECURSIVE sub (root, uuid, depth) AS (
+	SELECT {{.Table}}.uuid, {{.Table}}.uuid, 0 FROM {{.Table}} WHERE {{.WhereConditionString}}
+	UNION ALL
+	SELECT sub.root, taxonomy_tree.uuid, sub.depth + 1 FROM taxonomy_tree
+	JOIN sub ON taxonomy_tree.parent_uuid = sub.uuid
+	WHERE taxonomy_tree.kind = '{{.Kind}}' AND sub.depth < {{.MaxDepth}}
+)
+SELECT sub.root AS uuid, COUNT(DISTINCT parfum_info.id) AS count FROM sub
+JOIN {{.Table}} ON {{.Table}}.uuid = sub.uuid
+JOIN parfum_info ON {{.JoinCondition}}
+WHERE {{.PerfumsCondition}}
+GROUP BY sub.root
+{{end}}
+`))
+}
+
+func (t taxonomyTree) queryParams(condition string) TreeQueryParams {
+	return TreeQueryParams{Kind: t.Kind, Table: t.Table, WhereConditionString: condition, MaxDepth: maxTreeDepth}
+}
+
+// rootsCondition selects the items without a parent.
+func (t taxonomyTree) rootsCondition() string {
+	return t.Table + ".uuid NOT IN (SELECT taxonomy_tree.uuid FROM taxonomy_tree WHERE taxonomy_tree.kind = '" +
+		t.Kind + "')"
+}
+
+// descendants returns uids together with all the items below them.
+func (t taxonomyTree) descendants(uids []string) ([]string, error) {
+	query, err := renderQuery("select_tree_descendants", t.queryParams(addIdsToQuery(uids, t.Table+".uuid")))
+	if err != nil {
+		return nil, err
+	}
+	var res []string
+	if _, err := dbmap.Select(&res, query); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// parents maps each of uids having a parent to it.
+func (t taxonomyTree) parents(uids []string) (map[string]string, error) {
+	res := make(map[string]string)
+	if len(uids) == 0 {
+		return res, nil
+	}
+	query, err := renderQuery("select_tree_parents", t.queryParams(addIdsToQuery(uids, "taxonomy_tree.uuid")))
+	if err != nil {
+		return nil, err
+	}
+	var links []TreeLinkDBRecordV1
+	if _, err := dbmap.Select(&links, query); err != nil {
+		return nil, err
+	}
+	for _, link := range links {
+		res[link.Uuid] = link.ParentUuid
+	}
+	return res, nil
+}
+
+// below returns the descendants of uids without uids themselves.
+func (t taxonomyTree) below(uids []string) ([]string, error) {
+	all, err := t.descendants(uids)
+	if err != nil {
+		return nil, err
+	}
+	res := []string{}
+	for _, uuid := range all {
+		if !containsString(uids, uuid) {
+			res = append(res, uuid)
+		}
+	}
+	return res, nil
+}
+
+// perfumsCounts counts the perfums in one of statuses of the whole subtree
+// of each of uids in one query. A perfum found under several items of a
+// subtree is counted once.
+func (t taxonomyTree) perfumsCounts(uids []string, statuses []string) (map[string]int64, error) {
+	res := make(map[string]int64, len(uids))
+	if len(uids) == 0 {
+		return res, nil
+	}
+	queryParams := TreeCountQueryParams{
+		TreeQueryParams:  t.queryParams(addIdsToQuery(uids, t.Table+".uuid")),
+		JoinCondition:    perfumsJoins[t.Table],
+		PerfumsCondition: statusCondition("parfum_info", statuses),
+	}
+	query, err := renderQuery("select_tree_perfums_counts", queryParams)
+	if err != nil {
+		return nil, err
+	}
+	var rows []PerfumsCountDBRecordV1
+	if _, err := dbmap.Select(&rows, query); err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		res[row.Uuid] = row.Count
+	}
+	return res, nil
+}
+
+// perfumsCount counts the perfums in one of statuses of the whole subtrees
+// of uids in one query, the perfums MakeExtraObj lists with
+// MakeObjParams.Descendants.
+func (t taxonomyTree) perfumsCount(uids []string, statuses []string) (int64, error) {
+	queryParams := TreeCountQueryParams{
+		TreeQueryParams:  t.queryParams(addIdsToQuery(uids, t.Table+".uuid")),
+		JoinCondition:    perfumsJoins[t.Table],
+		PerfumsCondition: statusCondition("parfum_info", statuses),
+	}
+	query, err := renderQuery("select_tree_perfums_count", queryParams)
+	if err != nil {
+		return 0, err
+	}
+	return dbmap.SelectInt(query)
+}
+
+// rollUpPerfumsCounts sets the PerfumsCount of each item of list, a pointer
+// to a slice of the items of the tree, to the number of perfums in one of
+// statuses of its whole subtree.
+func (t taxonomyTree) rollUpPerfumsCounts(list interface{}, statuses []string) error {
+	counts, err := t.perfumsCounts(listUuids(list), statuses)
+	if err != nil {
+		return err
+	}
+	setPerfumsCounts(list, counts)
+	return nil
+}
+
+// ParamsExtraCounter is implemented by the collections whose MakeExtraObj
+// lists more than the perfums of uids for some MakeObjParams: its
+// ExtraCountParams counts the perfums MakeExtraObj(params, uids) lists.
+type ParamsExtraCounter interface {
+	ExtraCountParams(params *MakeObjParams, uids []string) (int64, error)
+}
+
+// ExtraCountFor is the Total of obj.MakeExtraObj(params, uids): the
+// ExtraCount of obj over the perfums in params.Statuses, and over the
+// subtrees of uids with params.Descendants.
+func ExtraCountFor(obj Objecter, params *MakeObjParams, uids []string) (int64, error) {
+	if params == nil {
+		return obj.ExtraCount(uids)
+	}
+	if counter, ok := obj.(ParamsExtraCounter); ok {
+		return counter.ExtraCountParams(params, uids)
+	}
+	if counter, ok := obj.(StatusExtraCounter); ok {
+		return counter.ExtraCountStatuses(uids, params.Statuses)
+	}
+	if len(params.Statuses) > 0 {
+		return 0, errors.New("not supported")
+	}
+	return obj.ExtraCount(uids)
+}
+
+func (t taxonomyTree) parentLink(uuid string) LinkV1 {
+	return LinkV1{
+		Href:   baseUrl + "/" + t.Kind + "/" + uuid,
+		Rel:    "Parent" + strings.ToUpper(t.Kind[:1]) + t.Kind[1:],
+		Method: "GET",
+	}
+}
+
+// subtreeParams pages all of uuids in one go, for the children of a tree.
+func subtreeParams(params *MakeObjParams, uuids []string) *MakeObjParams {
+	sub := &MakeObjParams{Total: int64(len(uuids)), Locales: params.Locales, RollUp: params.RollUp, Statuses: params.Statuses}
+	sub.Base.Version = params.Base.Version
+	sub.Base.Ids.String = strings.Join(uuids, ",")
+	sub.Base.Ids.Valid = true
+	sub.Base.Limit.Int64 = int64(len(uuids))
+	sub.Base.Limit.Valid = true
+	return sub
+}
+
+// fillTree sets the parents of the groups of obj, rolls their perfum counts
+// up over their subtrees in one query and, for a tree, nests their
+// descendants.
+func (obj *GroupsV1) fillTree(params *MakeObjParams) error {
+	uuids := make([]string, 0, len(obj.ObjList))
+	for _, item := range obj.ObjList {
+		uuids = append(uuids, item.Uuid)
+	}
+	parents, err := groupsTree.parents(uuids)
+	if err != nil {
+		return err
+	}
+	for i := range obj.ObjList {
+		item := &obj.ObjList[i]
+		if parent, ok := parents[item.Uuid]; ok {
+			item.ParentUuid.String = parent
+			item.ParentUuid.Valid = true
+			item.Links = append(item.Links, groupsTree.parentLink(parent))
+		}
+	}
+	if params.RollUp {
+		if err := groupsTree.rollUpPerfumsCounts(&obj.ObjList, params.Statuses); err != nil {
+			return err
+		}
+	}
+
+	if !params.Tree || len(uuids) == 0 {
+		return nil
+	}
+	below, err := groupsTree.below(uuids)
+	if err != nil {
+		return err
+	}
+	if below, err = activeUuids(groupsTree.Table, below, params.Statuses); err != nil || len(below) == 0 {
+		return err
+	}
+	sub := &GroupsV1{}
+	if _, err := sub.MakeObj(subtreeParams(params, below)); err != nil {
+		return err
+	}
+	children := make(map[string][]GroupV1)
+	for _, item := range sub.ObjList {
+		children[item.ParentUuid.String] = append(children[item.ParentUuid.String], item)
+	}
+	visited := make(map[string]bool)
+	for i := range obj.ObjList {
+		nestGroups(&obj.ObjList[i], children, visited)
+	}
+	return nil
+}
+
+// ExtraCountParams counts the perfums in one of params.Statuses of the
+// groups uids, of their whole subtrees with params.Descendants.
+func (obj *GroupsV1) ExtraCountParams(params *MakeObjParams, uids []string) (int64, error) {
+	if !params.Descendants {
+		return obj.ExtraCountStatuses(uids, params.Statuses)
+	}
+	if len(uids) == 0 {
+		return 0, errors.New("invalid args")
+	}
+	if err := checkStatuses(params.Statuses); err != nil {
+		return 0, err
+	}
+	return groupsTree.perfumsCount(uids, params.Statuses)
+}
+
+func nestGroups(item *GroupV1, children map[string][]GroupV1, visited map[string]bool) {
+	if visited[item.Uuid] {
+		return
+	}
+	visited[item.Uuid] = true
+	item.Children = children[item.Uuid]
+	for i := range item.Children {
+		nestGroups(&item.Children[i], children, visited)
+	}
+}
+
+// fillTree is GroupsV1.fillTree for components and their categories.
+func (obj *ComponentsV1) fillTree(params *MakeObjParams) error {
+	uuids := make([]string, 0, len(obj.ObjList))
+	for _, item := range obj.ObjList {
+		uuids = append(uuids, item.Uuid)
+	}
+	parents, err := componentsTree.parents(uuids)
+	if err != nil {
+		return err
+	}
+	for i := range obj.ObjList {
+		item := &obj.ObjList[i]
+		if parent, ok := parents[item.Uuid]; ok {
+			item.ParentUuid.String = parent
+			item.ParentUuid.Valid = true
+			item.Links = append(item.Links, componentsTree.parentLink(parent))
+		}
+	}
+	if params.RollUp {
+		if err := componentsTree.rollUpPerfumsCounts(&obj.ObjList, params.Statuses); err != nil {
+			return err
+		}
+	}
+
+	if !params.Tree || len(uuids) == 0 {
+		return nil
+	}
+	below, err := componentsTree.below(uuids)
+	if err != nil {
+		return err
+	}
+	if below, err = activeUuids(componentsTree.Table, below, params.Statuses); err != nil || len(below) == 0 {
+		return err
+	}
+	sub := &ComponentsV1{}
+	if _, err := sub.MakeObj(subtreeParams(params, below)); err != nil {
+		return err
+	}
+	children := make(map[string][]ComponentV1)
+	for _, item := range sub.ObjList {
+		children[item.ParentUuid.String] = append(children[item.ParentUuid.String], item)
+	}
+	visited := make(map[string]bool)
+	for i := range obj.ObjList {
+		nestComponents(&obj.ObjList[i], children, visited)
+	}
+	return nil
+}
+
+// ExtraCountParams is GroupsV1.ExtraCountParams for components.
+func (obj *ComponentsV1) ExtraCountParams(params *MakeObjParams, uids []string) (int64, error) {
+	if !params.Descendants {
+		return obj.ExtraCountStatuses(uids, params.Statuses)
+	}
+	if len(uids) == 0 {
+		return 0, errors.New("invalid args")
+	}
+	if err := checkStatuses(params.Statuses); err != nil {
+		return 0, err
+	}
+	return componentsTree.perfumsCount(uids, params.Statuses)
+}
+
+func nestComponents(item *ComponentV1, children map[string][]ComponentV1, visited map[string]bool) {
+	if visited[item.Uuid] {
+		return
+	}
+	visited[item.Uuid] = true
+	item.Children = children[item.Uuid]
+	for i := range item.Children {
+		nestComponents(&item.Children[i], children, visited)
+	}
+}
@@ -0,0 +1,57 @@
+package objects
+
+import (
+	"database/sql/driver"
+	"strings"
+	"testing"
+)
+
+func TestExtraCountForDescendants(t *testing.T) {
+	fake := useFakeDB(t, func(query string, args []driver.Value) (*fakeResult, error) {
+		return fakeColumn("count", int64(7)), nil
+	})
+
+	// the count walks the same subtree MakeExtraObj lists the perfums of
+	descendants, err := renderQuery("select_tree_descendants", groupsTree.queryParams(addIdsToQuery([]string{"g1"}, "groups.uuid")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	subtree := strings.TrimSpace(descendants[:strings.Index(descendants, "SELECT DISTINCT")])
+
+	v2, err := NewObjecter("groups", "v2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, obj := range []Objecter{&GroupsV1{}, v2} {
+		count, err := ExtraCountFor(obj, &MakeObjParams{Descendants: true}, []string{"g1"})
+		if err != nil || count != 7 {
+			t.Fatalf("%T count = %d, %v, want 7", obj, count, err)
+		}
+	}
+
+	statements := fake.ran("COUNT(DISTINCT parfum_info.id)")
+	if len(statements) != 2 || len(fake.statements) != 2 {
+		t.Fatalf("counted with %v", fake.statements)
+	}
+	for _, want := range []string{subtree, "JOIN parfum_info ON parfum_info.group_id = groups.id", "'active'"} {
+		if !strings.Contains(statements[0].query, want) {
+			t.Errorf("no %q in %s", want, statements[0].query)
+		}
+	}
+
+	// the subtree is counted over the perfums in the statuses listed
+	if _, err := ExtraCountFor(v2, &MakeObjParams{Descendants: true, Statuses: []string{StatusArchived}}, []string{"g1"}); err != nil {
+		t.Fatal(err)
+	}
+	statements = fake.ran("COUNT(DISTINCT parfum_info.id)")
+	if len(statements) != 3 || !strings.Contains(statements[2].query, "IN ('archived')") {
+		t.Errorf("statuses not counted: %v", statements)
+	}
+	if _, err := ExtraCountFor(v2, &MakeObjParams{Descendants: true, Statuses: []string{"gone"}}, []string{"g1"}); err != ErrInvalidStatus {
+		t.Errorf("unknown status: %v", err)
+	}
+
+	if _, err := ExtraCountFor(&ComponentsV1{}, &MakeObjParams{Descendants: true}, nil); err == nil {
+		t.Error("count of no components accepted")
+	}
+}
@@ -0,0 +1,394 @@
+package objects
+
+import (
+	"context"
+	"errors"
+	"github.com/unrolled/render"
+	"gopkg.in/gorp.v1"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// DefaultLocale is the locale of the names and descriptions stored in the
+// entity tables themselves; it ends every locale chain.
+var DefaultLocale = "ru"
+
+// LocaleFallbacks lists the locales tried after a requested language before
+// DefaultLocale.
+var LocaleFallbacks = map[string][]string{
+	"uk": {"en"},
+}
+
+var ErrInvalidTranslation = errors.New("invalid translation")
+
+// TranslationReq sets the text of Field ("name", or "description" of a
+// perfum) of the Kind item Uuid in Locale.
+type TranslationReq struct {
+	Kind   string `json:"kind"`
+	Uuid   string `json:"id"`
+	Field  string `json:"field"`
+	Locale string `json:"locale"`
+	Text   string `json:"text"`
+}
+
+// TranslationV1 ...
+type TranslationV1 struct {
+	Kind   string `db:"kind" json:"kind"`
+	Uuid   string `db:"uuid" json:"id"`
+	Field  string `db:"field" json:"field"`
+	Locale string `db:"locale" json:"locale"`
+	Text   string `db:"text" json:"text"`
+}
+
+// TranslationsV1 lists the translations of one item.
+type TranslationsV1 struct {
+	ObjList []TranslationV1 `db:"-" json:"translations_list"`
+	Total   int64           `db:"-" json:"total"`
+	Offset  int64           `db:"-" json:"offset"`
+	Amount  int64           `db:"-" json:"amount"`
+}
+
+// TranslationQueryParams ...
+type TranslationQueryParams struct {
+	WhereConditionString string
+	Locales              string
+}
+
+func init() {
+	template.Must(queries.Parse(`
+{{define "upsert_translation"}}
+INSERT INTO translations (kind, uuid, field, locale, text)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (uuid, field, locale)
+DO UPDATE SET text = EXCLUDED.text
+{{end}}
+
+{{define "select_translation"}}
+SELECT kind, uuid, field, locale, text FROM translations
+WHERE uuid = $1 AND field = $2 AND locale = $3
+{{end}}
+
+{{define "delete_translation"}}
+DELETE FROM translations
+WHERE uuid = $1 AND field = $2 AND locale = $3
+{{end}}
+
+{{define "select_translations"}}
+SELECT kind, uuid, field, locale, text FROM translations
+WHERE {{.WhereConditionString}}{{if .Locales}} AND translations.locale IN ({{.Locales}}){{end}}
+ORDER BY translations.field, translations.locale
+{{end}}
+`))
+}
+
+// ParseAcceptLanguage returns the locales of an Accept-Language header,
+// most preferred first.
+func ParseAcceptLanguage(header string) []string {
+	type weighted struct {
+		locale string
+		q      float64
+	}
+	list := []weighted{}
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		locale := strings.TrimSpace(fields[0])
+		if locale == "" || locale == "*" {
+			continue
+		}
+		q := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if v, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					q = v
+				}
+			}
+		}
+		if q > 0 {
+			list = append(list, weighted{locale, q})
+		}
+	}
+	sort.SliceStable(list, func(i, j int) bool { return list[i].q > list[j].q })
+
+	locales := make([]string, 0, len(list))
+	for _, w := range list {
+		locales = append(locales, w.locale)
+	}
+	return locales
+}
+
+// LocaleChain expands the requested locales into the order texts are looked
+// up in: each locale, its language, the language's fallbacks and finally
+// DefaultLocale. "uk-UA" gives uk-UA, uk, en, ru.
+func LocaleChain(requested []string) []string {
+	chain := []string{}
+	for _, locale := range requested {
+		locale = strings.Replace(locale, "_", "-", -1)
+		language := strings.ToLower(strings.SplitN(locale, "-", 2)[0])
+		if language == "" {
+			continue
+		}
+		if locale != language {
+			chain = appendUnique(chain, locale)
+		}
+		chain = appendUnique(chain, language)
+	}
+	for _, locale := range append([]string{}, chain...) {
+		for _, fallback := range LocaleFallbacks[locale] {
+			chain = appendUnique(chain, fallback)
+		}
+	}
+	return appendUnique(chain, DefaultLocale)
+}
+
+// localizedText is a text field of an object to be resolved by a localizer.
+type localizedText struct {
+	uuid    string
+	field   string
+	text    *string
+	locales *map[string]string
+	key     string
+}
+
+// localizer replaces the texts added to it by their translations in the
+// best locale of its chain and reports the locale each one was served in.
+// A nil localizer, made when no locale is requested, leaves texts as they
+// are.
+type localizer struct {
+	chain []string
+	texts []localizedText
+}
+
+func newLocalizer(requested []string) *localizer {
+	if len(requested) == 0 {
+		return nil
+	}
+	return &localizer{chain: LocaleChain(requested)}
+}
+
+// add registers the field of the item uuid held in text; the locale it is
+// served in goes to (*locales)[key].
+func (l *localizer) add(locales *map[string]string, key, uuid, field string, text *string) {
+	if l == nil || uuid == "" {
+		return
+	}
+	l.texts = append(l.texts, localizedText{uuid, field, text, locales, key})
+}
+
+func (l *localizer) resolve() error {
+	if l == nil || len(l.texts) == 0 {
+		return nil
+	}
+
+	uuids := []string{}
+	for _, t := range l.texts {
+		uuids = appendUnique(uuids, t.uuid)
+	}
+	args := bindArgs{}
+	queryParams := TranslationQueryParams{
+		WhereConditionString: addIdsToQuery(uuids, "translations.uuid"),
+		Locales:              args.list(l.chain),
+	}
+	query, err := renderQuery("select_translations", queryParams)
+	if err != nil {
+		return err
+	}
+	var rows []TranslationV1
+	if _, err := dbmap.Select(&rows, query, args...); err != nil {
+		return err
+	}
+
+	found := make(map[string]string, len(rows))
+	for _, row := range rows {
+		found[row.Uuid+"/"+row.Field+"/"+row.Locale] = row.Text
+	}
+
+	for _, t := range l.texts {
+		served := DefaultLocale
+		for _, locale := range l.chain {
+			if text, ok := found[t.uuid+"/"+t.field+"/"+locale]; ok {
+				*t.text = text
+				served = locale
+				break
+			}
+			if locale == DefaultLocale {
+				break
+			}
+		}
+		if *t.locales == nil {
+			*t.locales = make(map[string]string)
+		}
+		(*t.locales)[t.key] = served
+	}
+
+	return nil
+}
+
+// addPerfumInfo registers the texts of a perfum info, the names of its
+// taxonomies included.
+func (l *localizer) addPerfumInfo(info *PerfumInfoV1) {
+	l.add(&info.Locales, "name", info.Uuid, "name", &info.Name)
+	l.add(&info.Locales, "description", info.Uuid, "description", &info.Description)
+	l.add(&info.Locales, "brand_name", info.BrandUuid, "name", &info.BrandName)
+	l.add(&info.Locales, "gender_name", info.GenderUuid, "name", &info.GenderName)
+	l.add(&info.Locales, "group_name", info.GroupUuid, "name", &info.GroupName)
+	l.add(&info.Locales, "country_name", info.CountryUuid, "name", &info.CountryName)
+	l.add(&info.Locales, "season_name", info.SeasonUuid, "name", &info.SeasonName)
+	l.add(&info.Locales, "tsod_name", info.TsodUuid, "name", &info.TsodName)
+	l.add(&info.Locales, "type_name", info.TypeUuid, "name", &info.TypeName)
+	for i := range info.Seasons {
+		l.add(&info.Locales, "seasons."+info.Seasons[i].Uuid, info.Seasons[i].Uuid, "name", &info.Seasons[i].Name)
+	}
+	for i := range info.TimesOfDay {
+		l.add(&info.Locales, "times_of_day."+info.TimesOfDay[i].Uuid, info.TimesOfDay[i].Uuid, "name", &info.TimesOfDay[i].Name)
+	}
+}
+
+// localizedNameCondition matches pattern against the name of the items of
+// table, stored or translated into one of the locales of chain.
+func localizedNameCondition(args *bindArgs, table, pattern string, chain []string) string {
+	p := args.add(pattern)
+	if len(chain) == 0 {
+		return table + ".name ILIKE " + p
+	}
+	return "(" + table + ".name ILIKE " + p +
+		" OR EXISTS (SELECT 1 FROM translations WHERE translations.uuid = " + table + ".uuid" +
+		" AND translations.field = 'name' AND translations.locale IN (" + args.list(chain) + ")" +
+		" AND translations.text ILIKE " + p + "))"
+}
+
+// SetTranslation stores req, replacing the text of its field in its locale.
+func SetTranslation(ctx context.Context, req *TranslationReq) error {
+	if req == nil || req.Uuid == "" || req.Locale == "" || req.Text == "" {
+		return ErrInvalidTranslation
+	}
+	if _, ok := kindTables[req.Kind]; !ok {
+		return ErrInvalidTranslation
+	}
+	if req.Field != "name" && !(req.Field == "description" && req.Kind == "perfum") {
+		return ErrInvalidTranslation
+	}
+
+	query, err := renderQuery("upsert_translation", nil)
+	if err != nil {
+		return err
+	}
+
+	tx, err := dbmap.Begin()
+	if err != nil {
+		return err
+	}
+	before, err := selectTranslation(tx, req.Uuid, req.Field, req.Locale)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if _, err := tx.Exec(query, req.Kind, req.Uuid, req.Field, req.Locale, req.Text); err != nil {
+		tx.Rollback()
+		return err
+	}
+	after := &TranslationV1{Kind: req.Kind, Uuid: req.Uuid, Field: req.Field, Locale: req.Locale, Text: req.Text}
+	if err := recordAudit(tx, ctx, AuditTranslation, req.Kind, req.Uuid, before, after); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// DeleteTranslation drops the text of field of the item uuid in locale.
+func DeleteTranslation(ctx context.Context, uuid, field, locale string) error {
+	query, err := renderQuery("delete_translation", nil)
+	if err != nil {
+		return err
+	}
+
+	tx, err := dbmap.Begin()
+	if err != nil {
+		return err
+	}
+	before, err := selectTranslation(tx, uuid, field, locale)
+	if err != nil || before == nil {
+		tx.Rollback()
+		return err
+	}
+	if _, err := tx.Exec(query, uuid, field, locale); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := recordAudit(tx, ctx, AuditTranslation, before.Kind, uuid, before, nil); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// selectTranslation returns the text of field of the item uuid in locale, or
+// nil.
+func selectTranslation(exec gorp.SqlExecutor, uuid, field, locale string) (*TranslationV1, error) {
+	query, err := renderQuery("select_translation", nil)
+	if err != nil {
+		return nil, err
+	}
+	var res []TranslationV1
+	if _, err := exec.Select(&res, query, uuid, field, locale); err != nil {
+		return nil, err
+	}
+	if len(res) == 0 {
+		return nil, nil
+	}
+	return &res[0], nil
+}
+
+func NewTranslationsFactory(version string) (Objecter, error) {
+	return NewObjecter("translations", version)
+}
+
+// MakeObj lists the translations of the item params.Id, in the locales of
+// params.Locales if given.
+func (obj *TranslationsV1) MakeObj(pParams interface{}) (Objecter, error) {
+	if pParams == nil {
+		return nil, errors.New("invalid args")
+	}
+	params := pParams.(*MakeObjParams)
+	if params.Id == "" {
+		return nil, errors.New("invalid args")
+	}
+
+	args := bindArgs{}
+	queryParams := TranslationQueryParams{WhereConditionString: "translations.uuid = " + args.add(params.Id)}
+	if len(params.Locales) > 0 {
+		queryParams.Locales = args.list(params.Locales)
+	}
+	query, err := renderQuery("select_translations", queryParams)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := dbmap.Select(&obj.ObjList, query, args...); err != nil {
+		return nil, err
+	}
+
+	obj.Total = int64(len(obj.ObjList))
+	obj.Amount = int64(len(obj.ObjList))
+
+	return obj, nil
+}
+
+func (obj *TranslationsV1) MakeExtraObj(params *MakeObjParams, uids []string) (Objecter, error) {
+	return nil, errors.New("not supported")
+}
+
+func (obj *TranslationsV1) Count(pParams interface{}) (int64, error) {
+	return 0, errors.New("not supported")
+}
+
+func (obj *TranslationsV1) ExtraCount(uids []string) (int64, error) {
+	return 0, errors.New("not supported")
+}
+
+func (obj *TranslationsV1) Json(w http.ResponseWriter, status int) error {
+	render := render.New()
+	return render.JSON(w, status, obj)
+}
@@ -0,0 +1,115 @@
+package objects
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"testing"
+)
+
+func TestLocaleChain(t *testing.T) {
+	tests := []struct {
+		requested []string
+		want      string
+	}{
+		{nil, "ru"},
+		{[]string{"ru"}, "ru"},
+		{[]string{"uk-UA"}, "uk-UA,uk,en,ru"},
+		{[]string{"en_GB", "fr"}, "en-GB,en,fr,ru"},
+		{[]string{"fr", "uk"}, "fr,uk,en,ru"},
+		{[]string{"", "-x"}, "ru"},
+	}
+	for _, test := range tests {
+		if got := strings.Join(LocaleChain(test.requested), ","); got != test.want {
+			t.Errorf("LocaleChain(%v) = %s, want %s", test.requested, got, test.want)
+		}
+	}
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	got := strings.Join(ParseAcceptLanguage("en;q=0.5, uk-UA, ru;q=0.8, *;q=0.1, de;q=0"), ",")
+	if got != "uk-UA,ru,en" {
+		t.Errorf("got %s", got)
+	}
+}
+
+func TestLocalizerFallback(t *testing.T) {
+	if newLocalizer(nil) != nil {
+		t.Fatal("localizer made without locales")
+	}
+
+	fake := useFakeDB(t, func(query string, args []driver.Value) (*fakeResult, error) {
+		return &fakeResult{
+			columns: []string{"kind", "uuid", "field", "locale", "text"},
+			rows: [][]driver.Value{
+				{"brand", "b1", "name", "en", "Chanel"},
+				{"brand", "b2", "name", "en", "Dior"},
+				{"brand", "b2", "name", "uk", "Діор"},
+			},
+		}, nil
+	})
+
+	brands := []BrandV1{{Uuid: "b1", Name: "Шанель"}, {Uuid: "b2", Name: "Диор"}, {Uuid: "b3", Name: "Guerlain"}}
+	l := newLocalizer([]string{"uk-UA"})
+	for i := range brands {
+		l.add(&brands[i].Locales, "name", brands[i].Uuid, "name", &brands[i].Name)
+	}
+	if err := l.resolve(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct{ name, locale string }{{"Chanel", "en"}, {"Діор", "uk"}, {"Guerlain", "ru"}}
+	for i, w := range want {
+		if brands[i].Name != w.name || brands[i].Locales["name"] != w.locale {
+			t.Errorf("brand %s served %q in %s, want %q in %s", brands[i].Uuid,
+				brands[i].Name, brands[i].Locales["name"], w.name, w.locale)
+		}
+	}
+	if statements := fake.ran("FROM translations"); len(statements) != 1 || len(statements[0].args) != 4 {
+		t.Errorf("translations read with %v, want one query over the chain", statements)
+	}
+}
+
+func TestSetTranslation(t *testing.T) {
+	fake := useFakeDB(t, nil)
+	invalid := []*TranslationReq{
+		nil,
+		{Kind: "brand", Uuid: "b1", Field: "name", Locale: "en"},
+		{Kind: "planet", Uuid: "b1", Field: "name", Locale: "en", Text: "Mars"},
+		{Kind: "brand", Uuid: "b1", Field: "description", Locale: "en", Text: "Maison"},
+	}
+	for _, req := range invalid {
+		if err := SetTranslation(testClaimsContext(), req); err != ErrInvalidTranslation {
+			t.Errorf("SetTranslation(%+v) = %v, want %v", req, err, ErrInvalidTranslation)
+		}
+	}
+	if len(fake.statements) != 0 {
+		t.Fatalf("invalid translations ran %v", fake.statements)
+	}
+
+	req := &TranslationReq{Kind: "brand", Uuid: "b1", Field: "name", Locale: "en", Text: "Chanel"}
+	if err := SetTranslation(context.Background(), req); err != ErrUnauthenticated {
+		t.Fatalf("anonymous SetTranslation = %v, want %v", err, ErrUnauthenticated)
+	}
+	if fake.commits != 0 || fake.rollbacks != 1 {
+		t.Fatalf("anonymous change committed: %d commits, %d rollbacks", fake.commits, fake.rollbacks)
+	}
+
+	if err := SetTranslation(testClaimsContext(), req); err != nil {
+		t.Fatal(err)
+	}
+	upserts := fake.ran("INSERT INTO translations")
+	if len(upserts) != 2 || upserts[1].args[4] != "Chanel" {
+		t.Fatalf("upserts %v", upserts)
+	}
+	audits := fake.ran("INSERT INTO audit_log")
+	if len(audits) != 1 || audits[0].args[1] != "u1" || audits[0].args[4] != AuditTranslation {
+		t.Fatalf("audit entries %v", audits)
+	}
+	if events := fake.ran("INSERT INTO outbox"); len(events) != 1 || events[0].args[1] != "b1" {
+		t.Fatalf("outbox events %v", events)
+	}
+	if fake.commits != 1 {
+		t.Fatalf("%d commits, want 1", fake.commits)
+	}
+}
@@ -0,0 +1,188 @@
+package objects
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ImageUrlBuilder makes the URL a rendition of an image is fetched from.
+type ImageUrlBuilder interface {
+	ImageUrl(imageUuid, size string) string
+}
+
+// imageUrls builds every image URL the objects emit.
+var imageUrls ImageUrlBuilder = BaseImageUrls{}
+
+// SetImageUrlBuilder replaces the builder of image URLs, nil restores
+// BaseImageUrls. It is meant to be called once at start up.
+func SetImageUrlBuilder(builder ImageUrlBuilder) {
+	if builder == nil {
+		builder = BaseImageUrls{}
+	}
+	imageUrls = builder
+}
+
+// BaseImageUrls serves images from baseUrl.
+type BaseImageUrls struct{}
+
+func (BaseImageUrls) ImageUrl(imageUuid, size string) string {
+	return baseUrl + imagePath(imageUuid, size)
+}
+
+// CdnImageUrls serves images from Host, e.g. "https://cdn.example.com".
+type CdnImageUrls struct {
+	Host string
+}
+
+func (c CdnImageUrls) ImageUrl(imageUuid, size string) string {
+	return strings.TrimRight(c.Host, "/") + imagePath(imageUuid, size)
+}
+
+// defaultImageUrlTTL is the TTL of SignedImageUrls that set none.
+const defaultImageUrlTTL = time.Hour
+
+// SignedImageUrls adds an expiry and a signature of it to the URLs of Next.
+// Expiries are rounded up to whole TTLs so URLs stay cacheable for a while.
+type SignedImageUrls struct {
+	Next   ImageUrlBuilder
+	Secret []byte
+	// TTL defaults to defaultImageUrlTTL when not positive.
+	TTL time.Duration
+	// Now is used in place of time.Now when set.
+	Now func() time.Time
+}
+
+func (s SignedImageUrls) ImageUrl(imageUuid, size string) string {
+	ttl := s.ttl()
+	expires := s.now().Truncate(ttl).Add(2 * ttl).Unix()
+	return s.Next.ImageUrl(imageUuid, size) +
+		"?expires=" + strconv.FormatInt(expires, 10) +
+		"&sig=" + s.sign(imageUuid, size, expires)
+}
+
+// Verify checks the expiry and signature of a request for a rendition.
+func (s SignedImageUrls) Verify(r *http.Request) bool {
+	imageUuid, size, ok := imagePathParts(r.URL.Path)
+	if !ok {
+		return false
+	}
+	expires, err := strconv.ParseInt(r.URL.Query().Get("expires"), 10, 64)
+	if err != nil || s.now().Unix() > expires {
+		return false
+	}
+	sig := s.sign(imageUuid, size, expires)
+	return hmac.Equal([]byte(sig), []byte(r.URL.Query().Get("sig")))
+}
+
+func (s SignedImageUrls) sign(imageUuid, size string, expires int64) string {
+	mac := hmac.New(sha256.New, s.Secret)
+	mac.Write([]byte(imagePath(imageUuid, size) + "\n" + strconv.FormatInt(expires, 10)))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (s SignedImageUrls) ttl() time.Duration {
+	if s.TTL <= 0 {
+		return defaultImageUrlTTL
+	}
+	return s.TTL
+}
+
+func (s SignedImageUrls) now() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+	return time.Now()
+}
+
+// RequireSignedImages lets through only the requests Verify accepts.
+func RequireSignedImages(urls SignedImageUrls, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !urls.Verify(r) {
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func imagePath(imageUuid, size string) string {
+	return "/image/" + imageUuid + "/" + size
+}
+
+// imagePathParts splits a /image/{uuid}/{size} path.
+func imagePathParts(path string) (string, string, bool) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) < 2 {
+		return "", "", false
+	}
+	imageUuid, size := parts[len(parts)-2], parts[len(parts)-1]
+	if imageUuid == "" || size == "" || strings.ContainsAny(imageUuid+size, `.\`) {
+		return "", "", false
+	}
+	return imageUuid, size, true
+}
+
+// imageUrlPair returns the small and large URLs of the v1 objects.
+func imageUrlPair(imageUuid string) (string, string) {
+	return imageUrls.ImageUrl(imageUuid, "small"), imageUrls.ImageUrl(imageUuid, "large")
+}
+
+// ImageSizeV2 ...
+type ImageSizeV2 struct {
+	Size   string `json:"size"`
+	Url    string `json:"url"`
+	Width  int64  `json:"width"`
+	Height int64  `json:"height"`
+}
+
+// ImageRefV2 replaces the small_img_url/large_img_url pair of v1. Width
+// and height are 0 for images uploaded before renditions were recorded, and
+// such images have no srcset.
+type ImageRefV2 struct {
+	Id     string        `json:"id"`
+	Url    string        `json:"url"`
+	Srcset string        `json:"srcset"`
+	Sizes  []ImageSizeV2 `json:"sizes"`
+}
+
+func newImageRefV2(imageUuid string, renditions []ImageRenditionV1) *ImageRefV2 {
+	ref := &ImageRefV2{
+		Id:  imageUuid,
+		Url: imageUrls.ImageUrl(imageUuid, "large"),
+	}
+	if len(renditions) == 0 {
+		for _, size := range DefaultImageSizes {
+			ref.Sizes = append(ref.Sizes, ImageSizeV2{Size: size.Name, Url: imageUrls.ImageUrl(imageUuid, size.Name)})
+		}
+		return ref
+	}
+
+	srcset := make([]string, 0, len(renditions))
+	for _, r := range renditions {
+		url := imageUrls.ImageUrl(imageUuid, r.Size)
+		ref.Sizes = append(ref.Sizes, ImageSizeV2{Size: r.Size, Url: url, Width: r.Width, Height: r.Height})
+		srcset = append(srcset, url+" "+strconv.FormatInt(r.Width, 10)+"w")
+	}
+	ref.Srcset = strings.Join(srcset, ", ")
+
+	return ref
+}
+
+// imageRefsV2 builds the references of the images uuids in one query.
+func imageRefsV2(uuids []string) (map[string]*ImageRefV2, error) {
+	renditions, err := imageRenditions(uuids)
+	if err != nil {
+		return nil, err
+	}
+
+	refs := make(map[string]*ImageRefV2, len(uuids))
+	for _, imageUuid := range uuids {
+		refs[imageUuid] = newImageRefV2(imageUuid, renditions[imageUuid])
+	}
+	return refs, nil
+}
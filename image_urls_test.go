@@ -0,0 +1,79 @@
+package objects
+
+import (
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestImageUrls(ttl time.Duration, clock *testClock) SignedImageUrls {
+	return SignedImageUrls{
+		Next:   CdnImageUrls{Host: "https://cdn.example.com/"},
+		Secret: []byte("image secret"),
+		TTL:    ttl,
+		Now:    clock.Now,
+	}
+}
+
+func TestSignedImageUrlsVerify(t *testing.T) {
+	clock := &testClock{now: time.Date(2024, 1, 1, 12, 30, 0, 0, time.UTC)}
+	urls := newTestImageUrls(time.Hour, clock)
+	signed := urls.ImageUrl("i1", "small")
+	if !strings.HasPrefix(signed, "https://cdn.example.com/image/i1/small?expires=") {
+		t.Fatalf("unexpected url %s", signed)
+	}
+	u, err := url.Parse(signed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// expiries are rounded up to whole TTLs: valid until 14:00
+	if !urls.Verify(httptest.NewRequest("GET", u.RequestURI(), nil)) {
+		t.Fatal("fresh url rejected")
+	}
+	clock.now = time.Date(2024, 1, 1, 14, 0, 0, 0, time.UTC)
+	if !urls.Verify(httptest.NewRequest("GET", u.RequestURI(), nil)) {
+		t.Fatal("url rejected at its expiry")
+	}
+	clock.now = clock.now.Add(time.Second)
+	if urls.Verify(httptest.NewRequest("GET", u.RequestURI(), nil)) {
+		t.Fatal("expired url accepted")
+	}
+	clock.now = time.Date(2024, 1, 1, 12, 30, 0, 0, time.UTC)
+
+	query := u.Query()
+	tampered := []string{
+		"/image/i2/small?" + query.Encode(),
+		"/image/i1/large?" + query.Encode(),
+		"/image/i1/small?expires=" + "9999999999" + "&sig=" + query.Get("sig"),
+		"/image/i1/small?expires=" + query.Get("expires") + "&sig=" + strings.Repeat("A", len(query.Get("sig"))),
+		"/image/i1/small?expires=" + query.Get("expires"),
+		"/image/i1/small",
+	}
+	for _, path := range tampered {
+		if urls.Verify(httptest.NewRequest("GET", path, nil)) {
+			t.Errorf("tampered url %s accepted", path)
+		}
+	}
+
+	other := urls
+	other.Secret = []byte("other secret")
+	if other.Verify(httptest.NewRequest("GET", u.RequestURI(), nil)) {
+		t.Error("url accepted under another secret")
+	}
+}
+
+func TestSignedImageUrlsZeroTTL(t *testing.T) {
+	clock := &testClock{now: time.Date(2024, 1, 1, 12, 30, 0, 0, time.UTC)}
+	urls := newTestImageUrls(0, clock)
+	u, err := url.Parse(urls.ImageUrl("i1", "large"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	clock.now = clock.now.Add(time.Minute)
+	if !urls.Verify(httptest.NewRequest("GET", u.RequestURI(), nil)) {
+		t.Fatal("url without a TTL expired at once")
+	}
+}
@@ -0,0 +1,562 @@
+package objects
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/unrolled/render"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+)
+
+var (
+	ErrImageNotFound = errors.New("image not found")
+	ErrImageTooLarge = errors.New("image too large")
+	ErrUnknownOwner  = errors.New("unknown image owner kind")
+)
+
+const (
+	maxImageBytes  = 20 << 20
+	maxImagePixels = 40 << 20
+	jpegQuality    = 85
+)
+
+// ImageSize is a rendition fitted into MaxWidth x MaxHeight keeping the
+// aspect ratio. Images are never scaled up.
+type ImageSize struct {
+	Name      string
+	MaxWidth  int
+	MaxHeight int
+}
+
+// DefaultImageSizes are the renditions the /image/{id}/small|large links
+// point at.
+var DefaultImageSizes = []ImageSize{
+	{Name: "small", MaxWidth: 240, MaxHeight: 240},
+	{Name: "large", MaxWidth: 1024, MaxHeight: 1024},
+}
+
+// ImageStore keeps the encoded renditions, keyed by "<image uuid>/<size>".
+type ImageStore interface {
+	Put(ctx context.Context, key string, data []byte) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// FileImageStore is an ImageStore on the local filesystem under Root.
+type FileImageStore struct {
+	Root string
+}
+
+func (s FileImageStore) path(key string) (string, error) {
+	path := filepath.Join(s.Root, filepath.FromSlash(key))
+	if !strings.HasPrefix(path, filepath.Clean(s.Root)+string(filepath.Separator)) {
+		return "", errors.New("invalid image key")
+	}
+	return path, nil
+}
+
+func (s FileImageStore) Put(ctx context.Context, key string, data []byte) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	// write aside and rename so readers never see a partial file
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func (s FileImageStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, ErrImageNotFound
+	}
+	return f, err
+}
+
+func (s FileImageStore) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// ImageRenditionV1 ...
+type ImageRenditionV1 struct {
+	ImageUuid string `db:"image_uuid" json:"-"`
+	Size      string `db:"size" json:"size"`
+	Width     int64  `db:"width" json:"width"`
+	Height    int64  `db:"height" json:"height"`
+	Format    string `db:"format" json:"format"`
+	Bytes     int64  `db:"bytes" json:"bytes"`
+	Url       string `db:"-" json:"url"`
+}
+
+// ImageV1 describes an uploaded image: the original's dimensions and
+// format and its renditions.
+type ImageV1 struct {
+	Uuid       string             `db:"uuid" json:"id"`
+	OwnerKind  string             `db:"owner_kind" json:"owner_kind"`
+	OwnerUuid  string             `db:"owner_uuid" json:"owner_id"`
+	Width      int64              `db:"width" json:"width"`
+	Height     int64              `db:"height" json:"height"`
+	Format     string             `db:"format" json:"format"`
+	CreatedAt  time.Time          `db:"created_at" json:"created_at"`
+	Renditions []ImageRenditionV1 `db:"-" json:"renditions"`
+	Links      []LinkV1           `db:"-" json:"links"`
+}
+
+type ImagesV1 struct {
+	ObjList []ImageV1 `db:"-" json:"images_list"`
+	Total   int64     `db:"-" json:"total"`
+	Offset  int64     `db:"-" json:"offset"`
+	Amount  int64     `db:"-" json:"amount"`
+}
+
+// ImageQueryParams ...
+type ImageQueryParams struct {
+	Table                string
+	WhereConditionString string
+}
+
+func init() {
+	template.Must(queries.Parse(`
+{{define "insert_image"}}
+INSERT INTO images (uuid, owner_kind, owner_uuid, width, height, format, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+{{end}}
+
+{{define "insert_image_rendition"}}
+INSERT INTO image_renditions (image_uuid, size, width, height, format, bytes)
+VALUES ($1, $2, $3, $4, $5, $6)
+{{end}}
+
+{{define "update_owner_image"}}
+UPDATE {{.Table}} SET img_id = (SELECT images.id FROM images WHERE images.uuid = $1)
+WHERE {{.Table}}.uuid = $2
+{{end}}
+
+{{define "select_images"}}
+SELECT uuid, owner_kind, owner_uuid, width, height, format, created_at FROM images
+WHERE {{.WhereConditionString}}
+ORDER BY images.created_at
+{{end}}
+
+{{define "select_image_renditions"}}
+SELECT image_uuid, size, width, height, format, bytes FROM image_renditions
+WHERE {{.WhereConditionString}}
+ORDER BY image_renditions.width
+{{end}}
+`))
+}
+
+// ImagePipeline decodes uploads, renders their sizes into an ImageStore and
+// records the dimensions.
+type ImagePipeline struct {
+	store ImageStore
+	sizes []ImageSize
+}
+
+// NewImagePipeline renders DefaultImageSizes plus extra.
+func NewImagePipeline(store ImageStore, extra ...ImageSize) (*ImagePipeline, error) {
+	if store == nil {
+		return nil, errors.New("invalid args")
+	}
+	sizes := append([]ImageSize{}, DefaultImageSizes...)
+	for _, size := range extra {
+		if size.Name == "" || size.MaxWidth <= 0 || size.MaxHeight <= 0 {
+			return nil, errors.New("invalid image size " + size.Name)
+		}
+		sizes = append(sizes, size)
+	}
+
+	return &ImagePipeline{store: store, sizes: sizes}, nil
+}
+
+// Upload stores the image read from r for the item ownerUuid of kind (see
+// kindTables) and makes it the item's image, a change of the item audited
+// as made by the user of ctx.
+func (p *ImagePipeline) Upload(ctx context.Context, kind, ownerUuid string, r io.Reader) (*ImageV1, error) {
+	if claims, ok := ClaimsFromContext(ctx); !ok || claims.UserId == "" {
+		return nil, ErrUnauthenticated
+	}
+	table, ok := kindTables[kind]
+	if !ok {
+		return nil, ErrUnknownOwner
+	}
+
+	data, err := ioutil.ReadAll(io.LimitReader(r, maxImageBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxImageBytes {
+		return nil, ErrImageTooLarge
+	}
+
+	// check the header first, decoding allocates the full bitmap
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	if config.Width*config.Height > maxImagePixels {
+		return nil, ErrImageTooLarge
+	}
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	imageUuid, err := newUuid()
+	if err != nil {
+		return nil, err
+	}
+	img := &ImageV1{
+		Uuid:      imageUuid,
+		OwnerKind: kind,
+		OwnerUuid: ownerUuid,
+		Width:     int64(config.Width),
+		Height:    int64(config.Height),
+		Format:    format,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	for _, size := range p.sizes {
+		rendition, data, err := renderImage(src, format, size)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.store.Put(ctx, imageUuid+"/"+size.Name, data); err != nil {
+			return nil, err
+		}
+		rendition.ImageUuid = imageUuid
+		img.Renditions = append(img.Renditions, *rendition)
+	}
+
+	if err := saveImage(ctx, img, table); err != nil {
+		for _, size := range p.sizes {
+			p.store.Delete(ctx, imageUuid+"/"+size.Name)
+		}
+		return nil, err
+	}
+
+	fillImageLinks(img)
+	return img, nil
+}
+
+// renderImage scales src into size. Images with transparency stay PNG,
+// the rest are encoded as JPEG.
+func renderImage(src image.Image, format string, size ImageSize) (*ImageRenditionV1, []byte, error) {
+	b := src.Bounds()
+	width, height := b.Dx(), b.Dy()
+	if width > size.MaxWidth || height > size.MaxHeight {
+		if width*size.MaxHeight > height*size.MaxWidth {
+			height = height * size.MaxWidth / width
+			width = size.MaxWidth
+		} else {
+			width = width * size.MaxHeight / height
+			height = size.MaxHeight
+		}
+		if width < 1 {
+			width = 1
+		}
+		if height < 1 {
+			height = 1
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, b, draw.Src, nil)
+
+	buf := bytes.NewBuffer(nil)
+	outFormat := "jpeg"
+	if (format == "png" || format == "gif" || format == "webp") && !dst.Opaque() {
+		outFormat = "png"
+		if err := png.Encode(buf, dst); err != nil {
+			return nil, nil, err
+		}
+	} else if err := jpeg.Encode(buf, dst, &jpeg.Options{Quality: jpegQuality}); err != nil {
+		return nil, nil, err
+	}
+
+	return &ImageRenditionV1{
+		Size:   size.Name,
+		Width:  int64(width),
+		Height: int64(height),
+		Format: outFormat,
+		Bytes:  int64(buf.Len()),
+	}, buf.Bytes(), nil
+}
+
+func saveImage(ctx context.Context, img *ImageV1, table string) error {
+	insertImage, err := renderQuery("insert_image", nil)
+	if err != nil {
+		return err
+	}
+	insertRendition, err := renderQuery("insert_image_rendition", nil)
+	if err != nil {
+		return err
+	}
+	updateOwner, err := renderQuery("update_owner_image", ImageQueryParams{Table: table})
+	if err != nil {
+		return err
+	}
+
+	tx, err := dbmap.Begin()
+	if err != nil {
+		return err
+	}
+	before, err := snapshot(tx, AuditImage, img.OwnerKind, img.OwnerUuid)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if before == nil {
+		tx.Rollback()
+		return errors.New("image owner not found")
+	}
+	if _, err := tx.Exec(insertImage, img.Uuid, img.OwnerKind, img.OwnerUuid, img.Width, img.Height, img.Format, img.CreatedAt); err != nil {
+		tx.Rollback()
+		return err
+	}
+	for _, r := range img.Renditions {
+		if _, err := tx.Exec(insertRendition, r.ImageUuid, r.Size, r.Width, r.Height, r.Format, r.Bytes); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	if _, err := tx.Exec(updateOwner, img.Uuid, img.OwnerUuid); err != nil {
+		tx.Rollback()
+		return err
+	}
+	after := &ImageSnapshotV1{ImageUuid: img.Uuid}
+	if err := recordAudit(tx, ctx, AuditImage, img.OwnerKind, img.OwnerUuid, before, after); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func fillImageLinks(img *ImageV1) {
+	for i := range img.Renditions {
+		img.Renditions[i].Url = imageUrls.ImageUrl(img.Uuid, img.Renditions[i].Size)
+	}
+	img.Links = []LinkV1{
+		LinkV1{
+			Href:   baseUrl + "/image/" + img.Uuid,
+			Rel:    "ImageInfo",
+			Method: "GET",
+		},
+	}
+	if owner, ok := kindLinkNames[img.OwnerKind]; ok {
+		img.Links = append(img.Links, LinkV1{
+			Href:   baseUrl + "/" + img.OwnerKind + "/" + img.OwnerUuid,
+			Rel:    owner + "Info",
+			Method: "GET",
+		})
+	}
+}
+
+// kindLinkNames is the prefix of the Rel of the links to each kind.
+var kindLinkNames = map[string]string{
+	"perfum":    "Perfum",
+	"brand":     "Brand",
+	"component": "Component",
+	"country":   "Country",
+	"gender":    "Gender",
+	"group":     "Group",
+	"note":      "Note",
+	"perfumer":  "Perfumer",
+	"season":    "Season",
+	"tag":       "Tag",
+	"timeofday": "Timeofday",
+	"type":      "Type",
+}
+
+// ImageHandler serves the renditions at /image/{uuid}/{size}.
+func ImageHandler(store ImageStore) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		imageUuid, size, ok := imagePathParts(r.URL.Path)
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		rc, err := store.Get(r.Context(), imageUuid+"/"+size)
+		if err == ErrImageNotFound {
+			http.NotFound(w, r)
+			return
+		} else if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		defer rc.Close()
+
+		data, err := ioutil.ReadAll(rc)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", http.DetectContentType(data))
+		w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+		w.Write(data)
+	})
+}
+
+func NewImagesFactory(version string) (Objecter, error) {
+	return NewObjecter("images", version)
+}
+
+// MakeObj returns the images params.Base.Ids with their renditions.
+func (obj *ImagesV1) MakeObj(pParams interface{}) (Objecter, error) {
+	if pParams == nil {
+		return nil, errors.New("invalid args")
+	}
+	params := pParams.(*MakeObjParams)
+	if !params.Base.Ids.Valid {
+		return nil, errors.New("invalid args")
+	}
+
+	if err := obj.load(addIdsToQuery(params.Base.Ids.String, "images.uuid")); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+// MakeExtraObj returns the images uploaded for the items uids.
+func (obj *ImagesV1) MakeExtraObj(params *MakeObjParams, uids []string) (Objecter, error) {
+	if params == nil || len(uids) == 0 {
+		return nil, errors.New("invalid args")
+	}
+
+	if err := obj.load(addIdsToQuery(uids, "images.owner_uuid")); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+func (obj *ImagesV1) load(condition string) error {
+	query, err := renderQuery("select_images", ImageQueryParams{WhereConditionString: condition})
+	if err != nil {
+		return err
+	}
+	if _, err := dbmap.Select(&obj.ObjList, query); err != nil {
+		return err
+	}
+
+	uuids := make([]string, 0, len(obj.ObjList))
+	for _, img := range obj.ObjList {
+		uuids = append(uuids, img.Uuid)
+	}
+	renditions, err := imageRenditions(uuids)
+	if err != nil {
+		return err
+	}
+	for i := range obj.ObjList {
+		obj.ObjList[i].Renditions = renditions[obj.ObjList[i].Uuid]
+		fillImageLinks(&obj.ObjList[i])
+	}
+
+	obj.Total = int64(len(obj.ObjList))
+	obj.Amount = int64(len(obj.ObjList))
+
+	return nil
+}
+
+// imageRenditions returns the renditions of the images uuids, smallest
+// first.
+func imageRenditions(uuids []string) (map[string][]ImageRenditionV1, error) {
+	res := make(map[string][]ImageRenditionV1)
+	if len(uuids) == 0 {
+		return res, nil
+	}
+
+	condition := addIdsToQuery(uuids, "image_renditions.image_uuid")
+	query, err := renderQuery("select_image_renditions", ImageQueryParams{WhereConditionString: condition})
+	if err != nil {
+		return nil, err
+	}
+	var renditions []ImageRenditionV1
+	if _, err := dbmap.Select(&renditions, query); err != nil {
+		return nil, err
+	}
+	for _, r := range renditions {
+		res[r.ImageUuid] = append(res[r.ImageUuid], r)
+	}
+
+	return res, nil
+}
+
+func (obj *ImagesV1) Count(pParams interface{}) (int64, error) {
+	if pParams == nil {
+		return 0, errors.New("invalid args")
+	}
+
+	dbQuery := QueryTemplateParams{}
+	dbQuery.FromTableName = "images"
+	query := bytes.NewBufferString("")
+	if err := tmpl.ExecuteTemplate(query, "select_count", &dbQuery); err != nil {
+		return 0, err
+	}
+
+	count, err := dbmap.SelectInt(query.String())
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (obj *ImagesV1) ExtraCount(uids []string) (int64, error) {
+	if len(uids) == 0 {
+		return 0, errors.New("invalid args")
+	}
+
+	dbQuery := QueryTemplateParams{}
+	dbQuery.FromTableName = "images"
+	dbQuery.WhereConditionString = addIdsToQuery(uids, "images.owner_uuid")
+	query := bytes.NewBufferString("")
+	if err := tmpl.ExecuteTemplate(query, "select_count", &dbQuery); err != nil {
+		return 0, err
+	}
+
+	count, err := dbmap.SelectInt(query.String())
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (obj *ImagesV1) Json(w http.ResponseWriter, status int) error {
+	render := render.New()
+	return render.JSON(w, status, obj)
+}
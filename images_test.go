@@ -0,0 +1,163 @@
+package objects
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRenderImageSizing(t *testing.T) {
+	size := ImageSize{Name: "small", MaxWidth: 240, MaxHeight: 240}
+	tests := []struct {
+		width, height int
+		wantW, wantH  int64
+	}{
+		{1200, 600, 240, 120},
+		{600, 1200, 120, 240},
+		{100, 50, 100, 50},
+		{4000, 10, 240, 1},
+	}
+	for _, test := range tests {
+		src := image.NewRGBA(image.Rect(0, 0, test.width, test.height))
+		rendition, data, err := renderImage(src, "jpeg", size)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rendition.Width != test.wantW || rendition.Height != test.wantH {
+			t.Errorf("%dx%d rendered %dx%d, want %dx%d", test.width, test.height,
+				rendition.Width, rendition.Height, test.wantW, test.wantH)
+		}
+		decoded, err := jpeg.DecodeConfig(bytes.NewReader(data))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if int64(decoded.Width) != rendition.Width || int64(decoded.Height) != rendition.Height ||
+			rendition.Bytes != int64(len(data)) || rendition.Size != "small" {
+			t.Errorf("rendition %+v does not describe its %dx%d data", rendition, decoded.Width, decoded.Height)
+		}
+	}
+}
+
+func TestRenderImageFormat(t *testing.T) {
+	size := ImageSize{Name: "large", MaxWidth: 1024, MaxHeight: 1024}
+	opaque := image.NewRGBA(image.Rect(0, 0, 8, 8))
+	transparent := image.NewNRGBA(image.Rect(0, 0, 8, 8))
+	for x := 0; x < 8; x++ {
+		for y := 0; y < 8; y++ {
+			opaque.Set(x, y, color.RGBA{R: 200, A: 255})
+			transparent.Set(x, y, color.NRGBA{G: 200, A: 100})
+		}
+	}
+	tests := []struct {
+		src    image.Image
+		format string
+		want   string
+	}{
+		{opaque, "png", "jpeg"},
+		{transparent, "png", "png"},
+		{transparent, "jpeg", "jpeg"},
+	}
+	for _, test := range tests {
+		rendition, data, err := renderImage(test.src, test.format, size)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rendition.Format != test.want {
+			t.Errorf("%s source rendered as %s, want %s", test.format, rendition.Format, test.want)
+		}
+		if test.want == "png" {
+			if _, err := png.Decode(bytes.NewReader(data)); err != nil {
+				t.Error(err)
+			}
+		}
+	}
+}
+
+func TestFillImageLinksOwners(t *testing.T) {
+	for kind := range kindTables {
+		if _, ok := kindLinkNames[kind]; !ok {
+			t.Errorf("images of %s are not linked to their owner", kind)
+		}
+	}
+
+	img := &ImageV1{Uuid: "i1", OwnerKind: "perfumer", OwnerUuid: "n1"}
+	fillImageLinks(img)
+	if len(img.Links) != 2 || img.Links[1].Rel != "PerfumerInfo" || img.Links[1].Href != baseUrl+"/perfumer/n1" {
+		t.Errorf("perfumer image links %+v", img.Links)
+	}
+}
+
+func TestUploadAudited(t *testing.T) {
+	dir, err := ioutil.TempDir("", "images")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	pipeline, err := NewImagePipeline(FileImageStore{Root: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	upload := &bytes.Buffer{}
+	if err := png.Encode(upload, image.NewRGBA(image.Rect(0, 0, 16, 8))); err != nil {
+		t.Fatal(err)
+	}
+
+	fake := useFakeDB(t, func(query string, args []driver.Value) (*fakeResult, error) {
+		if strings.Contains(query, "COALESCE(images.uuid, '')") && args[0] == "b1" {
+			return fakeColumn("uuid", "old"), nil
+		}
+		return nil, nil
+	})
+
+	// an image is uploaded by an authenticated user only, before anything is
+	// stored
+	if _, err := pipeline.Upload(context.Background(), "brand", "b1", bytes.NewReader(upload.Bytes())); err != ErrUnauthenticated {
+		t.Errorf("anonymous Upload = %v", err)
+	}
+	if stored, _ := ioutil.ReadDir(dir); len(stored) != 0 || len(fake.statements) != 0 {
+		t.Errorf("anonymous upload stored: %d files, %d statements", len(stored), len(fake.statements))
+	}
+
+	img, err := pipeline.Upload(testClaimsContext(), "brand", "b1", bytes.NewReader(upload.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	audits := fake.ran("INSERT INTO audit_log")
+	if len(audits) != 1 || len(fake.ran("INSERT INTO outbox")) != 1 || fake.commits != 1 {
+		t.Fatalf("upload not audited: %+v", fake.statements)
+	}
+	var before, after ImageSnapshotV1
+	json.Unmarshal([]byte(audits[0].args[5].(string)), &before)
+	json.Unmarshal([]byte(audits[0].args[6].(string)), &after)
+	if audits[0].args[1] != "u1" || audits[0].args[4] != AuditImage || before.ImageUuid != "old" || after.ImageUuid != img.Uuid {
+		t.Errorf("audit %v", audits[0].args)
+	}
+
+	// an upload for no item stores nothing
+	if _, err := pipeline.Upload(testClaimsContext(), "brand", "b2", bytes.NewReader(upload.Bytes())); err == nil {
+		t.Error("upload for a missing brand accepted")
+	}
+	if fake.rollbacks != 1 || len(fake.ran("INSERT INTO audit_log")) != 1 {
+		t.Errorf("upload for a missing brand not rolled back: %+v", fake.statements)
+	}
+	stored := []string{}
+	filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			stored = append(stored, path)
+		}
+		return err
+	})
+	if len(stored) != len(img.Renditions) {
+		t.Errorf("stored %v, want the renditions of %s only", stored, img.Uuid)
+	}
+}
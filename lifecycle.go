@@ -0,0 +1,267 @@
+package objects
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"text/template"
+)
+
+// Lifecycle states of perfums and taxonomy items. Rows without a status are
+// active.
+const (
+	StatusActive       = "active"
+	StatusDiscontinued = "discontinued"
+	StatusArchived     = "archived"
+	StatusDeleted      = "deleted"
+)
+
+var lifecycleStatuses = []string{StatusActive, StatusDiscontinued, StatusArchived, StatusDeleted}
+
+var ErrInvalidStatus = errors.New("invalid status")
+
+// StatusDBRecordV1 ...
+type StatusDBRecordV1 struct {
+	Uuid   string `db:"uuid"`
+	Status string `db:"status"`
+}
+
+// StatusQueryParams ...
+type StatusQueryParams struct {
+	Table                string
+	WhereConditionString string
+}
+
+func init() {
+	template.Must(queries.Parse(`
+{{define "select_statuses"}}
+SELECT {{.Table}}.uuid, COALESCE({{.Table}}.status, 'active') AS status FROM {{.Table}}
+WHERE {{.WhereConditionString}}
+{{end}}
+
+{{define "update_status"}}
+UPDATE {{.Table}} SET status = $2 WHERE {{.Table}}.uuid = $1
+{{end}}
+`))
+}
+
+// checkStatuses returns ErrInvalidStatus unless every one of statuses is a
+// lifecycle state.
+func checkStatuses(statuses []string) error {
+	for _, status := range statuses {
+		if !containsString(lifecycleStatuses, status) {
+			return ErrInvalidStatus
+		}
+	}
+	return nil
+}
+
+// statusCondition selects the items of table in one of statuses, the active
+// ones only when statuses is empty. Only the lifecycle states themselves make
+// it into the SQL, whatever statuses holds.
+func statusCondition(table string, statuses []string) string {
+	if len(statuses) == 0 {
+		statuses = []string{StatusActive}
+	}
+	known := []string{}
+	for _, status := range lifecycleStatuses {
+		if containsString(statuses, status) {
+			known = append(known, status)
+		}
+	}
+	if len(known) == 0 {
+		return "FALSE"
+	}
+	return "COALESCE(" + table + ".status, 'active') IN ('" + strings.Join(known, "', '") + "')"
+}
+
+// perfumsStatusCondition is statusCondition for the perfums counted from
+// table, parfum_info or the parfums of compositions.
+func perfumsStatusCondition(table string, statuses []string) string {
+	if table == "parfums" {
+		return "parfums.parfum_info_id IN (SELECT parfum_info.id FROM parfum_info WHERE " +
+			statusCondition("parfum_info", statuses) + ")"
+	}
+	return statusCondition("parfum_info", statuses)
+}
+
+// paramsStatuses returns the statuses pParams asks for, if it is a
+// *MakeObjParams, or ErrInvalidStatus.
+func paramsStatuses(pParams interface{}) ([]string, error) {
+	params, ok := pParams.(*MakeObjParams)
+	if !ok {
+		return nil, nil
+	}
+	if err := checkStatuses(params.Statuses); err != nil {
+		return nil, err
+	}
+	return params.Statuses, nil
+}
+
+// StatusExtraCounter is implemented by the collections whose ExtraCount
+// counts perfums. ExtraCountStatuses counts those in one of statuses, as
+// MakeExtraObj lists them for MakeObjParams.Statuses; ExtraCount counts the
+// active ones.
+type StatusExtraCounter interface {
+	ExtraCountStatuses(uids []string, statuses []string) (int64, error)
+}
+
+// andCondition joins two conditions, either of which may be empty.
+func andCondition(a, b string) string {
+	switch {
+	case a == "":
+		return b
+	case b == "":
+		return a
+	}
+	return "(" + a + ") AND " + b
+}
+
+// SetStatus moves the kind item uuid to status.
+func SetStatus(ctx context.Context, kind, uuid, status string) error {
+	table, ok := kindTables[kind]
+	if !ok || uuid == "" || !containsString(lifecycleStatuses, status) {
+		return ErrInvalidStatus
+	}
+	query, err := renderQuery("update_status", StatusQueryParams{Table: table})
+	if err != nil {
+		return err
+	}
+
+	tx, err := dbmap.Begin()
+	if err != nil {
+		return err
+	}
+	before, err := snapshot(tx, AuditStatus, kind, uuid)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if before == nil {
+		tx.Rollback()
+		return ErrInvalidStatus
+	}
+	if _, err := tx.Exec(query, uuid, status); err != nil {
+		tx.Rollback()
+		return err
+	}
+	after := &StatusSnapshotV1{Status: status}
+	if err := recordAudit(tx, ctx, AuditStatus, kind, uuid, before, after); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// activeUuids keeps the uuids of items of table in one of statuses, in
+// order.
+func activeUuids(table string, uuids []string, statuses []string) ([]string, error) {
+	if len(uuids) == 0 {
+		return uuids, nil
+	}
+	found, err := itemStatuses(table, uuids)
+	if err != nil {
+		return nil, err
+	}
+	if len(statuses) == 0 {
+		statuses = []string{StatusActive}
+	}
+	res := []string{}
+	for _, uuid := range uuids {
+		if containsString(statuses, found[uuid]) {
+			res = append(res, uuid)
+		}
+	}
+	return res, nil
+}
+
+// itemStatuses maps the uuids of items of table to their status.
+func itemStatuses(table string, uuids []string) (map[string]string, error) {
+	if len(uuids) == 0 {
+		return map[string]string{}, nil
+	}
+	queryParams := StatusQueryParams{Table: table, WhereConditionString: addIdsToQuery(uuids, table+".uuid")}
+	query, err := renderQuery("select_statuses", queryParams)
+	if err != nil {
+		return nil, err
+	}
+	var rows []StatusDBRecordV1
+	if _, err := dbmap.Select(&rows, query); err != nil {
+		return nil, err
+	}
+	res := make(map[string]string, len(rows))
+	for _, row := range rows {
+		res[row.Uuid] = row.Status
+	}
+	return res, nil
+}
+
+// fillStatus sets the status of each item of list, a pointer to a slice of
+// the items of table, and turns the deleted ones into tombstones like
+// fillPerfumStatus does: only their id, status and first link, the one to
+// the item itself, are kept.
+func fillStatus(table string, list interface{}) error {
+	items := reflect.ValueOf(list).Elem()
+	if items.Len() == 0 {
+		return nil
+	}
+
+	uuids := make([]string, 0, items.Len())
+	for i := 0; i < items.Len(); i++ {
+		uuids = append(uuids, items.Index(i).FieldByName("Uuid").String())
+	}
+	statuses, err := itemStatuses(table, uuids)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < items.Len(); i++ {
+		item := items.Index(i)
+		status := statuses[item.FieldByName("Uuid").String()]
+		if status == StatusDeleted {
+			tombstone := reflect.New(item.Type()).Elem()
+			tombstone.FieldByName("Uuid").Set(item.FieldByName("Uuid"))
+			if links := item.FieldByName("Links"); links.Len() > 0 {
+				tombstone.FieldByName("Links").Set(links.Slice(0, 1))
+			}
+			item.Set(tombstone)
+		}
+		item.FieldByName("Status").SetString(status)
+	}
+	return nil
+}
+
+// fillPerfumStatus sets the status of each perfum of list and turns the
+// deleted ones into tombstones: their links keep answering with the id and
+// status of the perfum instead of not finding it.
+func fillPerfumStatus(list []PerfumInfoV1) error {
+	if len(list) == 0 {
+		return nil
+	}
+
+	uuids := make([]string, 0, len(list))
+	for i := range list {
+		uuids = append(uuids, list[i].Uuid)
+	}
+	statuses, err := itemStatuses("parfum_info", uuids)
+	if err != nil {
+		return err
+	}
+	for i := range list {
+		list[i].Status = statuses[list[i].Uuid]
+		if list[i].Status == StatusDeleted {
+			list[i] = PerfumInfoV1{
+				Uuid:   list[i].Uuid,
+				Status: StatusDeleted,
+				Links: []LinkV1{
+					LinkV1{
+						Href:   baseUrl + "/perfum/" + list[i].Uuid,
+						Rel:    "PerfumInfo",
+						Method: "GET",
+					},
+				},
+			}
+		}
+	}
+	return nil
+}
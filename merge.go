@@ -0,0 +1,325 @@
+package objects
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"text/template"
+)
+
+var ErrInvalidMerge = errors.New("invalid merge")
+
+// MergeReq merges the duplicate Kind item Uuid into the item IntoUuid.
+type MergeReq struct {
+	Kind     string `json:"kind"`
+	Uuid     string `json:"id"`
+	IntoUuid string `json:"into_id"`
+}
+
+// MergeV1 reports a merge: the perfums of FromUuid now refer to IntoUuid,
+// and FromUuid redirects to it.
+type MergeV1 struct {
+	Kind     string   `json:"kind"`
+	FromUuid string   `json:"from_id"`
+	IntoUuid string   `json:"into_id"`
+	Perfums  int64    `json:"perfums"`
+	Links    []LinkV1 `json:"links"`
+}
+
+// RedirectDBRecordV1 ...
+type RedirectDBRecordV1 struct {
+	OldUuid string `db:"old_uuid"`
+	NewUuid string `db:"new_uuid"`
+}
+
+// MergeQueryParams ...
+type MergeQueryParams struct {
+	Kind                 string
+	Table                string
+	WhereConditionString string
+}
+
+// mergeSteps are the queries moving the references of a kind from $1 to
+// $2, in order; the ones every kind needs run after them.
+var mergeSteps = map[string][]string{
+	"brand": {"merge_brand_perfums", "merge_brand_webhooks"},
+	"component": {
+		"merge_component_parfums_duplicates", "merge_component_parfums",
+		"merge_component_weights_duplicates", "merge_component_weights",
+		"merge_tree_children", "merge_tree",
+	},
+}
+
+// mergePerfums select the perfums referring to $1 of each kind, announced as
+// updated once the merge moved them.
+var mergePerfums = map[string]string{
+	"brand":     "select_merge_brand_perfums",
+	"component": "select_merge_component_perfums",
+}
+
+func init() {
+	template.Must(queries.Parse(`
+{{define "select_merge_name"}}
+SELECT {{.Table}}.name FROM {{.Table}} WHERE {{.Table}}.uuid = $1
+{{end}}
+
+{{define "merge_brand_perfums"}}
+UPDATE parfum_info SET brand_id = (SELECT brands.id FROM brands WHERE brands.uuid = $2)
+WHERE parfum_info.brand_id = (SELECT brands.id FROM brands WHERE brands.uuid = $1)
+{{end}}
+
+{{define "merge_brand_webhooks"}}
+UPDATE webhook_subscriptions SET brand_uuid = $2 WHERE webhook_subscriptions.brand_uuid = $1
+{{end}}
+
+{{define "select_merge_brand_perfums"}}
+SELECT parfum_info.uuid FROM parfum_info
+WHERE parfum_info.brand_id = (SELECT brands.id FROM brands WHERE brands.uuid = $1)
+{{end}}
+
+{{define "select_merge_component_perfums"}}
+SELECT DISTINCT parfum_info.uuid FROM parfum_info
+JOIN parfums ON parfums.parfum_info_id = parfum_info.id
+WHERE parfums.component_id = (SELECT components.id FROM components WHERE components.uuid = $1)
+{{end}}
+
+{{define "merge_component_parfums_duplicates"}}
+DELETE FROM parfums
+WHERE parfums.component_id = (SELECT components.id FROM components WHERE components.uuid = $1)
+AND EXISTS (SELECT 1 FROM parfums p WHERE p.component_id = (SELECT components.id FROM components WHERE components.uuid = $2)
+	AND p.parfum_info_id = parfums.parfum_info_id AND p.note_id = parfums.note_id)
+{{end}}
+
+{{define "merge_component_parfums"}}
+UPDATE parfums SET component_id = (SELECT components.id FROM components WHERE components.uuid = $2)
+WHERE parfums.component_id = (SELECT components.id FROM components WHERE components.uuid = $1)
+{{end}}
+
+{{define "merge_component_weights_duplicates"}}
+DELETE FROM component_weights
+WHERE component_weights.component_uuid = $1
+AND EXISTS (SELECT 1 FROM component_weights w WHERE w.component_uuid = $2
+	AND w.perfum_uuid = component_weights.perfum_uuid AND w.note_uuid = component_weights.note_uuid)
+{{end}}
+
+{{define "merge_component_weights"}}
+UPDATE component_weights SET component_uuid = $2 WHERE component_weights.component_uuid = $1
+{{end}}
+
+{{define "merge_tree_children"}}
+UPDATE taxonomy_tree SET parent_uuid = $2
+WHERE taxonomy_tree.kind = '{{.Kind}}' AND taxonomy_tree.parent_uuid = $1 AND taxonomy_tree.uuid <> $2
+{{end}}
+
+{{define "merge_tree"}}
+DELETE FROM taxonomy_tree WHERE taxonomy_tree.kind = '{{.Kind}}' AND (taxonomy_tree.uuid = $1 OR taxonomy_tree.parent_uuid = $1)
+{{end}}
+
+{{define "merge_translations"}}
+UPDATE translations SET uuid = $2
+WHERE translations.uuid = $1 AND NOT EXISTS (SELECT 1 FROM translations t
+	WHERE t.uuid = $2 AND t.field = translations.field AND t.locale = translations.locale)
+{{end}}
+
+{{define "merge_translations_rest"}}
+DELETE FROM translations WHERE translations.uuid = $1
+{{end}}
+
+{{define "merge_images"}}
+UPDATE images SET owner_uuid = $2 WHERE images.owner_kind = '{{.Kind}}' AND images.owner_uuid = $1
+{{end}}
+
+{{define "merge_owner_image"}}
+UPDATE {{.Table}} SET img_id = (SELECT m.img_id FROM {{.Table}} m WHERE m.uuid = $1)
+WHERE {{.Table}}.uuid = $2 AND {{.Table}}.img_id IS NULL
+{{end}}
+
+{{define "merge_aliases"}}
+UPDATE aliases SET entity_uuid = $2 WHERE aliases.entity_uuid = $1
+{{end}}
+
+{{define "merge_name_alias"}}
+INSERT INTO aliases (uuid, kind, entity_uuid, alias, normalized)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (kind, normalized) DO NOTHING
+{{end}}
+
+{{define "merge_redirects"}}
+UPDATE redirects SET new_uuid = $2 WHERE redirects.new_uuid = $1
+{{end}}
+
+{{define "insert_redirect"}}
+INSERT INTO redirects (kind, old_uuid, new_uuid) VALUES ($1, $2, $3)
+{{end}}
+
+{{define "delete_merged"}}
+DELETE FROM {{.Table}} WHERE {{.Table}}.uuid = $1
+{{end}}
+
+{{define "select_redirects"}}
+SELECT old_uuid, new_uuid FROM redirects
+WHERE redirects.kind = '{{.Kind}}' AND {{.WhereConditionString}}
+{{end}}
+`))
+}
+
+// Merge moves every reference to the item req.Uuid over to req.IntoUuid,
+// perfums, images and webhook subscriptions alike, and deletes it in one
+// transaction. The old uuid keeps resolving through redirects, and its name
+// becomes an alias of the surviving item. The outbox announces the surviving
+// item, the merged one gone and each perfum moved.
+func Merge(ctx context.Context, req *MergeReq) (*MergeV1, error) {
+	if req == nil || req.Uuid == "" || req.IntoUuid == "" || req.Uuid == req.IntoUuid {
+		return nil, ErrInvalidMerge
+	}
+	steps, ok := mergeSteps[req.Kind]
+	if !ok {
+		return nil, ErrInvalidMerge
+	}
+	queryParams := MergeQueryParams{Kind: req.Kind, Table: kindTables[req.Kind]}
+
+	renderStep := func(name string) (string, error) { return renderQuery(name, queryParams) }
+	nameQuery, err := renderStep("select_merge_name")
+	if err != nil {
+		return nil, err
+	}
+	perfumsQuery, err := renderStep(mergePerfums[req.Kind])
+	if err != nil {
+		return nil, err
+	}
+	aliasUuid, err := newUuid()
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := dbmap.Begin()
+	if err != nil {
+		return nil, err
+	}
+	name, err := tx.SelectNullStr(nameQuery, req.Uuid)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	into, err := tx.SelectNullStr(nameQuery, req.IntoUuid)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if !name.Valid || !into.Valid {
+		tx.Rollback()
+		return nil, ErrInvalidMerge
+	}
+
+	var perfums []string
+	if _, err := tx.Select(&perfums, perfumsQuery, req.Uuid); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	res := &MergeV1{Kind: req.Kind, FromUuid: req.Uuid, IntoUuid: req.IntoUuid, Perfums: int64(len(perfums))}
+	steps = append(steps, "merge_images", "merge_owner_image", "merge_translations", "merge_translations_rest", "merge_aliases", "merge_redirects")
+	for _, step := range steps {
+		query, err := renderStep(step)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		if _, err := tx.Exec(query, req.Uuid, req.IntoUuid); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
+	for _, step := range []struct {
+		name string
+		args []interface{}
+	}{
+		{"merge_name_alias", []interface{}{aliasUuid, req.Kind, req.IntoUuid, name.String, normalizeAlias(name.String)}},
+		{"insert_redirect", []interface{}{req.Kind, req.Uuid, req.IntoUuid}},
+		{"delete_merged", []interface{}{req.Uuid}},
+	} {
+		query, err := renderStep(step.name)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		if _, err := tx.Exec(query, step.args...); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+	if err := recordAudit(tx, ctx, AuditMerge, req.Kind, req.IntoUuid, nil, res); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	// recordAudit announced req.IntoUuid already
+	if err := recordOutbox(tx, req.Kind, req.Uuid, OutboxDelete); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	for _, perfum := range perfums {
+		if err := recordOutbox(tx, "perfum", perfum, AuditUpdate); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	res.Links = []LinkV1{
+		LinkV1{
+			Href:   baseUrl + "/" + req.Kind + "/" + req.IntoUuid,
+			Rel:    strings.ToUpper(req.Kind[:1]) + req.Kind[1:] + "Info",
+			Method: "GET",
+		},
+	}
+	return res, nil
+}
+
+// redirectIds replaces the uuids of merged items of kind in ids, a comma
+// separated list, by the ones they were merged into. It returns the new list
+// and the merged uuid each surviving one was requested by.
+func redirectIds(kind, ids string) (string, map[string]string, error) {
+	list := strings.Split(ids, ",")
+	for i := range list {
+		list[i] = strings.TrimSpace(list[i])
+	}
+
+	args := bindArgs{}
+	queryParams := MergeQueryParams{Kind: kind, WhereConditionString: "redirects.old_uuid IN (" + args.list(list) + ")"}
+	query, err := renderQuery("select_redirects", queryParams)
+	if err != nil {
+		return "", nil, err
+	}
+	var redirects []RedirectDBRecordV1
+	if _, err := dbmap.Select(&redirects, query, args...); err != nil {
+		return "", nil, err
+	}
+	if len(redirects) == 0 {
+		return ids, nil, nil
+	}
+
+	to := make(map[string]string)
+	from := make(map[string]string)
+	for _, r := range redirects {
+		to[r.OldUuid] = r.NewUuid
+		from[r.NewUuid] = r.OldUuid
+	}
+	for i, uuid := range list {
+		if into, ok := to[uuid]; ok {
+			list[i] = into
+		}
+	}
+	return strings.Join(list, ","), from, nil
+}
+
+// redirectUuids is redirectIds for a list of uuids.
+func redirectUuids(kind string, uids []string) ([]string, error) {
+	ids, _, err := redirectIds(kind, strings.Join(uids, ","))
+	if err != nil {
+		return nil, err
+	}
+	return strings.Split(ids, ","), nil
+}
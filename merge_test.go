@@ -0,0 +1,116 @@
+package objects
+
+import (
+	"database/sql/driver"
+	"strings"
+	"testing"
+)
+
+// parfumRow is a row of the parfums table: the component a perfum has in
+// one level of its pyramid.
+type parfumRow struct {
+	perfum, component, note string
+}
+
+// fakeParfums answers the queries a component merge runs against rows, a
+// stand-in for the parfums table, and applies its changes to them.
+func fakeParfums(rows *[]parfumRow) func(string, []driver.Value) (*fakeResult, error) {
+	return func(query string, args []driver.Value) (*fakeResult, error) {
+		switch {
+		case strings.Contains(query, "SELECT components.name"):
+			return fakeColumn("name", "Name of "+args[0].(string)), nil
+		case strings.Contains(query, "SELECT DISTINCT parfum_info.uuid"):
+			res := fakeColumn("uuid")
+			seen := map[string]bool{}
+			for _, row := range *rows {
+				if row.component == args[0] && !seen[row.perfum] {
+					seen[row.perfum] = true
+					res.rows = append(res.rows, []driver.Value{row.perfum})
+				}
+			}
+			return res, nil
+		case strings.Contains(query, "DELETE FROM parfums"):
+			sameNote := strings.Contains(query, "p.note_id = parfums.note_id")
+			kept := []parfumRow{}
+			for _, row := range *rows {
+				duplicate := false
+				for _, other := range *rows {
+					if row.component == args[0] && other.component == args[1] && other.perfum == row.perfum &&
+						(!sameNote || other.note == row.note) {
+						duplicate = true
+					}
+				}
+				if !duplicate {
+					kept = append(kept, row)
+				}
+			}
+			*rows = kept
+		case strings.Contains(query, "UPDATE parfums SET component_id"):
+			for i := range *rows {
+				if (*rows)[i].component == args[0] {
+					(*rows)[i].component = args[1].(string)
+				}
+			}
+		}
+		return nil, nil
+	}
+}
+
+func TestMergeComponentDuplicates(t *testing.T) {
+	rows := []parfumRow{
+		// the duplicate in the top notes, the item it's merged into in the base
+		{"p1", "c-dup", "top"},
+		{"p1", "c", "base"},
+		// both in the heart
+		{"p2", "c-dup", "heart"},
+		{"p2", "c", "heart"},
+		{"p3", "other", "top"},
+	}
+	fake := useFakeDB(t, fakeParfums(&rows))
+
+	res, err := Merge(testClaimsContext(), &MergeReq{Kind: "component", Uuid: "c-dup", IntoUuid: "c"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []parfumRow{{"p1", "c", "top"}, {"p1", "c", "base"}, {"p2", "c", "heart"}, {"p3", "other", "top"}}
+	if len(rows) != len(want) {
+		t.Fatalf("rows %v, want %v", rows, want)
+	}
+	for i := range want {
+		if rows[i] != want[i] {
+			t.Errorf("row %d is %v, want %v", i, rows[i], want[i])
+		}
+	}
+	if res.Perfums != 2 {
+		t.Errorf("%d perfums moved, want 2", res.Perfums)
+	}
+
+	events := map[string]bool{}
+	for _, s := range fake.ran("INSERT INTO outbox") {
+		events[s.args[0].(string)+" "+s.args[1].(string)+" "+s.args[2].(string)] = true
+	}
+	for _, event := range []string{"component c " + AuditMerge, "component c-dup " + OutboxDelete, "perfum p1 " + AuditUpdate, "perfum p2 " + AuditUpdate} {
+		if !events[event] {
+			t.Errorf("no %q event in %v", event, events)
+		}
+	}
+	if fake.commits != 1 {
+		t.Errorf("%d commits, want 1", fake.commits)
+	}
+}
+
+func TestListItemsRedirected(t *testing.T) {
+	obj := &BrandsV1{ObjList: []BrandV1{{Uuid: "b1", RedirectedFrom: "b-old"}, {Uuid: "b2"}}}
+	items := listItems(obj)
+	for _, uuid := range []string{"b1", "b-old", "b2"} {
+		if _, ok := items[uuid]; !ok {
+			t.Errorf("%s not indexed", uuid)
+		}
+	}
+	if len(items) != 3 {
+		t.Errorf("%d items indexed, want 3", len(items))
+	}
+	if item := items["b-old"].(BrandV1); item.Uuid != "b1" {
+		t.Errorf("b-old indexes %s", item.Uuid)
+	}
+}
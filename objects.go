@@ -16,6 +16,27 @@ type MakeObjParams struct {
 	Total      int64
 	PerfumsNum NullInt64
 	DbQuery    QueryTemplateParams
+	// Locales requested for names and descriptions, most preferred first.
+	Locales []string
+	// Levels limits compositions to the notes of these pyramid levels.
+	Levels []string
+	// Match is MatchAny (the default) or MatchAll for the perfums of
+	// several seasons or times of day.
+	Match string
+	// Tree lists groups and components as trees of their root items.
+	Tree bool
+	// Descendants has MakeExtraObj include the perfums of the groups and
+	// components below uids.
+	Descendants bool
+	// RollUp counts the perfums of the whole subtree of an item.
+	RollUp bool
+	// Statuses lists the items in these lifecycle states instead of the
+	// active ones only; items asked for by id are served whatever their
+	// state.
+	Statuses []string
+	// Claims are those of the authenticated caller, nil for anonymous
+	// requests.
+	Claims *IdTokenClaims
 }
 
 type Objecter interface {
@@ -35,32 +56,42 @@ type LinkV1 struct {
 
 // PerfumInfoV1 ...
 type PerfumInfoV1 struct {
-	Id              string         `db:"info_id" json:"-"`
-	Uuid            string         `db:"info_uuid" json:"id"`
-	Name            string         `db:"name" json:"name"`
-	DescriptionUuid string         `db:"description_uuid" json:"description_id"`
-	Description     string         `db:"description" json:"description"`
-	Year            int64          `db:"info_year" json:"year"`
-	BrandUuid       string         `db:"brand_uuid" json:"brand_id"`
-	BrandName       string         `db:"brand_name" json:"brand_name"`
-	GenderUuid      string         `db:"gender_uuid" json:"gender_id"`
-	GenderName      string         `db:"gender_name" json:"gender_name"`
-	GroupUuid       string         `db:"group_uuid" json:"group_id"`
-	GroupName       string         `db:"group_name" json:"group_name"`
-	CountryUuid     string         `db:"country_uuid" json:"country_id"`
-	CountryName     string         `db:"country_name" json:"country_name"`
-	SeasonUuid      string         `db:"season_uuid" json:"season_id"`
-	SeasonName      string         `db:"season_name" json:"season_name"`
-	TsodUuid        string         `db:"tsod_uuid" json:"tsod_id"`
-	TsodName        string         `db:"tsod_name" json:"tsod_name"`
-	TypeUuid        string         `db:"type_uuid" json:"type_id"`
-	TypeName        string         `db:"type_name" json:"type_name"`
-	ImgUuid         sql.NullString `db:"img_uuid" json:"-"`
-	StarsUuid       sql.NullString `db:"stars_uuid" json:"stars_id"`
-	ShopUuid        sql.NullString `db:"shop_uuid" json:"shop_id"`
-	Links           []LinkV1       `db:"-" json:"links"`
-	SmallImgUrl     string         `db:"-" json:"small_img_url"`
-	LargeImgUrl     string         `db:"-" json:"large_img_url"`
+	Id              string              `db:"info_id" json:"-"`
+	Uuid            string              `db:"info_uuid" json:"id"`
+	Name            string              `db:"name" json:"name"`
+	DescriptionUuid string              `db:"description_uuid" json:"description_id"`
+	Description     string              `db:"description" json:"description"`
+	Year            int64               `db:"info_year" json:"year"`
+	BrandUuid       string              `db:"brand_uuid" json:"brand_id"`
+	BrandName       string              `db:"brand_name" json:"brand_name"`
+	GenderUuid      string              `db:"gender_uuid" json:"gender_id"`
+	GenderName      string              `db:"gender_name" json:"gender_name"`
+	GroupUuid       string              `db:"group_uuid" json:"group_id"`
+	GroupName       string              `db:"group_name" json:"group_name"`
+	CountryUuid     string              `db:"country_uuid" json:"country_id"`
+	CountryName     string              `db:"country_name" json:"country_name"`
+	SeasonUuid      string              `db:"season_uuid" json:"season_id"`
+	SeasonName      string              `db:"season_name" json:"season_name"`
+	TsodUuid        string              `db:"tsod_uuid" json:"tsod_id"`
+	TsodName        string              `db:"tsod_name" json:"tsod_name"`
+	TypeUuid        string              `db:"type_uuid" json:"type_id"`
+	TypeName        string              `db:"type_name" json:"type_name"`
+	ImgUuid         sql.NullString      `db:"img_uuid" json:"-"`
+	StarsUuid       sql.NullString      `db:"stars_uuid" json:"stars_id"`
+	StarsAverage    float64             `db:"-" json:"stars_average"`
+	StarsCount      int64               `db:"-" json:"stars_count"`
+	ShopUuid        sql.NullString      `db:"shop_uuid" json:"shop_id"`
+	Status          string              `db:"-" json:"status"`
+	ParentUuid      sql.NullString      `db:"-" json:"parent_id"`
+	FamilyRelation  string              `db:"-" json:"family_relation"`
+	Perfumers       []PerfumerRefV1     `db:"-" json:"perfumers"`
+	Seasons         []TaxonomyItemRefV1 `db:"-" json:"seasons"`
+	TimesOfDay      []TaxonomyItemRefV1 `db:"-" json:"times_of_day"`
+	Tags            []TaxonomyItemRefV1 `db:"-" json:"tags"`
+	Links           []LinkV1            `db:"-" json:"links"`
+	Locales         map[string]string   `db:"-" json:"locales,omitempty"`
+	SmallImgUrl     string              `db:"-" json:"small_img_url"`
+	LargeImgUrl     string              `db:"-" json:"large_img_url"`
 }
 
 type PerfumsInfoV1 struct {
@@ -70,13 +101,8 @@ type PerfumsInfoV1 struct {
 	Amount  int64          `db:"-" json:"amount"`
 }
 
-func NewPerfumsInfoFactory(version string) Objecter {
-	switch version {
-	case "v1":
-		return &PerfumsInfoV1{ObjList: make([]PerfumInfoV1, 0)}
-	}
-
-	return nil
+func NewPerfumsInfoFactory(version string) (Objecter, error) {
+	return NewObjecter("perfums_info", version)
 }
 
 func (obj *PerfumsInfoV1) MakeObj(pParams interface{}) (Objecter, error) {
@@ -84,6 +110,9 @@ func (obj *PerfumsInfoV1) MakeObj(pParams interface{}) (Objecter, error) {
 		return nil, errors.New("invalid args")
 	}
 	params := pParams.(*MakeObjParams)
+	if err := checkStatuses(params.Statuses); err != nil {
+		return nil, err
+	}
 
 	if err := setDbQueryBaseParams(&params.Base, &params.DbQuery); err != nil {
 		return nil, err
@@ -91,6 +120,9 @@ func (obj *PerfumsInfoV1) MakeObj(pParams interface{}) (Objecter, error) {
 
 	if params.Base.Ids.Valid {
 		params.DbQuery.AndConditionString = addIdsToQuery(params.Base.Ids.String, "parfum_info.uuid")
+	} else {
+		params.DbQuery.WhereConditionString = andCondition(params.DbQuery.WhereConditionString,
+			statusCondition("parfum_info", params.Statuses))
 	}
 
 	query := bytes.NewBufferString("")
@@ -117,14 +149,49 @@ func (obj *PerfumsInfoV1) MakeObj(pParams interface{}) (Objecter, error) {
 				Rel:    "PerfumInfo",
 				Method: "GET",
 			},
+			LinkV1{
+				Href:   baseUrl + "/perfum/" + obj.ObjList[i].Uuid + "/reviews",
+				Rel:    "PerfumReviews",
+				Method: "GET",
+			},
 		}
 
 		if obj.ObjList[i].ImgUuid.Valid {
-			obj.ObjList[i].SmallImgUrl = baseUrl + "/image/" + obj.ObjList[i].ImgUuid.String + "/small"
-			obj.ObjList[i].LargeImgUrl = baseUrl + "/image/" + obj.ObjList[i].ImgUuid.String + "/large"
+			obj.ObjList[i].SmallImgUrl, obj.ObjList[i].LargeImgUrl = imageUrlPair(obj.ObjList[i].ImgUuid.String)
 		}
 	}
 
+	if err := fillPerfumSeasons(obj.ObjList); err != nil {
+		return nil, err
+	}
+
+	l := newLocalizer(params.Locales)
+	for i := range obj.ObjList {
+		l.addPerfumInfo(&obj.ObjList[i])
+	}
+	if err := l.resolve(); err != nil {
+		return nil, err
+	}
+
+	if err := fillPerfumStars(obj.ObjList); err != nil {
+		return nil, err
+	}
+	if err := fillPerfumOffers(obj.ObjList); err != nil {
+		return nil, err
+	}
+	if err := fillPerfumFamily(obj.ObjList); err != nil {
+		return nil, err
+	}
+	if err := fillPerfumPerfumers(obj.ObjList); err != nil {
+		return nil, err
+	}
+	if err := fillPerfumTags(obj.ObjList); err != nil {
+		return nil, err
+	}
+	if err := fillPerfumStatus(obj.ObjList); err != nil {
+		return nil, err
+	}
+
 	return obj, nil
 }
 
@@ -142,7 +209,10 @@ func (obj *PerfumsInfoV1) MakeExtraObj(params *MakeObjParams, uids []string) (Ob
 		params.Base.Ids.Valid = false
 	}
 
-	composition := NewPerfumsCompositionFactory(params.Base.Version)
+	composition, err := NewObjecter("perfums_composition", params.Base.Version)
+	if err != nil {
+		return nil, err
+	}
 	return composition.MakeObj(params)
 }
 
@@ -153,6 +223,11 @@ func (obj *PerfumsInfoV1) Count(pParams interface{}) (int64, error) {
 
 	dbQuery := QueryTemplateParams{}
 	dbQuery.FromTableName = "parfum_info"
+	statuses, err := paramsStatuses(pParams)
+	if err != nil {
+		return 0, err
+	}
+	dbQuery.WhereConditionString = statusCondition("parfum_info", statuses)
 	query := bytes.NewBufferString("")
 	if err := tmpl.ExecuteTemplate(query, "select_count", &dbQuery); err != nil {
 		return 0, err
@@ -167,13 +242,20 @@ func (obj *PerfumsInfoV1) Count(pParams interface{}) (int64, error) {
 }
 
 func (obj *PerfumsInfoV1) ExtraCount(uids []string) (int64, error) {
+	return obj.ExtraCountStatuses(uids, nil)
+}
+
+func (obj *PerfumsInfoV1) ExtraCountStatuses(uids []string, statuses []string) (int64, error) {
 	if len(uids) == 0 {
 		return 0, errors.New("invalid args")
 	}
+	if err := checkStatuses(statuses); err != nil {
+		return 0, err
+	}
 
 	dbQuery := QueryTemplateParams{}
 	dbQuery.FromTableName = "parfum_info"
-	dbQuery.WhereConditionString = addIdsToQuery(uids, "parfum_info.uuid")
+	dbQuery.WhereConditionString = andCondition(addIdsToQuery(uids, "parfum_info.uuid"), perfumsStatusCondition(dbQuery.FromTableName, statuses))
 	query := bytes.NewBufferString("")
 	if err := tmpl.ExecuteTemplate(query, "select_count", &dbQuery); err != nil {
 		return 0, err
@@ -203,9 +285,12 @@ type PerfumCompositionDBRecordV1 struct {
 }
 
 type ComponentItemV1 struct {
-	Id    string   `json:"component_id"`
-	Name  string   `json:"component_name"`
-	Links []LinkV1 `json:"links"`
+	Id         string            `json:"component_id"`
+	Name       string            `json:"component_name"`
+	Intensity  *int64            `json:"intensity"`
+	Percentage *float64          `json:"percentage"`
+	Links      []LinkV1          `json:"links"`
+	Locales    map[string]string `json:"locales,omitempty"`
 }
 
 func NewComponentItemV1(id, name string) *ComponentItemV1 {
@@ -229,9 +314,12 @@ func (c ByComponentName) Less(i, j int) bool {
 type NoteItemV1 struct {
 	Id             string            `json:"note_id"`
 	Name           string            `json:"note_name"`
+	Level          string            `json:"level"`
+	Position       int64             `json:"position"`
 	Components     []ComponentItemV1 `json:"components"`
 	Links          []LinkV1          `json:"links"`
 	ComponentCount int64             `json:"component_count"`
+	Locales        map[string]string `json:"locales,omitempty"`
 }
 
 func NewNoteItemV1(id, name string) *NoteItemV1 {
@@ -384,10 +472,17 @@ func (obj *PerfumCompositionV1) AddPerfumInfoItem(info *PerfumInfoV1) *PerfumCom
 			Method: "GET",
 		},
 	}
+	for _, perfumer := range info.Perfumers {
+		obj.PerfumInfoV1.Links = append(obj.PerfumInfoV1.Links, perfumerLinks(perfumer.Uuid)...)
+	}
+	obj.PerfumInfoV1.Links = append(obj.PerfumInfoV1.Links, seasonsTaxonomy.extraLinks(info.Seasons, info.SeasonUuid)...)
+	obj.PerfumInfoV1.Links = append(obj.PerfumInfoV1.Links, timesOfDayTaxonomy.extraLinks(info.TimesOfDay, info.TsodUuid)...)
+	for _, tag := range info.Tags {
+		obj.PerfumInfoV1.Links = append(obj.PerfumInfoV1.Links, tagLinks(tag.Uuid)...)
+	}
 
 	if info.ImgUuid.Valid {
-		obj.SmallImgUrl = baseUrl + "/image/" + info.ImgUuid.String + "/small"
-		obj.LargeImgUrl = baseUrl + "/image/" + info.ImgUuid.String + "/large"
+		obj.SmallImgUrl, obj.LargeImgUrl = imageUrlPair(info.ImgUuid.String)
 	}
 
 	return obj
@@ -409,15 +504,8 @@ func NewPerfumCompositionV1() *PerfumCompositionV1 {
 	}
 }
 
-func NewPerfumsCompositionFactory(version string) Objecter {
-	switch version {
-	case "v1":
-		return &PerfumsCompositionV1{
-			ObjList: []PerfumCompositionV1{},
-		}
-	}
-
-	return nil
+func NewPerfumsCompositionFactory(version string) (Objecter, error) {
+	return NewObjecter("perfums_composition", version)
 }
 
 func (obj *PerfumsCompositionV1) MakeObj(pParams interface{}) (Objecter, error) {
@@ -516,15 +604,39 @@ func (obj *PerfumsCompositionV1) MakeObj(pParams interface{}) (Objecter, error)
 				newComp := NewComponentItemV1(compId, compName)
 				newNote.AddComponentItem(newComp)
 			}
-			sort.Sort(ByComponentName(newNote.Components))
 			newNote.ComponentCount = int64(len(newNote.Components))
 			pCompos.TotalComponents += newNote.ComponentCount
 			pCompos.AddNoteItem(newNote)
 		}
-		sort.Sort(ByNoteName(pCompos.Notes))
 		obj.ObjList = append(obj.ObjList, *pCompos)
 	}
 
+	if err := fillPyramid(obj.ObjList, params.Levels); err != nil {
+		return nil, err
+	}
+
+	l := newLocalizer(params.Locales)
+	for i := range obj.ObjList {
+		for j := range obj.ObjList[i].Notes {
+			note := &obj.ObjList[i].Notes[j]
+			l.add(&note.Locales, "note_name", note.Id, "name", &note.Name)
+			for k := range note.Components {
+				component := &note.Components[k]
+				l.add(&component.Locales, "component_name", component.Id, "name", &component.Name)
+			}
+		}
+	}
+	if err := l.resolve(); err != nil {
+		return nil, err
+	}
+	// sorted once names are translated
+	for i := range obj.ObjList {
+		for j := range obj.ObjList[i].Notes {
+			sort.Sort(ByComponentWeight(obj.ObjList[i].Notes[j].Components))
+		}
+		sort.Sort(ByNotePyramid(obj.ObjList[i].Notes))
+	}
+
 	return obj, nil
 }
 
@@ -587,14 +699,17 @@ func (obj *PerfumsCompositionV1) Json(w http.ResponseWriter, status int) error {
 
 // Brand ...
 type BrandV1 struct {
-	Id           string         `db:"id" json:"-"`
-	Uuid         string         `db:"brand_uuid" json:"id"`
-	Name         string         `db:"name" json:"name"`
-	ImageId      sql.NullString `db:"img_uuid" json:"-"`
-	PerfumsCount int64          `db:"-" json:"perfums_count"`
-	Links        []LinkV1       `db:"-" json:"links"`
-	SmallImgUrl  string         `db:"-" json:"small_img_url"`
-	LargeImgUrl  string         `db:"-" json:"large_img_url"`
+	Id             string            `db:"id" json:"-"`
+	Uuid           string            `db:"brand_uuid" json:"id"`
+	Name           string            `db:"name" json:"name"`
+	Status         string            `db:"-" json:"status"`
+	ImageId        sql.NullString    `db:"img_uuid" json:"-"`
+	PerfumsCount   int64             `db:"-" json:"perfums_count"`
+	Links          []LinkV1          `db:"-" json:"links"`
+	Locales        map[string]string `db:"-" json:"locales,omitempty"`
+	SmallImgUrl    string            `db:"-" json:"small_img_url"`
+	LargeImgUrl    string            `db:"-" json:"large_img_url"`
+	RedirectedFrom string            `db:"-" json:"redirected_from,omitempty"`
 }
 
 // Brands ...
@@ -605,13 +720,8 @@ type BrandsV1 struct {
 	Amount  int64     `db:"-" json:"amount"`
 }
 
-func NewBrandsFactory(version string) Objecter {
-	switch version {
-	case "v1":
-		return &BrandsV1{ObjList: make([]BrandV1, 0)}
-	}
-
-	return nil
+func NewBrandsFactory(version string) (Objecter, error) {
+	return NewObjecter("brands", version)
 }
 
 func (obj *BrandsV1) MakeObj(pParams interface{}) (Objecter, error) {
@@ -620,13 +730,24 @@ func (obj *BrandsV1) MakeObj(pParams interface{}) (Objecter, error) {
 	}
 
 	params := pParams.(*MakeObjParams)
+	if err := checkStatuses(params.Statuses); err != nil {
+		return nil, err
+	}
 
 	if err := setDbQueryBaseParams(&params.Base, &params.DbQuery); err != nil {
 		return nil, err
 	}
 
+	var redirected map[string]string
 	if params.Base.Ids.Valid {
-		params.DbQuery.WhereConditionString = addIdsToQuery(params.Base.Ids.String, "brands.uuid")
+		ids, from, err := redirectIds("brand", params.Base.Ids.String)
+		if err != nil {
+			return nil, err
+		}
+		redirected = from
+		params.DbQuery.WhereConditionString = addIdsToQuery(ids, "brands.uuid")
+	} else {
+		params.DbQuery.WhereConditionString = statusCondition("brands", params.Statuses)
 	}
 
 	query := bytes.NewBufferString("")
@@ -642,10 +763,11 @@ func (obj *BrandsV1) MakeObj(pParams interface{}) (Objecter, error) {
 	obj.Offset = params.Base.Offset.Int64
 	obj.Amount = int64(len(obj.ObjList))
 
+	if err := fillPerfumsCounts("brands", &obj.ObjList, params.Statuses); err != nil {
+		return nil, err
+	}
+
 	for i := 0; i < len(obj.ObjList); i++ {
-		// obj.ObjList[i].PerfumsCount = params.PerfumsNum
-		perfumsCount, _ := GetPerfumsCount("brands", obj.ObjList[i].Uuid)
-		obj.ObjList[i].PerfumsCount = perfumsCount
 		obj.ObjList[i].Links = []LinkV1{
 			LinkV1{
 				Href:   baseUrl + "/brand/" + obj.ObjList[i].Uuid,
@@ -659,12 +781,24 @@ func (obj *BrandsV1) MakeObj(pParams interface{}) (Objecter, error) {
 			},
 		}
 
+		obj.ObjList[i].RedirectedFrom = redirected[obj.ObjList[i].Uuid]
+
 		if obj.ObjList[i].ImageId.Valid {
-			obj.ObjList[i].SmallImgUrl = baseUrl + "/image/" + obj.ObjList[i].ImageId.String + "/small"
-			obj.ObjList[i].LargeImgUrl = baseUrl + "/image/" + obj.ObjList[i].ImageId.String + "/large"
+			obj.ObjList[i].SmallImgUrl, obj.ObjList[i].LargeImgUrl = imageUrlPair(obj.ObjList[i].ImageId.String)
 		}
 	}
 
+	l := newLocalizer(params.Locales)
+	for i := range obj.ObjList {
+		l.add(&obj.ObjList[i].Locales, "name", obj.ObjList[i].Uuid, "name", &obj.ObjList[i].Name)
+	}
+	if err := l.resolve(); err != nil {
+		return nil, err
+	}
+	if err := fillStatus("brands", &obj.ObjList); err != nil {
+		return nil, err
+	}
+
 	return obj, nil
 }
 
@@ -673,6 +807,11 @@ func (obj *BrandsV1) MakeExtraObj(params *MakeObjParams, uids []string) (Objecte
 		return nil, errors.New("invalid args")
 	}
 
+	uids, err := redirectUuids("brand", uids)
+	if err != nil {
+		return nil, err
+	}
+
 	params.DbQuery.ConditionTableField = "brand_id"
 	params.DbQuery.ConditionTableName = "brands"
 	params.DbQuery.ConditionUuid = addIdsToQuery(uids, "brands.uuid")
@@ -695,7 +834,10 @@ func (obj *BrandsV1) MakeExtraObj(params *MakeObjParams, uids []string) (Objecte
 		return nil, err
 	}
 
-	pinfos := NewPerfumsInfoFactory(params.Base.Version)
+	pinfos, err := NewObjecter("perfums_info", params.Base.Version)
+	if err != nil {
+		return nil, err
+	}
 	return pinfos.MakeObj(params)
 }
 
@@ -706,6 +848,11 @@ func (obj *BrandsV1) Count(pParams interface{}) (int64, error) {
 
 	dbQuery := QueryTemplateParams{}
 	dbQuery.FromTableName = "brands"
+	statuses, err := paramsStatuses(pParams)
+	if err != nil {
+		return 0, err
+	}
+	dbQuery.WhereConditionString = statusCondition("brands", statuses)
 	query := bytes.NewBufferString("")
 	if err := tmpl.ExecuteTemplate(query, "select_count", &dbQuery); err != nil {
 		return 0, err
@@ -720,9 +867,21 @@ func (obj *BrandsV1) Count(pParams interface{}) (int64, error) {
 }
 
 func (obj *BrandsV1) ExtraCount(uids []string) (int64, error) {
+	return obj.ExtraCountStatuses(uids, nil)
+}
+
+func (obj *BrandsV1) ExtraCountStatuses(uids []string, statuses []string) (int64, error) {
 	if len(uids) == 0 {
 		return 0, errors.New("invalid args")
 	}
+	if err := checkStatuses(statuses); err != nil {
+		return 0, err
+	}
+
+	uids, err := redirectUuids("brand", uids)
+	if err != nil {
+		return 0, err
+	}
 
 	dbQuery := QueryTemplateParams{}
 	dbQuery.FromTableName = "parfum_info"
@@ -733,7 +892,7 @@ func (obj *BrandsV1) ExtraCount(uids []string) (int64, error) {
 	if err := tmpl.ExecuteTemplate(query, "condition_select_id_eq_uuid", &dbQuery); err != nil {
 		return 0, err
 	}
-	dbQuery.WhereConditionString = query.String()
+	dbQuery.WhereConditionString = andCondition(query.String(), perfumsStatusCondition(dbQuery.FromTableName, statuses))
 	query.Reset()
 	if err := tmpl.ExecuteTemplate(query, "select_count", &dbQuery); err != nil {
 		return 0, err
@@ -754,14 +913,19 @@ func (obj *BrandsV1) Json(w http.ResponseWriter, status int) error {
 
 // ComponentDB ...
 type ComponentV1 struct {
-	Id           int64          `db:"id" json:"-"`
-	Uuid         string         `db:"component_uuid" json:"id"`
-	Name         string         `db:"name" json:"name"`
-	ImageId      sql.NullString `db:"img_uuid" json:"-"`
-	PerfumsCount int64          `db:"-" json:"perfums_count"`
-	Links        []LinkV1       `db:"-" json:"links"`
-	SmallImgUrl  string         `db:"-" json:"small_img_url"`
-	LargeImgUrl  string         `db:"-" json:"large_img_url"`
+	Id             int64             `db:"id" json:"-"`
+	Uuid           string            `db:"component_uuid" json:"id"`
+	Name           string            `db:"name" json:"name"`
+	Status         string            `db:"-" json:"status"`
+	ImageId        sql.NullString    `db:"img_uuid" json:"-"`
+	PerfumsCount   int64             `db:"-" json:"perfums_count"`
+	Links          []LinkV1          `db:"-" json:"links"`
+	Locales        map[string]string `db:"-" json:"locales,omitempty"`
+	SmallImgUrl    string            `db:"-" json:"small_img_url"`
+	LargeImgUrl    string            `db:"-" json:"large_img_url"`
+	ParentUuid     sql.NullString    `db:"-" json:"parent_id"`
+	Children       []ComponentV1     `db:"-" json:"children,omitempty"`
+	RedirectedFrom string            `db:"-" json:"redirected_from,omitempty"`
 }
 
 // Components ...
@@ -772,13 +936,8 @@ type ComponentsV1 struct {
 	Amount  int64         `db:"-" json:"amount"`
 }
 
-func NewComponentsFactory(version string) Objecter {
-	switch version {
-	case "v1":
-		return &ComponentsV1{ObjList: make([]ComponentV1, 0)}
-	}
-
-	return nil
+func NewComponentsFactory(version string) (Objecter, error) {
+	return NewObjecter("components", version)
 }
 
 func (obj *ComponentsV1) MakeObj(pParams interface{}) (Objecter, error) {
@@ -787,13 +946,26 @@ func (obj *ComponentsV1) MakeObj(pParams interface{}) (Objecter, error) {
 	}
 
 	params := pParams.(*MakeObjParams)
+	if err := checkStatuses(params.Statuses); err != nil {
+		return nil, err
+	}
 
 	if err := setDbQueryBaseParams(&params.Base, &params.DbQuery); err != nil {
 		return nil, err
 	}
 
+	var redirected map[string]string
 	if params.Base.Ids.Valid {
-		params.DbQuery.WhereConditionString = addIdsToQuery(params.Base.Ids.String, "components.uuid")
+		ids, from, err := redirectIds("component", params.Base.Ids.String)
+		if err != nil {
+			return nil, err
+		}
+		redirected = from
+		params.DbQuery.WhereConditionString = addIdsToQuery(ids, "components.uuid")
+	} else if params.Tree {
+		params.DbQuery.WhereConditionString = andCondition(componentsTree.rootsCondition(), statusCondition("components", params.Statuses))
+	} else {
+		params.DbQuery.WhereConditionString = statusCondition("components", params.Statuses)
 	}
 
 	query := bytes.NewBufferString("")
@@ -809,10 +981,11 @@ func (obj *ComponentsV1) MakeObj(pParams interface{}) (Objecter, error) {
 	obj.Offset = params.Base.Offset.Int64
 	obj.Amount = int64(len(obj.ObjList))
 
+	if err := fillPerfumsCounts("components", &obj.ObjList, params.Statuses); err != nil {
+		return nil, err
+	}
+
 	for i := 0; i < len(obj.ObjList); i++ {
-		// obj.ObjList[i].PerfumsCount = params.PerfumsNum
-		perfumsCount, _ := GetPerfumsCount("components", obj.ObjList[i].Uuid)
-		obj.ObjList[i].PerfumsCount = perfumsCount
 		obj.ObjList[i].Links = []LinkV1{
 			LinkV1{
 				Href:   baseUrl + "/component/" + obj.ObjList[i].Uuid,
@@ -826,12 +999,28 @@ func (obj *ComponentsV1) MakeObj(pParams interface{}) (Objecter, error) {
 			},
 		}
 
+		obj.ObjList[i].RedirectedFrom = redirected[obj.ObjList[i].Uuid]
+
 		if obj.ObjList[i].ImageId.Valid {
-			obj.ObjList[i].SmallImgUrl = baseUrl + "/image/" + obj.ObjList[i].ImageId.String + "/small"
-			obj.ObjList[i].LargeImgUrl = baseUrl + "/image/" + obj.ObjList[i].ImageId.String + "/large"
+			obj.ObjList[i].SmallImgUrl, obj.ObjList[i].LargeImgUrl = imageUrlPair(obj.ObjList[i].ImageId.String)
 		}
 	}
 
+	if err := obj.fillTree(params); err != nil {
+		return nil, err
+	}
+
+	l := newLocalizer(params.Locales)
+	for i := range obj.ObjList {
+		l.add(&obj.ObjList[i].Locales, "name", obj.ObjList[i].Uuid, "name", &obj.ObjList[i].Name)
+	}
+	if err := l.resolve(); err != nil {
+		return nil, err
+	}
+	if err := fillStatus("components", &obj.ObjList); err != nil {
+		return nil, err
+	}
+
 	return obj, nil
 }
 
@@ -840,6 +1029,17 @@ func (obj *ComponentsV1) MakeExtraObj(params *MakeObjParams, uids []string) (Obj
 		return nil, errors.New("invalid args")
 	}
 
+	uids, err := redirectUuids("component", uids)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.Descendants {
+		if uids, err = componentsTree.descendants(uids); err != nil {
+			return nil, err
+		}
+	}
+
 	params.DbQuery.WhereConditionString = addIdsToQuery(uids, "components.uuid")
 	query := bytes.NewBufferString("")
 	if params.Base.Ids.Valid {
@@ -855,7 +1055,10 @@ func (obj *ComponentsV1) MakeExtraObj(params *MakeObjParams, uids []string) (Obj
 	params.DbQuery.WhereConditionString = ""
 	params.DbQuery.AndConditionString = ""
 
-	pinfos := NewPerfumsInfoFactory(params.Base.Version)
+	pinfos, err := NewObjecter("perfums_info", params.Base.Version)
+	if err != nil {
+		return nil, err
+	}
 	return pinfos.MakeObj(params)
 }
 
@@ -866,6 +1069,14 @@ func (obj *ComponentsV1) Count(pParams interface{}) (int64, error) {
 
 	dbQuery := QueryTemplateParams{}
 	dbQuery.FromTableName = "components"
+	if params, ok := pParams.(*MakeObjParams); ok && params.Tree {
+		dbQuery.WhereConditionString = componentsTree.rootsCondition()
+	}
+	statuses, err := paramsStatuses(pParams)
+	if err != nil {
+		return 0, err
+	}
+	dbQuery.WhereConditionString = andCondition(dbQuery.WhereConditionString, statusCondition("components", statuses))
 	query := bytes.NewBufferString("")
 	if err := tmpl.ExecuteTemplate(query, "select_count", &dbQuery); err != nil {
 		return 0, err
@@ -880,9 +1091,21 @@ func (obj *ComponentsV1) Count(pParams interface{}) (int64, error) {
 }
 
 func (obj *ComponentsV1) ExtraCount(uids []string) (int64, error) {
+	return obj.ExtraCountStatuses(uids, nil)
+}
+
+func (obj *ComponentsV1) ExtraCountStatuses(uids []string, statuses []string) (int64, error) {
 	if len(uids) == 0 {
 		return 0, errors.New("invalid args")
 	}
+	if err := checkStatuses(statuses); err != nil {
+		return 0, err
+	}
+
+	uids, err := redirectUuids("component", uids)
+	if err != nil {
+		return 0, err
+	}
 
 	dbQuery := QueryTemplateParams{}
 	dbQuery.FromTableName = "parfums"
@@ -894,7 +1117,7 @@ func (obj *ComponentsV1) ExtraCount(uids []string) (int64, error) {
 	if err := tmpl.ExecuteTemplate(query, "condition_select_id_eq_uuid", &dbQuery); err != nil {
 		return 0, err
 	}
-	dbQuery.WhereConditionString = query.String()
+	dbQuery.WhereConditionString = andCondition(query.String(), perfumsStatusCondition(dbQuery.FromTableName, statuses))
 	query.Reset()
 	if err := tmpl.ExecuteTemplate(query, "select_count", &dbQuery); err != nil {
 		return 0, err
@@ -914,14 +1137,16 @@ func (obj *ComponentsV1) Json(w http.ResponseWriter, status int) error {
 }
 
 type CountryV1 struct {
-	Id           int64          `db:"id" json:"-"`
-	Uuid         string         `db:"country_uuid" json:"id"`
-	Name         string         `db:"name" json:"name"`
-	ImageId      sql.NullString `db:"img_uuid" json:"-"`
-	PerfumsCount int64          `db:"-" json:"perfums_count"`
-	Links        []LinkV1       `db:"-" json:"links"`
-	SmallImgUrl  string         `db:"-" json:"small_img_url"`
-	LargeImgUrl  string         `db:"-" json:"large_img_url"`
+	Id           int64             `db:"id" json:"-"`
+	Uuid         string            `db:"country_uuid" json:"id"`
+	Name         string            `db:"name" json:"name"`
+	Status       string            `db:"-" json:"status"`
+	ImageId      sql.NullString    `db:"img_uuid" json:"-"`
+	PerfumsCount int64             `db:"-" json:"perfums_count"`
+	Links        []LinkV1          `db:"-" json:"links"`
+	Locales      map[string]string `db:"-" json:"locales,omitempty"`
+	SmallImgUrl  string            `db:"-" json:"small_img_url"`
+	LargeImgUrl  string            `db:"-" json:"large_img_url"`
 }
 
 // Countries ...
@@ -932,13 +1157,8 @@ type CountriesV1 struct {
 	Amount  int64       `db:"-" json:"amount"`
 }
 
-func NewCountriesFactory(version string) Objecter {
-	switch version {
-	case "v1":
-		return &CountriesV1{ObjList: make([]CountryV1, 0)}
-	}
-
-	return nil
+func NewCountriesFactory(version string) (Objecter, error) {
+	return NewObjecter("countries", version)
 }
 
 func (obj *CountriesV1) MakeObj(pParams interface{}) (Objecter, error) {
@@ -947,6 +1167,9 @@ func (obj *CountriesV1) MakeObj(pParams interface{}) (Objecter, error) {
 	}
 
 	params := pParams.(*MakeObjParams)
+	if err := checkStatuses(params.Statuses); err != nil {
+		return nil, err
+	}
 
 	if err := setDbQueryBaseParams(&params.Base, &params.DbQuery); err != nil {
 		return nil, err
@@ -954,6 +1177,8 @@ func (obj *CountriesV1) MakeObj(pParams interface{}) (Objecter, error) {
 
 	if params.Base.Ids.Valid {
 		params.DbQuery.WhereConditionString = addIdsToQuery(params.Base.Ids.String, "countries.uuid")
+	} else {
+		params.DbQuery.WhereConditionString = statusCondition("countries", params.Statuses)
 	}
 
 	query := bytes.NewBufferString("")
@@ -969,10 +1194,11 @@ func (obj *CountriesV1) MakeObj(pParams interface{}) (Objecter, error) {
 	obj.Offset = params.Base.Offset.Int64
 	obj.Amount = int64(len(obj.ObjList))
 
+	if err := fillPerfumsCounts("countries", &obj.ObjList, params.Statuses); err != nil {
+		return nil, err
+	}
+
 	for i := 0; i < len(obj.ObjList); i++ {
-		// obj.ObjList[i].PerfumsCount = params.PerfumsNum
-		perfumsCount, _ := GetPerfumsCount("countries", obj.ObjList[i].Uuid)
-		obj.ObjList[i].PerfumsCount = perfumsCount
 		obj.ObjList[i].Links = []LinkV1{
 			LinkV1{
 				Href:   baseUrl + "/country/" + obj.ObjList[i].Uuid,
@@ -987,11 +1213,21 @@ func (obj *CountriesV1) MakeObj(pParams interface{}) (Objecter, error) {
 		}
 
 		if obj.ObjList[i].ImageId.Valid {
-			obj.ObjList[i].SmallImgUrl = baseUrl + "/image/" + obj.ObjList[i].ImageId.String + "/small"
-			obj.ObjList[i].LargeImgUrl = baseUrl + "/image/" + obj.ObjList[i].ImageId.String + "/large"
+			obj.ObjList[i].SmallImgUrl, obj.ObjList[i].LargeImgUrl = imageUrlPair(obj.ObjList[i].ImageId.String)
 		}
 	}
 
+	l := newLocalizer(params.Locales)
+	for i := range obj.ObjList {
+		l.add(&obj.ObjList[i].Locales, "name", obj.ObjList[i].Uuid, "name", &obj.ObjList[i].Name)
+	}
+	if err := l.resolve(); err != nil {
+		return nil, err
+	}
+	if err := fillStatus("countries", &obj.ObjList); err != nil {
+		return nil, err
+	}
+
 	return obj, nil
 }
 
@@ -1022,7 +1258,10 @@ func (obj *CountriesV1) MakeExtraObj(params *MakeObjParams, uids []string) (Obje
 		return nil, err
 	}
 
-	pinfos := NewPerfumsInfoFactory(params.Base.Version)
+	pinfos, err := NewObjecter("perfums_info", params.Base.Version)
+	if err != nil {
+		return nil, err
+	}
 	return pinfos.MakeObj(params)
 }
 
@@ -1033,6 +1272,11 @@ func (obj *CountriesV1) Count(pParams interface{}) (int64, error) {
 
 	dbQuery := QueryTemplateParams{}
 	dbQuery.FromTableName = "countries"
+	statuses, err := paramsStatuses(pParams)
+	if err != nil {
+		return 0, err
+	}
+	dbQuery.WhereConditionString = statusCondition("countries", statuses)
 	query := bytes.NewBufferString("")
 	if err := tmpl.ExecuteTemplate(query, "select_count", &dbQuery); err != nil {
 		return 0, err
@@ -1047,9 +1291,16 @@ func (obj *CountriesV1) Count(pParams interface{}) (int64, error) {
 }
 
 func (obj *CountriesV1) ExtraCount(uids []string) (int64, error) {
+	return obj.ExtraCountStatuses(uids, nil)
+}
+
+func (obj *CountriesV1) ExtraCountStatuses(uids []string, statuses []string) (int64, error) {
 	if len(uids) == 0 {
 		return 0, errors.New("invalid args")
 	}
+	if err := checkStatuses(statuses); err != nil {
+		return 0, err
+	}
 
 	dbQuery := QueryTemplateParams{}
 	dbQuery.FromTableName = "parfum_info"
@@ -1060,7 +1311,7 @@ func (obj *CountriesV1) ExtraCount(uids []string) (int64, error) {
 	if err := tmpl.ExecuteTemplate(query, "condition_select_id_eq_uuid", &dbQuery); err != nil {
 		return 0, err
 	}
-	dbQuery.WhereConditionString = query.String()
+	dbQuery.WhereConditionString = andCondition(query.String(), perfumsStatusCondition(dbQuery.FromTableName, statuses))
 	query.Reset()
 	if err := tmpl.ExecuteTemplate(query, "select_count", &dbQuery); err != nil {
 		return 0, err
@@ -1080,14 +1331,16 @@ func (obj *CountriesV1) Json(w http.ResponseWriter, status int) error {
 }
 
 type GenderV1 struct {
-	Id           int64          `db:"id" json:"-"`
-	Uuid         string         `db:"gender_uuid" json:"id"`
-	Name         string         `db:"name" json:"name"`
-	ImageId      sql.NullString `db:"img_uuid" json:"-"`
-	PerfumsCount int64          `db:"-" json:"perfums_count"`
-	Links        []LinkV1       `db:"-" json:"links"`
-	SmallImgUrl  string         `db:"-" json:"small_img_url"`
-	LargeImgUrl  string         `db:"-" json:"large_img_url"`
+	Id           int64             `db:"id" json:"-"`
+	Uuid         string            `db:"gender_uuid" json:"id"`
+	Name         string            `db:"name" json:"name"`
+	Status       string            `db:"-" json:"status"`
+	ImageId      sql.NullString    `db:"img_uuid" json:"-"`
+	PerfumsCount int64             `db:"-" json:"perfums_count"`
+	Links        []LinkV1          `db:"-" json:"links"`
+	Locales      map[string]string `db:"-" json:"locales,omitempty"`
+	SmallImgUrl  string            `db:"-" json:"small_img_url"`
+	LargeImgUrl  string            `db:"-" json:"large_img_url"`
 }
 
 // GendersV1 ...
@@ -1098,13 +1351,8 @@ type GendersV1 struct {
 	Amount  int64      `db:"-" json:"amount"`
 }
 
-func NewGendersFactory(version string) Objecter {
-	switch version {
-	case "v1":
-		return &GendersV1{ObjList: make([]GenderV1, 0)}
-	}
-
-	return nil
+func NewGendersFactory(version string) (Objecter, error) {
+	return NewObjecter("genders", version)
 }
 
 func (obj *GendersV1) MakeObj(pParams interface{}) (Objecter, error) {
@@ -1113,6 +1361,9 @@ func (obj *GendersV1) MakeObj(pParams interface{}) (Objecter, error) {
 	}
 
 	params := pParams.(*MakeObjParams)
+	if err := checkStatuses(params.Statuses); err != nil {
+		return nil, err
+	}
 
 	if err := setDbQueryBaseParams(&params.Base, &params.DbQuery); err != nil {
 		return nil, err
@@ -1120,6 +1371,8 @@ func (obj *GendersV1) MakeObj(pParams interface{}) (Objecter, error) {
 
 	if params.Base.Ids.Valid {
 		params.DbQuery.WhereConditionString = addIdsToQuery(params.Base.Ids.String, "gender.uuid")
+	} else {
+		params.DbQuery.WhereConditionString = statusCondition("gender", params.Statuses)
 	}
 
 	query := bytes.NewBufferString("")
@@ -1135,10 +1388,11 @@ func (obj *GendersV1) MakeObj(pParams interface{}) (Objecter, error) {
 	obj.Offset = params.Base.Offset.Int64
 	obj.Amount = int64(len(obj.ObjList))
 
+	if err := fillPerfumsCounts("gender", &obj.ObjList, params.Statuses); err != nil {
+		return nil, err
+	}
+
 	for i := 0; i < len(obj.ObjList); i++ {
-		// obj.ObjList[i].PerfumsCount = params.PerfumsNum
-		perfumsCount, _ := GetPerfumsCount("genders", obj.ObjList[i].Uuid)
-		obj.ObjList[i].PerfumsCount = perfumsCount
 		obj.ObjList[i].Links = []LinkV1{
 			LinkV1{
 				Href:   baseUrl + "/gender/" + obj.ObjList[i].Uuid,
@@ -1153,11 +1407,21 @@ func (obj *GendersV1) MakeObj(pParams interface{}) (Objecter, error) {
 		}
 
 		if obj.ObjList[i].ImageId.Valid {
-			obj.ObjList[i].SmallImgUrl = baseUrl + "/image/" + obj.ObjList[i].ImageId.String + "/small"
-			obj.ObjList[i].LargeImgUrl = baseUrl + "/image/" + obj.ObjList[i].ImageId.String + "/large"
+			obj.ObjList[i].SmallImgUrl, obj.ObjList[i].LargeImgUrl = imageUrlPair(obj.ObjList[i].ImageId.String)
 		}
 	}
 
+	l := newLocalizer(params.Locales)
+	for i := range obj.ObjList {
+		l.add(&obj.ObjList[i].Locales, "name", obj.ObjList[i].Uuid, "name", &obj.ObjList[i].Name)
+	}
+	if err := l.resolve(); err != nil {
+		return nil, err
+	}
+	if err := fillStatus("gender", &obj.ObjList); err != nil {
+		return nil, err
+	}
+
 	return obj, nil
 }
 
@@ -1188,7 +1452,10 @@ func (obj *GendersV1) MakeExtraObj(params *MakeObjParams, uids []string) (Object
 		return nil, err
 	}
 
-	pinfos := NewPerfumsInfoFactory(params.Base.Version)
+	pinfos, err := NewObjecter("perfums_info", params.Base.Version)
+	if err != nil {
+		return nil, err
+	}
 	return pinfos.MakeObj(params)
 }
 
@@ -1199,6 +1466,11 @@ func (obj *GendersV1) Count(pParams interface{}) (int64, error) {
 
 	dbQuery := QueryTemplateParams{}
 	dbQuery.FromTableName = "gender"
+	statuses, err := paramsStatuses(pParams)
+	if err != nil {
+		return 0, err
+	}
+	dbQuery.WhereConditionString = statusCondition("gender", statuses)
 	query := bytes.NewBufferString("")
 	if err := tmpl.ExecuteTemplate(query, "select_count", &dbQuery); err != nil {
 		return 0, err
@@ -1213,9 +1485,16 @@ func (obj *GendersV1) Count(pParams interface{}) (int64, error) {
 }
 
 func (obj *GendersV1) ExtraCount(uids []string) (int64, error) {
+	return obj.ExtraCountStatuses(uids, nil)
+}
+
+func (obj *GendersV1) ExtraCountStatuses(uids []string, statuses []string) (int64, error) {
 	if len(uids) == 0 {
 		return 0, errors.New("invalid args")
 	}
+	if err := checkStatuses(statuses); err != nil {
+		return 0, err
+	}
 
 	dbQuery := QueryTemplateParams{}
 	dbQuery.FromTableName = "parfum_info"
@@ -1226,7 +1505,7 @@ func (obj *GendersV1) ExtraCount(uids []string) (int64, error) {
 	if err := tmpl.ExecuteTemplate(query, "condition_select_id_eq_uuid", &dbQuery); err != nil {
 		return 0, err
 	}
-	dbQuery.WhereConditionString = query.String()
+	dbQuery.WhereConditionString = andCondition(query.String(), perfumsStatusCondition(dbQuery.FromTableName, statuses))
 	query.Reset()
 	if err := tmpl.ExecuteTemplate(query, "select_count", &dbQuery); err != nil {
 		return 0, err
@@ -1246,14 +1525,18 @@ func (obj *GendersV1) Json(w http.ResponseWriter, status int) error {
 }
 
 type GroupV1 struct {
-	Id           int64          `db:"id" json:"-"`
-	Uuid         string         `db:"group_uuid" json:"id"`
-	Name         string         `db:"name" json:"name"`
-	ImageId      sql.NullString `db:"img_uuid" json:"-"`
-	PerfumsCount int64          `db:"-" json:"perfums_count"`
-	Links        []LinkV1       `db:"-" json:"links"`
-	SmallImgUrl  string         `db:"-" json:"small_img_url"`
-	LargeImgUrl  string         `db:"-" json:"large_img_url"`
+	Id           int64             `db:"id" json:"-"`
+	Uuid         string            `db:"group_uuid" json:"id"`
+	Name         string            `db:"name" json:"name"`
+	Status       string            `db:"-" json:"status"`
+	ImageId      sql.NullString    `db:"img_uuid" json:"-"`
+	PerfumsCount int64             `db:"-" json:"perfums_count"`
+	Links        []LinkV1          `db:"-" json:"links"`
+	Locales      map[string]string `db:"-" json:"locales,omitempty"`
+	SmallImgUrl  string            `db:"-" json:"small_img_url"`
+	LargeImgUrl  string            `db:"-" json:"large_img_url"`
+	ParentUuid   sql.NullString    `db:"-" json:"parent_id"`
+	Children     []GroupV1         `db:"-" json:"children,omitempty"`
 }
 
 // GroupsV1 ...
@@ -1264,13 +1547,8 @@ type GroupsV1 struct {
 	Amount  int64     `db:"-" json:"amount"`
 }
 
-func NewGroupsFactory(version string) Objecter {
-	switch version {
-	case "v1":
-		return &GroupsV1{ObjList: make([]GroupV1, 0)}
-	}
-
-	return nil
+func NewGroupsFactory(version string) (Objecter, error) {
+	return NewObjecter("groups", version)
 }
 
 func (obj *GroupsV1) MakeObj(pParams interface{}) (Objecter, error) {
@@ -1279,6 +1557,9 @@ func (obj *GroupsV1) MakeObj(pParams interface{}) (Objecter, error) {
 	}
 
 	params := pParams.(*MakeObjParams)
+	if err := checkStatuses(params.Statuses); err != nil {
+		return nil, err
+	}
 
 	if err := setDbQueryBaseParams(&params.Base, &params.DbQuery); err != nil {
 		return nil, err
@@ -1286,6 +1567,10 @@ func (obj *GroupsV1) MakeObj(pParams interface{}) (Objecter, error) {
 
 	if params.Base.Ids.Valid {
 		params.DbQuery.WhereConditionString = addIdsToQuery(params.Base.Ids.String, "groups.uuid")
+	} else if params.Tree {
+		params.DbQuery.WhereConditionString = andCondition(groupsTree.rootsCondition(), statusCondition("groups", params.Statuses))
+	} else {
+		params.DbQuery.WhereConditionString = statusCondition("groups", params.Statuses)
 	}
 
 	query := bytes.NewBufferString("")
@@ -1301,10 +1586,11 @@ func (obj *GroupsV1) MakeObj(pParams interface{}) (Objecter, error) {
 	obj.Offset = params.Base.Offset.Int64
 	obj.Amount = int64(len(obj.ObjList))
 
+	if err := fillPerfumsCounts("groups", &obj.ObjList, params.Statuses); err != nil {
+		return nil, err
+	}
+
 	for i := 0; i < len(obj.ObjList); i++ {
-		// obj.ObjList[i].PerfumsCount = params.PerfumsNum
-		perfumsCount, _ := GetPerfumsCount("groups", obj.ObjList[i].Uuid)
-		obj.ObjList[i].PerfumsCount = perfumsCount
 		obj.ObjList[i].Links = []LinkV1{
 			LinkV1{
 				Href:   baseUrl + "/group/" + obj.ObjList[i].Uuid,
@@ -1319,11 +1605,25 @@ func (obj *GroupsV1) MakeObj(pParams interface{}) (Objecter, error) {
 		}
 
 		if obj.ObjList[i].ImageId.Valid {
-			obj.ObjList[i].SmallImgUrl = baseUrl + "/image/" + obj.ObjList[i].ImageId.String + "/small"
-			obj.ObjList[i].LargeImgUrl = baseUrl + "/image/" + obj.ObjList[i].ImageId.String + "/large"
+			obj.ObjList[i].SmallImgUrl, obj.ObjList[i].LargeImgUrl = imageUrlPair(obj.ObjList[i].ImageId.String)
 		}
 	}
 
+	if err := obj.fillTree(params); err != nil {
+		return nil, err
+	}
+
+	l := newLocalizer(params.Locales)
+	for i := range obj.ObjList {
+		l.add(&obj.ObjList[i].Locales, "name", obj.ObjList[i].Uuid, "name", &obj.ObjList[i].Name)
+	}
+	if err := l.resolve(); err != nil {
+		return nil, err
+	}
+	if err := fillStatus("groups", &obj.ObjList); err != nil {
+		return nil, err
+	}
+
 	return obj, nil
 }
 
@@ -1332,6 +1632,13 @@ func (obj *GroupsV1) MakeExtraObj(params *MakeObjParams, uids []string) (Objecte
 		return nil, errors.New("invalid args")
 	}
 
+	if params.Descendants {
+		var err error
+		if uids, err = groupsTree.descendants(uids); err != nil {
+			return nil, err
+		}
+	}
+
 	params.DbQuery.ConditionTableField = "group_id"
 	params.DbQuery.ConditionTableName = "groups"
 	params.DbQuery.ConditionUuid = addIdsToQuery(uids, "groups.uuid")
@@ -1354,7 +1661,10 @@ func (obj *GroupsV1) MakeExtraObj(params *MakeObjParams, uids []string) (Objecte
 		return nil, err
 	}
 
-	pinfos := NewPerfumsInfoFactory(params.Base.Version)
+	pinfos, err := NewObjecter("perfums_info", params.Base.Version)
+	if err != nil {
+		return nil, err
+	}
 	return pinfos.MakeObj(params)
 }
 
@@ -1365,6 +1675,14 @@ func (obj *GroupsV1) Count(pParams interface{}) (int64, error) {
 
 	dbQuery := QueryTemplateParams{}
 	dbQuery.FromTableName = "groups"
+	if params, ok := pParams.(*MakeObjParams); ok && params.Tree {
+		dbQuery.WhereConditionString = groupsTree.rootsCondition()
+	}
+	statuses, err := paramsStatuses(pParams)
+	if err != nil {
+		return 0, err
+	}
+	dbQuery.WhereConditionString = andCondition(dbQuery.WhereConditionString, statusCondition("groups", statuses))
 	query := bytes.NewBufferString("")
 	if err := tmpl.ExecuteTemplate(query, "select_count", &dbQuery); err != nil {
 		return 0, err
@@ -1379,9 +1697,16 @@ func (obj *GroupsV1) Count(pParams interface{}) (int64, error) {
 }
 
 func (obj *GroupsV1) ExtraCount(uids []string) (int64, error) {
+	return obj.ExtraCountStatuses(uids, nil)
+}
+
+func (obj *GroupsV1) ExtraCountStatuses(uids []string, statuses []string) (int64, error) {
 	if len(uids) == 0 {
 		return 0, errors.New("invalid args")
 	}
+	if err := checkStatuses(statuses); err != nil {
+		return 0, err
+	}
 
 	dbQuery := QueryTemplateParams{}
 	dbQuery.FromTableName = "parfum_info"
@@ -1392,7 +1717,7 @@ func (obj *GroupsV1) ExtraCount(uids []string) (int64, error) {
 	if err := tmpl.ExecuteTemplate(query, "condition_select_id_eq_uuid", &dbQuery); err != nil {
 		return 0, err
 	}
-	dbQuery.WhereConditionString = query.String()
+	dbQuery.WhereConditionString = andCondition(query.String(), perfumsStatusCondition(dbQuery.FromTableName, statuses))
 	query.Reset()
 	if err := tmpl.ExecuteTemplate(query, "select_count", &dbQuery); err != nil {
 		return 0, err
@@ -1412,14 +1737,16 @@ func (obj *GroupsV1) Json(w http.ResponseWriter, status int) error {
 }
 
 type NoteV1 struct {
-	Id           int64          `db:"id" json:"-"`
-	Uuid         string         `db:"note_uuid" json:"id"`
-	Name         string         `db:"name" json:"name"`
-	ImageId      sql.NullString `db:"img_uuid" json:"-"`
-	PerfumsCount int64          `db:"-" json:"perfums_count"`
-	Links        []LinkV1       `db:"-" json:"links"`
-	SmallImgUrl  string         `db:"-" json:"small_img_url"`
-	LargeImgUrl  string         `db:"-" json:"large_img_url"`
+	Id           int64             `db:"id" json:"-"`
+	Uuid         string            `db:"note_uuid" json:"id"`
+	Name         string            `db:"name" json:"name"`
+	Status       string            `db:"-" json:"status"`
+	ImageId      sql.NullString    `db:"img_uuid" json:"-"`
+	PerfumsCount int64             `db:"-" json:"perfums_count"`
+	Links        []LinkV1          `db:"-" json:"links"`
+	Locales      map[string]string `db:"-" json:"locales,omitempty"`
+	SmallImgUrl  string            `db:"-" json:"small_img_url"`
+	LargeImgUrl  string            `db:"-" json:"large_img_url"`
 }
 
 type NotesV1 struct {
@@ -1429,13 +1756,8 @@ type NotesV1 struct {
 	Amount  int64    `db:"-" json:"amount"`
 }
 
-func NewNotesFactory(version string) Objecter {
-	switch version {
-	case "v1":
-		return &NotesV1{ObjList: make([]NoteV1, 0)}
-	}
-
-	return nil
+func NewNotesFactory(version string) (Objecter, error) {
+	return NewObjecter("notes", version)
 }
 
 func (obj *NotesV1) MakeObj(pParams interface{}) (Objecter, error) {
@@ -1444,6 +1766,9 @@ func (obj *NotesV1) MakeObj(pParams interface{}) (Objecter, error) {
 	}
 
 	params := pParams.(*MakeObjParams)
+	if err := checkStatuses(params.Statuses); err != nil {
+		return nil, err
+	}
 
 	if err := setDbQueryBaseParams(&params.Base, &params.DbQuery); err != nil {
 		return nil, err
@@ -1451,6 +1776,8 @@ func (obj *NotesV1) MakeObj(pParams interface{}) (Objecter, error) {
 
 	if params.Base.Ids.Valid {
 		params.DbQuery.WhereConditionString = addIdsToQuery(params.Base.Ids.String, "notes.uuid")
+	} else {
+		params.DbQuery.WhereConditionString = statusCondition("notes", params.Statuses)
 	}
 
 	query := bytes.NewBufferString("")
@@ -1466,10 +1793,11 @@ func (obj *NotesV1) MakeObj(pParams interface{}) (Objecter, error) {
 	obj.Offset = params.Base.Offset.Int64
 	obj.Amount = int64(len(obj.ObjList))
 
+	if err := fillPerfumsCounts("notes", &obj.ObjList, params.Statuses); err != nil {
+		return nil, err
+	}
+
 	for i := 0; i < len(obj.ObjList); i++ {
-		// obj.ObjList[i].PerfumsCount = params.PerfumsNum
-		perfumsCount, _ := GetPerfumsCount("notes", obj.ObjList[i].Uuid)
-		obj.ObjList[i].PerfumsCount = perfumsCount
 		obj.ObjList[i].Links = []LinkV1{
 			LinkV1{
 				Href:   baseUrl + "/note/" + obj.ObjList[i].Uuid,
@@ -1484,11 +1812,21 @@ func (obj *NotesV1) MakeObj(pParams interface{}) (Objecter, error) {
 		}
 
 		if obj.ObjList[i].ImageId.Valid {
-			obj.ObjList[i].SmallImgUrl = baseUrl + "/image/" + obj.ObjList[i].ImageId.String + "/small"
-			obj.ObjList[i].LargeImgUrl = baseUrl + "/image/" + obj.ObjList[i].ImageId.String + "/large"
+			obj.ObjList[i].SmallImgUrl, obj.ObjList[i].LargeImgUrl = imageUrlPair(obj.ObjList[i].ImageId.String)
 		}
 	}
 
+	l := newLocalizer(params.Locales)
+	for i := range obj.ObjList {
+		l.add(&obj.ObjList[i].Locales, "name", obj.ObjList[i].Uuid, "name", &obj.ObjList[i].Name)
+	}
+	if err := l.resolve(); err != nil {
+		return nil, err
+	}
+	if err := fillStatus("notes", &obj.ObjList); err != nil {
+		return nil, err
+	}
+
 	return obj, nil
 }
 
@@ -1517,7 +1855,10 @@ func (obj *NotesV1) MakeExtraObj(params *MakeObjParams, uids []string) (Objecter
 		return nil, err
 	}
 
-	pinfos := NewPerfumsInfoFactory(params.Base.Version)
+	pinfos, err := NewObjecter("perfums_info", params.Base.Version)
+	if err != nil {
+		return nil, err
+	}
 	return pinfos.MakeObj(params)
 }
 
@@ -1528,6 +1869,11 @@ func (obj *NotesV1) Count(pParams interface{}) (int64, error) {
 
 	dbQuery := QueryTemplateParams{}
 	dbQuery.FromTableName = "notes"
+	statuses, err := paramsStatuses(pParams)
+	if err != nil {
+		return 0, err
+	}
+	dbQuery.WhereConditionString = statusCondition("notes", statuses)
 	query := bytes.NewBufferString("")
 	if err := tmpl.ExecuteTemplate(query, "select_count", &dbQuery); err != nil {
 		return 0, err
@@ -1542,9 +1888,16 @@ func (obj *NotesV1) Count(pParams interface{}) (int64, error) {
 }
 
 func (obj *NotesV1) ExtraCount(uids []string) (int64, error) {
+	return obj.ExtraCountStatuses(uids, nil)
+}
+
+func (obj *NotesV1) ExtraCountStatuses(uids []string, statuses []string) (int64, error) {
 	if len(uids) == 0 {
 		return 0, errors.New("invalid args")
 	}
+	if err := checkStatuses(statuses); err != nil {
+		return 0, err
+	}
 
 	dbQuery := QueryTemplateParams{}
 	dbQuery.FromTableName = "parfums"
@@ -1556,7 +1909,7 @@ func (obj *NotesV1) ExtraCount(uids []string) (int64, error) {
 	if err := tmpl.ExecuteTemplate(query, "condition_select_id_eq_uuid", &dbQuery); err != nil {
 		return 0, err
 	}
-	dbQuery.WhereConditionString = query.String()
+	dbQuery.WhereConditionString = andCondition(query.String(), perfumsStatusCondition(dbQuery.FromTableName, statuses))
 	query.Reset()
 	if err := tmpl.ExecuteTemplate(query, "select_count", &dbQuery); err != nil {
 		return 0, err
@@ -1576,14 +1929,16 @@ func (obj *NotesV1) Json(w http.ResponseWriter, status int) error {
 }
 
 type SeasonV1 struct {
-	Id           int64          `db:"id" json:"-"`
-	Uuid         string         `db:"season_uuid" json:"id"`
-	Name         string         `db:"name" json:"name"`
-	ImageId      sql.NullString `db:"img_uuid" json:"-"`
-	PerfumsCount int64          `db:"-" json:"perfums_count"`
-	Links        []LinkV1       `db:"-" json:"links"`
-	SmallImgUrl  string         `db:"-" json:"small_img_url"`
-	LargeImgUrl  string         `db:"-" json:"large_img_url"`
+	Id           int64             `db:"id" json:"-"`
+	Uuid         string            `db:"season_uuid" json:"id"`
+	Name         string            `db:"name" json:"name"`
+	Status       string            `db:"-" json:"status"`
+	ImageId      sql.NullString    `db:"img_uuid" json:"-"`
+	PerfumsCount int64             `db:"-" json:"perfums_count"`
+	Links        []LinkV1          `db:"-" json:"links"`
+	Locales      map[string]string `db:"-" json:"locales,omitempty"`
+	SmallImgUrl  string            `db:"-" json:"small_img_url"`
+	LargeImgUrl  string            `db:"-" json:"large_img_url"`
 }
 
 type SeasonsV1 struct {
@@ -1593,13 +1948,8 @@ type SeasonsV1 struct {
 	Amount  int64      `db:"-" json:"amount"`
 }
 
-func NewSeasonsFactory(version string) Objecter {
-	switch version {
-	case "v1":
-		return &SeasonsV1{ObjList: make([]SeasonV1, 0)}
-	}
-
-	return nil
+func NewSeasonsFactory(version string) (Objecter, error) {
+	return NewObjecter("seasons", version)
 }
 
 func (obj *SeasonsV1) MakeObj(pParams interface{}) (Objecter, error) {
@@ -1608,6 +1958,9 @@ func (obj *SeasonsV1) MakeObj(pParams interface{}) (Objecter, error) {
 	}
 
 	params := pParams.(*MakeObjParams)
+	if err := checkStatuses(params.Statuses); err != nil {
+		return nil, err
+	}
 
 	if err := setDbQueryBaseParams(&params.Base, &params.DbQuery); err != nil {
 		return nil, err
@@ -1615,6 +1968,8 @@ func (obj *SeasonsV1) MakeObj(pParams interface{}) (Objecter, error) {
 
 	if params.Base.Ids.Valid {
 		params.DbQuery.WhereConditionString = addIdsToQuery(params.Base.Ids.String, "seasons.uuid")
+	} else {
+		params.DbQuery.WhereConditionString = statusCondition("seasons", params.Statuses)
 	}
 
 	query := bytes.NewBufferString("")
@@ -1630,10 +1985,11 @@ func (obj *SeasonsV1) MakeObj(pParams interface{}) (Objecter, error) {
 	obj.Offset = params.Base.Offset.Int64
 	obj.Amount = int64(len(obj.ObjList))
 
+	if err := fillPerfumsCounts("seasons", &obj.ObjList, params.Statuses); err != nil {
+		return nil, err
+	}
+
 	for i := 0; i < len(obj.ObjList); i++ {
-		// obj.ObjList[i].PerfumsCount = params.PerfumsNum
-		perfumsCount, _ := GetPerfumsCount("seasons", obj.ObjList[i].Uuid)
-		obj.ObjList[i].PerfumsCount = perfumsCount
 		obj.ObjList[i].Links = []LinkV1{
 			LinkV1{
 				Href:   baseUrl + "/season/" + obj.ObjList[i].Uuid,
@@ -1648,28 +2004,37 @@ func (obj *SeasonsV1) MakeObj(pParams interface{}) (Objecter, error) {
 		}
 
 		if obj.ObjList[i].ImageId.Valid {
-			obj.ObjList[i].SmallImgUrl = baseUrl + "/image/" + obj.ObjList[i].ImageId.String + "/small"
-			obj.ObjList[i].LargeImgUrl = baseUrl + "/image/" + obj.ObjList[i].ImageId.String + "/large"
+			obj.ObjList[i].SmallImgUrl, obj.ObjList[i].LargeImgUrl = imageUrlPair(obj.ObjList[i].ImageId.String)
 		}
 	}
 
+	l := newLocalizer(params.Locales)
+	for i := range obj.ObjList {
+		l.add(&obj.ObjList[i].Locales, "name", obj.ObjList[i].Uuid, "name", &obj.ObjList[i].Name)
+	}
+	if err := l.resolve(); err != nil {
+		return nil, err
+	}
+	if err := fillStatus("seasons", &obj.ObjList); err != nil {
+		return nil, err
+	}
+
 	return obj, nil
 }
 
+// MakeExtraObj lists the perfums having any of the items uids, or all of
+// them when params.Match is MatchAll.
 func (obj *SeasonsV1) MakeExtraObj(params *MakeObjParams, uids []string) (Objecter, error) {
 	if params == nil || len(uids) == 0 {
 		return nil, errors.New("invalid args")
 	}
 
-	params.DbQuery.ConditionTableField = "season_id"
-	params.DbQuery.ConditionTableName = "seasons"
-	params.DbQuery.ConditionUuid = addIdsToQuery(uids, "seasons.uuid")
-	query := bytes.NewBufferString("")
-	if err := tmpl.ExecuteTemplate(query, "condition_select_id_eq_uuid", &params.DbQuery); err != nil {
+	condition, err := seasonsTaxonomy.condition(uids, params.Match)
+	if err != nil {
 		return nil, err
 	}
-	params.DbQuery.WhereConditionString = query.String()
-	query.Reset()
+	params.DbQuery.WhereConditionString = condition
+	query := bytes.NewBufferString("")
 
 	if err := setDbQueryBaseParams(&params.Base, &params.DbQuery); err != nil {
 		return nil, err
@@ -1683,7 +2048,10 @@ func (obj *SeasonsV1) MakeExtraObj(params *MakeObjParams, uids []string) (Object
 		return nil, err
 	}
 
-	pinfos := NewPerfumsInfoFactory(params.Base.Version)
+	pinfos, err := NewObjecter("perfums_info", params.Base.Version)
+	if err != nil {
+		return nil, err
+	}
 	return pinfos.MakeObj(params)
 }
 
@@ -1694,6 +2062,11 @@ func (obj *SeasonsV1) Count(pParams interface{}) (int64, error) {
 
 	dbQuery := QueryTemplateParams{}
 	dbQuery.FromTableName = "seasons"
+	statuses, err := paramsStatuses(pParams)
+	if err != nil {
+		return 0, err
+	}
+	dbQuery.WhereConditionString = statusCondition("seasons", statuses)
 	query := bytes.NewBufferString("")
 	if err := tmpl.ExecuteTemplate(query, "select_count", &dbQuery); err != nil {
 		return 0, err
@@ -1708,21 +2081,25 @@ func (obj *SeasonsV1) Count(pParams interface{}) (int64, error) {
 }
 
 func (obj *SeasonsV1) ExtraCount(uids []string) (int64, error) {
+	return obj.ExtraCountStatuses(uids, nil)
+}
+
+func (obj *SeasonsV1) ExtraCountStatuses(uids []string, statuses []string) (int64, error) {
 	if len(uids) == 0 {
 		return 0, errors.New("invalid args")
 	}
+	if err := checkStatuses(statuses); err != nil {
+		return 0, err
+	}
 
+	condition, err := seasonsTaxonomy.condition(uids, MatchAny)
+	if err != nil {
+		return 0, err
+	}
 	dbQuery := QueryTemplateParams{}
 	dbQuery.FromTableName = "parfum_info"
-	dbQuery.ConditionTableField = "season_id"
-	dbQuery.ConditionTableName = "seasons"
-	dbQuery.ConditionUuid = addIdsToQuery(uids, "seasons.uuid")
+	dbQuery.WhereConditionString = andCondition(condition, perfumsStatusCondition(dbQuery.FromTableName, statuses))
 	query := bytes.NewBufferString("")
-	if err := tmpl.ExecuteTemplate(query, "condition_select_id_eq_uuid", &dbQuery); err != nil {
-		return 0, err
-	}
-	dbQuery.WhereConditionString = query.String()
-	query.Reset()
 	if err := tmpl.ExecuteTemplate(query, "select_count", &dbQuery); err != nil {
 		return 0, err
 	}
@@ -1741,14 +2118,16 @@ func (obj *SeasonsV1) Json(w http.ResponseWriter, status int) error {
 }
 
 type TimeOfDayV1 struct {
-	Id           int64          `db:"id" json:"-"`
-	Uuid         string         `db:"tsod_uuid" json:"id"`
-	Name         string         `db:"name" json:"name"`
-	ImageId      sql.NullString `db:"img_uuid" json:"-"`
-	PerfumsCount int64          `db:"-" json:"perfums_count"`
-	Links        []LinkV1       `db:"-" json:"links"`
-	SmallImgUrl  string         `db:"-" json:"small_img_url"`
-	LargeImgUrl  string         `db:"-" json:"large_img_url"`
+	Id           int64             `db:"id" json:"-"`
+	Uuid         string            `db:"tsod_uuid" json:"id"`
+	Name         string            `db:"name" json:"name"`
+	Status       string            `db:"-" json:"status"`
+	ImageId      sql.NullString    `db:"img_uuid" json:"-"`
+	PerfumsCount int64             `db:"-" json:"perfums_count"`
+	Links        []LinkV1          `db:"-" json:"links"`
+	Locales      map[string]string `db:"-" json:"locales,omitempty"`
+	SmallImgUrl  string            `db:"-" json:"small_img_url"`
+	LargeImgUrl  string            `db:"-" json:"large_img_url"`
 }
 
 type TimesOfDayV1 struct {
@@ -1758,13 +2137,8 @@ type TimesOfDayV1 struct {
 	Amount  int64         `db:"-" json:"amount"`
 }
 
-func NewTimesOfDayFactory(version string) Objecter {
-	switch version {
-	case "v1":
-		return &TimesOfDayV1{ObjList: make([]TimeOfDayV1, 0)}
-	}
-
-	return nil
+func NewTimesOfDayFactory(version string) (Objecter, error) {
+	return NewObjecter("times_of_day", version)
 }
 
 func (obj *TimesOfDayV1) MakeObj(pParams interface{}) (Objecter, error) {
@@ -1773,6 +2147,9 @@ func (obj *TimesOfDayV1) MakeObj(pParams interface{}) (Objecter, error) {
 	}
 
 	params := pParams.(*MakeObjParams)
+	if err := checkStatuses(params.Statuses); err != nil {
+		return nil, err
+	}
 
 	if err := setDbQueryBaseParams(&params.Base, &params.DbQuery); err != nil {
 		return nil, err
@@ -1780,6 +2157,8 @@ func (obj *TimesOfDayV1) MakeObj(pParams interface{}) (Objecter, error) {
 
 	if params.Base.Ids.Valid {
 		params.DbQuery.WhereConditionString = addIdsToQuery(params.Base.Ids.String, "times_of_day.uuid")
+	} else {
+		params.DbQuery.WhereConditionString = statusCondition("times_of_day", params.Statuses)
 	}
 
 	query := bytes.NewBufferString("")
@@ -1795,10 +2174,11 @@ func (obj *TimesOfDayV1) MakeObj(pParams interface{}) (Objecter, error) {
 	obj.Offset = params.Base.Offset.Int64
 	obj.Amount = int64(len(obj.ObjList))
 
+	if err := fillPerfumsCounts("times_of_day", &obj.ObjList, params.Statuses); err != nil {
+		return nil, err
+	}
+
 	for i := 0; i < len(obj.ObjList); i++ {
-		// obj.ObjList[i].PerfumsCount = params.PerfumsNum
-		perfumsCount, _ := GetPerfumsCount("timesOfDay", obj.ObjList[i].Uuid)
-		obj.ObjList[i].PerfumsCount = perfumsCount
 		obj.ObjList[i].Links = []LinkV1{
 			LinkV1{
 				Href:   baseUrl + "/timeofday/" + obj.ObjList[i].Uuid,
@@ -1813,28 +2193,37 @@ func (obj *TimesOfDayV1) MakeObj(pParams interface{}) (Objecter, error) {
 		}
 
 		if obj.ObjList[i].ImageId.Valid {
-			obj.ObjList[i].SmallImgUrl = baseUrl + "/image/" + obj.ObjList[i].ImageId.String + "/small"
-			obj.ObjList[i].LargeImgUrl = baseUrl + "/image/" + obj.ObjList[i].ImageId.String + "/large"
+			obj.ObjList[i].SmallImgUrl, obj.ObjList[i].LargeImgUrl = imageUrlPair(obj.ObjList[i].ImageId.String)
 		}
 	}
 
+	l := newLocalizer(params.Locales)
+	for i := range obj.ObjList {
+		l.add(&obj.ObjList[i].Locales, "name", obj.ObjList[i].Uuid, "name", &obj.ObjList[i].Name)
+	}
+	if err := l.resolve(); err != nil {
+		return nil, err
+	}
+	if err := fillStatus("times_of_day", &obj.ObjList); err != nil {
+		return nil, err
+	}
+
 	return obj, nil
 }
 
+// MakeExtraObj lists the perfums having any of the items uids, or all of
+// them when params.Match is MatchAll.
 func (obj *TimesOfDayV1) MakeExtraObj(params *MakeObjParams, uids []string) (Objecter, error) {
 	if params == nil || len(uids) == 0 {
 		return nil, errors.New("invalid args")
 	}
 
-	params.DbQuery.ConditionTableField = "tsod_id"
-	params.DbQuery.ConditionTableName = "times_of_day"
-	params.DbQuery.ConditionUuid = addIdsToQuery(uids, "times_of_day.uuid")
-	query := bytes.NewBufferString("")
-	if err := tmpl.ExecuteTemplate(query, "condition_select_id_eq_uuid", &params.DbQuery); err != nil {
+	condition, err := timesOfDayTaxonomy.condition(uids, params.Match)
+	if err != nil {
 		return nil, err
 	}
-	params.DbQuery.WhereConditionString = query.String()
-	query.Reset()
+	params.DbQuery.WhereConditionString = condition
+	query := bytes.NewBufferString("")
 
 	if err := setDbQueryBaseParams(&params.Base, &params.DbQuery); err != nil {
 		return nil, err
@@ -1848,7 +2237,10 @@ func (obj *TimesOfDayV1) MakeExtraObj(params *MakeObjParams, uids []string) (Obj
 		return nil, err
 	}
 
-	pinfos := NewPerfumsInfoFactory(params.Base.Version)
+	pinfos, err := NewObjecter("perfums_info", params.Base.Version)
+	if err != nil {
+		return nil, err
+	}
 	return pinfos.MakeObj(params)
 }
 
@@ -1859,6 +2251,11 @@ func (obj *TimesOfDayV1) Count(pParams interface{}) (int64, error) {
 
 	dbQuery := QueryTemplateParams{}
 	dbQuery.FromTableName = "times_of_day"
+	statuses, err := paramsStatuses(pParams)
+	if err != nil {
+		return 0, err
+	}
+	dbQuery.WhereConditionString = statusCondition("times_of_day", statuses)
 	query := bytes.NewBufferString("")
 	if err := tmpl.ExecuteTemplate(query, "select_count", &dbQuery); err != nil {
 		return 0, err
@@ -1873,21 +2270,25 @@ func (obj *TimesOfDayV1) Count(pParams interface{}) (int64, error) {
 }
 
 func (obj *TimesOfDayV1) ExtraCount(uids []string) (int64, error) {
+	return obj.ExtraCountStatuses(uids, nil)
+}
+
+func (obj *TimesOfDayV1) ExtraCountStatuses(uids []string, statuses []string) (int64, error) {
 	if len(uids) == 0 {
 		return 0, errors.New("invalid args")
 	}
+	if err := checkStatuses(statuses); err != nil {
+		return 0, err
+	}
 
+	condition, err := timesOfDayTaxonomy.condition(uids, MatchAny)
+	if err != nil {
+		return 0, err
+	}
 	dbQuery := QueryTemplateParams{}
 	dbQuery.FromTableName = "parfum_info"
-	dbQuery.ConditionTableField = "tsod_id"
-	dbQuery.ConditionTableName = "times_of_day"
-	dbQuery.ConditionUuid = addIdsToQuery(uids, "times_of_day.uuid")
+	dbQuery.WhereConditionString = andCondition(condition, perfumsStatusCondition(dbQuery.FromTableName, statuses))
 	query := bytes.NewBufferString("")
-	if err := tmpl.ExecuteTemplate(query, "condition_select_id_eq_uuid", &dbQuery); err != nil {
-		return 0, err
-	}
-	dbQuery.WhereConditionString = query.String()
-	query.Reset()
 	if err := tmpl.ExecuteTemplate(query, "select_count", &dbQuery); err != nil {
 		return 0, err
 	}
@@ -1906,14 +2307,16 @@ func (obj *TimesOfDayV1) Json(w http.ResponseWriter, status int) error {
 }
 
 type TypeV1 struct {
-	Id           int64          `db:"id" json:"-"`
-	Uuid         string         `db:"type_uuid" json:"id"`
-	Name         string         `db:"name" json:"name"`
-	ImageId      sql.NullString `db:"img_uuid" json:"-"`
-	PerfumsCount int64          `db:"-" json:"perfums_count"`
-	Links        []LinkV1       `db:"-" json:"links"`
-	SmallImgUrl  string         `db:"-" json:"small_img_url"`
-	LargeImgUrl  string         `db:"-" json:"large_img_url"`
+	Id           int64             `db:"id" json:"-"`
+	Uuid         string            `db:"type_uuid" json:"id"`
+	Name         string            `db:"name" json:"name"`
+	Status       string            `db:"-" json:"status"`
+	ImageId      sql.NullString    `db:"img_uuid" json:"-"`
+	PerfumsCount int64             `db:"-" json:"perfums_count"`
+	Links        []LinkV1          `db:"-" json:"links"`
+	Locales      map[string]string `db:"-" json:"locales,omitempty"`
+	SmallImgUrl  string            `db:"-" json:"small_img_url"`
+	LargeImgUrl  string            `db:"-" json:"large_img_url"`
 }
 
 type TypesV1 struct {
@@ -1923,13 +2326,8 @@ type TypesV1 struct {
 	Amount  int64    `db:"-" json:"amount"`
 }
 
-func NewTypesFactory(version string) Objecter {
-	switch version {
-	case "v1":
-		return &TypesV1{ObjList: make([]TypeV1, 0)}
-	}
-
-	return nil
+func NewTypesFactory(version string) (Objecter, error) {
+	return NewObjecter("types", version)
 }
 
 func (obj *TypesV1) MakeObj(pParams interface{}) (Objecter, error) {
@@ -1938,6 +2336,9 @@ func (obj *TypesV1) MakeObj(pParams interface{}) (Objecter, error) {
 	}
 
 	params := pParams.(*MakeObjParams)
+	if err := checkStatuses(params.Statuses); err != nil {
+		return nil, err
+	}
 
 	if err := setDbQueryBaseParams(&params.Base, &params.DbQuery); err != nil {
 		return nil, err
@@ -1945,6 +2346,8 @@ func (obj *TypesV1) MakeObj(pParams interface{}) (Objecter, error) {
 
 	if params.Base.Ids.Valid {
 		params.DbQuery.WhereConditionString = addIdsToQuery(params.Base.Ids.String, "types.uuid")
+	} else {
+		params.DbQuery.WhereConditionString = statusCondition("types", params.Statuses)
 	}
 
 	query := bytes.NewBufferString("")
@@ -1960,10 +2363,11 @@ func (obj *TypesV1) MakeObj(pParams interface{}) (Objecter, error) {
 	obj.Offset = params.Base.Offset.Int64
 	obj.Amount = int64(len(obj.ObjList))
 
+	if err := fillPerfumsCounts("types", &obj.ObjList, params.Statuses); err != nil {
+		return nil, err
+	}
+
 	for i := 0; i < len(obj.ObjList); i++ {
-		// obj.ObjList[i].PerfumsCount = params.PerfumsNum
-		perfumsCount, _ := GetPerfumsCount("types", obj.ObjList[i].Uuid)
-		obj.ObjList[i].PerfumsCount = perfumsCount
 		obj.ObjList[i].Links = []LinkV1{
 			LinkV1{
 				Href:   baseUrl + "/type/" + obj.ObjList[i].Uuid,
@@ -1978,11 +2382,21 @@ func (obj *TypesV1) MakeObj(pParams interface{}) (Objecter, error) {
 		}
 
 		if obj.ObjList[i].ImageId.Valid {
-			obj.ObjList[i].SmallImgUrl = baseUrl + "/image/" + obj.ObjList[i].ImageId.String + "/small"
-			obj.ObjList[i].LargeImgUrl = baseUrl + "/image/" + obj.ObjList[i].ImageId.String + "/large"
+			obj.ObjList[i].SmallImgUrl, obj.ObjList[i].LargeImgUrl = imageUrlPair(obj.ObjList[i].ImageId.String)
 		}
 	}
 
+	l := newLocalizer(params.Locales)
+	for i := range obj.ObjList {
+		l.add(&obj.ObjList[i].Locales, "name", obj.ObjList[i].Uuid, "name", &obj.ObjList[i].Name)
+	}
+	if err := l.resolve(); err != nil {
+		return nil, err
+	}
+	if err := fillStatus("types", &obj.ObjList); err != nil {
+		return nil, err
+	}
+
 	return obj, nil
 }
 
@@ -2013,7 +2427,10 @@ func (obj *TypesV1) MakeExtraObj(params *MakeObjParams, uids []string) (Objecter
 		return nil, err
 	}
 
-	pinfos := NewPerfumsInfoFactory(params.Base.Version)
+	pinfos, err := NewObjecter("perfums_info", params.Base.Version)
+	if err != nil {
+		return nil, err
+	}
 	return pinfos.MakeObj(params)
 }
 
@@ -2024,6 +2441,11 @@ func (obj *TypesV1) Count(pParams interface{}) (int64, error) {
 
 	dbQuery := QueryTemplateParams{}
 	dbQuery.FromTableName = "types"
+	statuses, err := paramsStatuses(pParams)
+	if err != nil {
+		return 0, err
+	}
+	dbQuery.WhereConditionString = statusCondition("types", statuses)
 	query := bytes.NewBufferString("")
 	if err := tmpl.ExecuteTemplate(query, "select_count", &dbQuery); err != nil {
 		return 0, err
@@ -2038,9 +2460,16 @@ func (obj *TypesV1) Count(pParams interface{}) (int64, error) {
 }
 
 func (obj *TypesV1) ExtraCount(uids []string) (int64, error) {
+	return obj.ExtraCountStatuses(uids, nil)
+}
+
+func (obj *TypesV1) ExtraCountStatuses(uids []string, statuses []string) (int64, error) {
 	if len(uids) == 0 {
 		return 0, errors.New("invalid args")
 	}
+	if err := checkStatuses(statuses); err != nil {
+		return 0, err
+	}
 
 	dbQuery := QueryTemplateParams{}
 	dbQuery.FromTableName = "parfum_info"
@@ -2051,7 +2480,7 @@ func (obj *TypesV1) ExtraCount(uids []string) (int64, error) {
 	if err := tmpl.ExecuteTemplate(query, "condition_select_id_eq_uuid", &dbQuery); err != nil {
 		return 0, err
 	}
-	dbQuery.WhereConditionString = query.String()
+	dbQuery.WhereConditionString = andCondition(query.String(), perfumsStatusCondition(dbQuery.FromTableName, statuses))
 	query.Reset()
 	if err := tmpl.ExecuteTemplate(query, "select_count", &dbQuery); err != nil {
 		return 0, err
@@ -2071,19 +2500,15 @@ func (obj *TypesV1) Json(w http.ResponseWriter, status int) error {
 }
 
 type PerfumsSearchResultV1 struct {
-	Links  []LinkV1 `json:"links"`
-	Total  int64    `json:"total"`
-	Offset int64    `json:"offset"`
-	Amount int64    `json:"amount"`
+	Links   []LinkV1       `json:"links"`
+	Aliases []AliasMatchV1 `json:"aliases,omitempty"`
+	Total   int64          `json:"total"`
+	Offset  int64          `json:"offset"`
+	Amount  int64          `json:"amount"`
 }
 
-func NewPerfumsSearchResultFactory(version string) Objecter {
-	switch version {
-	case "v1":
-		return &PerfumsSearchResultV1{Links: make([]LinkV1, 0)}
-	}
-
-	return nil
+func NewPerfumsSearchResultFactory(version string) (Objecter, error) {
+	return NewObjecter("perfums_search", version)
 }
 
 func (obj *PerfumsSearchResultV1) MakeObj(pParams interface{}) (Objecter, error) {
@@ -2091,25 +2516,30 @@ func (obj *PerfumsSearchResultV1) MakeObj(pParams interface{}) (Objecter, error)
 		return nil, errors.New("invalid args")
 	}
 
-	params := pParams.(*SearchParams)
+	q, err := newSearchQuery(pParams)
+	if err != nil {
+		return nil, err
+	}
 
 	search := NewSearchQueryTemplateParams()
-	if err := search.ParseSearchParams(params); err != nil {
+	if err := search.ParseSearchParams(q.SearchParams); err != nil {
 		return nil, err
 	}
-	search.Order = "perfum_info.info_uuid"
-	query := bytes.NewBufferString("")
-	if err := tmpl.ExecuteTemplate(query, "perfum_search", &search); err != nil {
+	aliases, err := resolveSearchAliases(&search)
+	if err != nil {
 		return nil, err
 	}
+	obj.Aliases = aliases
 
-	var results []string
-	if _, err = dbmap.Select(&results, query.String()); err != nil {
+	args := bindArgs{}
+	condition := perfumSearchCondition(&args, &search, q)
+	results, err := searchUuids("parfum_info", condition, args, &q.Base)
+	if err != nil {
 		return nil, err
 	}
 
-	obj.Total = params.Total
-	obj.Offset = params.Base.Offset.Int64
+	obj.Total = q.Total
+	obj.Offset = q.Base.Offset.Int64
 	obj.Amount = int64(len(results))
 
 	for _, result := range results {
@@ -2134,24 +2564,22 @@ func (obj *PerfumsSearchResultV1) Count(pParams interface{}) (int64, error) {
 		return 0, errors.New("invalid args")
 	}
 
-	params := pParams.(*SearchParams)
-
-	search := NewSearchQueryTemplateParams()
-	if err := search.ParseSearchParams(params); err != nil {
+	q, err := newSearchQuery(pParams)
+	if err != nil {
 		return 0, err
 	}
 
-	query := bytes.NewBufferString("")
-	if err := tmpl.ExecuteTemplate(query, "perfum_search_count", &search); err != nil {
+	search := NewSearchQueryTemplateParams()
+	if err := search.ParseSearchParams(q.SearchParams); err != nil {
 		return 0, err
 	}
-
-	count, err := dbmap.SelectInt(query.String())
-	if err != nil {
+	if _, err := resolveSearchAliases(&search); err != nil {
 		return 0, err
 	}
 
-	return count, nil
+	args := bindArgs{}
+	condition := perfumSearchCondition(&args, &search, q)
+	return searchCount("parfum_info", condition, args)
 }
 
 func (obj *PerfumsSearchResultV1) ExtraCount(uids []string) (int64, error) {
@@ -2190,29 +2618,25 @@ type UserResp struct {
 
 // IdTokenClaims ...
 type IdTokenClaims struct {
-	Iss    string  `json:"iss"`
-	Sub    string  `json:"sub"`
-	Aud    string  `json:"aud"`
-	Iat    float64 `json:"iat"`
-	Exp    float64 `json:"exp"`
-	UserId string  `json:"user_id"`
+	Iss    string   `json:"iss"`
+	Sub    string   `json:"sub"`
+	Aud    Audience `json:"aud"`
+	Iat    float64  `json:"iat"`
+	Exp    float64  `json:"exp"`
+	UserId string   `json:"user_id"`
 }
 
 //BrandsSearchResultV1
 type BrandsSearchResultV1 struct {
-	ObjList []BrandV1 `db:"-" json:"brands_list"`
-	Total   int64     `json:"total"`
-	Offset  int64     `json:"offset"`
-	Amount  int64     `json:"amount"`
+	ObjList []BrandV1      `db:"-" json:"brands_list"`
+	Aliases []AliasMatchV1 `db:"-" json:"aliases,omitempty"`
+	Total   int64          `json:"total"`
+	Offset  int64          `json:"offset"`
+	Amount  int64          `json:"amount"`
 }
 
-func NewBrandsSearchResultFactory(version string) Objecter {
-	switch version {
-	case "v1":
-		return &BrandsSearchResultV1{ObjList: make([]BrandV1, 0)}
-	}
-
-	return nil
+func NewBrandsSearchResultFactory(version string) (Objecter, error) {
+	return NewObjecter("brands_search", version)
 }
 
 func (obj *BrandsSearchResultV1) MakeObj(pParams interface{}) (Objecter, error) {
@@ -2220,55 +2644,45 @@ func (obj *BrandsSearchResultV1) MakeObj(pParams interface{}) (Objecter, error)
 		return nil, errors.New("invalid args")
 	}
 
-	params := pParams.(*SearchParams)
+	q, err := newSearchQuery(pParams)
+	if err != nil {
+		return nil, err
+	}
 
 	search := NewSearchQueryTemplateParams()
-	if err := search.ParseSearchParams(params); err != nil {
+	if err := search.ParseSearchParams(q.SearchParams); err != nil {
 		return nil, err
 	}
+	aliases, err := resolveSearchAliases(&search)
+	if err != nil {
+		return nil, err
+	}
+	obj.Aliases = aliases
 	if search.BrandUid == "" && search.Brand == "" {
 		// return empty object
 		return obj, nil
 	}
-	search.Order = "brands." + search.BrandsName
-	query := bytes.NewBufferString("")
-	if err := tmpl.ExecuteTemplate(query, "brands_search", &search); err != nil {
+
+	args := bindArgs{}
+	condition := searchCondition(&args, "brands", search.BrandUid, search.Brand, q)
+	uuids, err := searchUuids("brands", condition, args, &q.Base)
+	if err != nil {
 		return nil, err
 	}
-
-	fmt.Println("================================= Query begin =======================================")
-	fmt.Println(query.String())
-	fmt.Println("================================= Query end =========================================")
-
-	if _, err = dbmap.Select(&obj.ObjList, query.String()); err != nil {
+	items, err := searchedItems(&BrandsV1{}, uuids, q)
+	if err != nil {
 		return nil, err
 	}
-
-	obj.Total = params.Total
-	obj.Offset = params.Base.Offset.Int64
-	obj.Amount = int64(len(obj.ObjList))
-
-	for i := 0; i < len(obj.ObjList); i++ {
-		obj.ObjList[i].PerfumsCount, _ = GetPerfumsCount("brands", obj.ObjList[i].Uuid)
-		obj.ObjList[i].Links = []LinkV1{
-			LinkV1{
-				Href:   baseUrl + "/brand/" + obj.ObjList[i].Uuid,
-				Rel:    "BrandInfo",
-				Method: "GET",
-			},
-			LinkV1{
-				Href:   baseUrl + "/brand/" + obj.ObjList[i].Uuid + "/perfums",
-				Rel:    "BrandPerfums",
-				Method: "GET",
-			},
-		}
-
-		if obj.ObjList[i].ImageId.Valid {
-			obj.ObjList[i].SmallImgUrl = baseUrl + "/image/" + obj.ObjList[i].ImageId.String + "/small"
-			obj.ObjList[i].LargeImgUrl = baseUrl + "/image/" + obj.ObjList[i].ImageId.String + "/large"
+	for _, uuid := range uuids {
+		if item, ok := items[uuid]; ok {
+			obj.ObjList = append(obj.ObjList, item.(BrandV1))
 		}
 	}
 
+	obj.Total = q.Total
+	obj.Offset = q.Base.Offset.Int64
+	obj.Amount = int64(len(obj.ObjList))
+
 	return obj, nil
 }
 
@@ -2281,24 +2695,25 @@ func (obj *BrandsSearchResultV1) Count(pParams interface{}) (int64, error) {
 		return 0, errors.New("invalid args")
 	}
 
-	params := pParams.(*SearchParams)
-
-	search := NewSearchQueryTemplateParams()
-	if err := search.ParseSearchParams(params); err != nil {
+	q, err := newSearchQuery(pParams)
+	if err != nil {
 		return 0, err
 	}
 
-	query := bytes.NewBufferString("")
-	if err := tmpl.ExecuteTemplate(query, "brands_search_count", &search); err != nil {
+	search := NewSearchQueryTemplateParams()
+	if err := search.ParseSearchParams(q.SearchParams); err != nil {
 		return 0, err
 	}
-
-	count, err := dbmap.SelectInt(query.String())
-	if err != nil {
+	if _, err := resolveSearchAliases(&search); err != nil {
 		return 0, err
 	}
+	if search.BrandUid == "" && search.Brand == "" {
+		return 0, nil
+	}
 
-	return count, nil
+	args := bindArgs{}
+	condition := searchCondition(&args, "brands", search.BrandUid, search.Brand, q)
+	return searchCount("brands", condition, args)
 }
 
 func (obj *BrandsSearchResultV1) ExtraCount(uids []string) (int64, error) {
@@ -2312,19 +2727,15 @@ func (obj *BrandsSearchResultV1) Json(w http.ResponseWriter, status int) error {
 
 //ComponentsSearchResultV1
 type ComponentsSearchResultV1 struct {
-	ObjList []ComponentV1 `db:"-" json:"components"`
-	Total   int64         `db:"-" json:"total"`
-	Offset  int64         `db:"-" json:"offset"`
-	Amount  int64         `db:"-" json:"amount"`
+	ObjList []ComponentV1  `db:"-" json:"components"`
+	Aliases []AliasMatchV1 `db:"-" json:"aliases,omitempty"`
+	Total   int64          `db:"-" json:"total"`
+	Offset  int64          `db:"-" json:"offset"`
+	Amount  int64          `db:"-" json:"amount"`
 }
 
-func NewComponentsSearchResultFactory(version string) Objecter {
-	switch version {
-	case "v1":
-		return &ComponentsSearchResultV1{ObjList: make([]ComponentV1, 0)}
-	}
-
-	return nil
+func NewComponentsSearchResultFactory(version string) (Objecter, error) {
+	return NewObjecter("components_search", version)
 }
 
 func (obj *ComponentsSearchResultV1) MakeObj(pParams interface{}) (Objecter, error) {
@@ -2332,50 +2743,45 @@ func (obj *ComponentsSearchResultV1) MakeObj(pParams interface{}) (Objecter, err
 		return nil, errors.New("invalid args")
 	}
 
-	params := pParams.(*SearchParams)
+	q, err := newSearchQuery(pParams)
+	if err != nil {
+		return nil, err
+	}
 
 	search := NewSearchQueryTemplateParams()
-	if err := search.ParseSearchParams(params); err != nil {
+	if err := search.ParseSearchParams(q.SearchParams); err != nil {
+		return nil, err
+	}
+	aliases, err := resolveSearchAliases(&search)
+	if err != nil {
 		return nil, err
 	}
+	obj.Aliases = aliases
 	if search.ComponentUid == "" && search.Component == "" {
 		// return empty object
 		return obj, nil
 	}
-	search.Order = "components." + search.ComponentsName
-	query := bytes.NewBufferString("")
-	if err := tmpl.ExecuteTemplate(query, "components_search", &search); err != nil {
+
+	args := bindArgs{}
+	condition := searchCondition(&args, "components", search.ComponentUid, search.Component, q)
+	uuids, err := searchUuids("components", condition, args, &q.Base)
+	if err != nil {
 		return nil, err
 	}
-	if _, err = dbmap.Select(&obj.ObjList, query.String()); err != nil {
+	items, err := searchedItems(&ComponentsV1{}, uuids, q)
+	if err != nil {
 		return nil, err
 	}
-
-	obj.Total = params.Total
-	obj.Offset = params.Base.Offset.Int64
-	obj.Amount = int64(len(obj.ObjList))
-
-	for i := 0; i < len(obj.ObjList); i++ {
-		obj.ObjList[i].PerfumsCount, _ = GetPerfumsCount("components", obj.ObjList[i].Uuid)
-		obj.ObjList[i].Links = []LinkV1{
-			LinkV1{
-				Href:   baseUrl + "/component/" + obj.ObjList[i].Uuid,
-				Rel:    "ComponentInfo",
-				Method: "GET",
-			},
-			LinkV1{
-				Href:   baseUrl + "/component/" + obj.ObjList[i].Uuid + "/perfums",
-				Rel:    "ComponentPerfums",
-				Method: "GET",
-			},
-		}
-
-		if obj.ObjList[i].ImageId.Valid {
-			obj.ObjList[i].SmallImgUrl = baseUrl + "/image/" + obj.ObjList[i].ImageId.String + "/small"
-			obj.ObjList[i].LargeImgUrl = baseUrl + "/image/" + obj.ObjList[i].ImageId.String + "/large"
+	for _, uuid := range uuids {
+		if item, ok := items[uuid]; ok {
+			obj.ObjList = append(obj.ObjList, item.(ComponentV1))
 		}
 	}
 
+	obj.Total = q.Total
+	obj.Offset = q.Base.Offset.Int64
+	obj.Amount = int64(len(obj.ObjList))
+
 	return obj, nil
 }
 
@@ -2388,24 +2794,25 @@ func (obj *ComponentsSearchResultV1) Count(pParams interface{}) (int64, error) {
 		return 0, errors.New("invalid args")
 	}
 
-	params := pParams.(*SearchParams)
-
-	search := NewSearchQueryTemplateParams()
-	if err := search.ParseSearchParams(params); err != nil {
+	q, err := newSearchQuery(pParams)
+	if err != nil {
 		return 0, err
 	}
 
-	query := bytes.NewBufferString("")
-	if err := tmpl.ExecuteTemplate(query, "components_search_count", &search); err != nil {
+	search := NewSearchQueryTemplateParams()
+	if err := search.ParseSearchParams(q.SearchParams); err != nil {
 		return 0, err
 	}
-
-	count, err := dbmap.SelectInt(query.String())
-	if err != nil {
+	if _, err := resolveSearchAliases(&search); err != nil {
 		return 0, err
 	}
+	if search.ComponentUid == "" && search.Component == "" {
+		return 0, nil
+	}
 
-	return count, nil
+	args := bindArgs{}
+	condition := searchCondition(&args, "components", search.ComponentUid, search.Component, q)
+	return searchCount("components", condition, args)
 }
 
 func (obj *ComponentsSearchResultV1) ExtraCount(uids []string) (int64, error) {
@@ -2425,13 +2832,8 @@ type CountriesSearchResultV1 struct {
 	Amount  int64       `db:"-" json:"amount"`
 }
 
-func NewCountriesSearchResultFactory(version string) Objecter {
-	switch version {
-	case "v1":
-		return &CountriesSearchResultV1{ObjList: make([]CountryV1, 0)}
-	}
-
-	return nil
+func NewCountriesSearchResultFactory(version string) (Objecter, error) {
+	return NewObjecter("countries_search", version)
 }
 
 func (obj *CountriesSearchResultV1) MakeObj(pParams interface{}) (Objecter, error) {
@@ -2439,50 +2841,40 @@ func (obj *CountriesSearchResultV1) MakeObj(pParams interface{}) (Objecter, erro
 		return nil, errors.New("invalid args")
 	}
 
-	params := pParams.(*SearchParams)
+	q, err := newSearchQuery(pParams)
+	if err != nil {
+		return nil, err
+	}
 
 	search := NewSearchQueryTemplateParams()
-	if err := search.ParseSearchParams(params); err != nil {
+	if err := search.ParseSearchParams(q.SearchParams); err != nil {
 		return nil, err
 	}
 	if search.CountryUid == "" && search.Country == "" {
 		// return empty object
 		return obj, nil
 	}
-	search.Order = "countries." + search.CountriesName
-	query := bytes.NewBufferString("")
-	if err := tmpl.ExecuteTemplate(query, "countries_search", &search); err != nil {
+
+	args := bindArgs{}
+	condition := searchCondition(&args, "countries", search.CountryUid, search.Country, q)
+	uuids, err := searchUuids("countries", condition, args, &q.Base)
+	if err != nil {
 		return nil, err
 	}
-	if _, err = dbmap.Select(&obj.ObjList, query.String()); err != nil {
+	items, err := searchedItems(&CountriesV1{}, uuids, q)
+	if err != nil {
 		return nil, err
 	}
-
-	obj.Total = params.Total
-	obj.Offset = params.Base.Offset.Int64
-	obj.Amount = int64(len(obj.ObjList))
-
-	for i := 0; i < len(obj.ObjList); i++ {
-		obj.ObjList[i].PerfumsCount, _ = GetPerfumsCount("countries", obj.ObjList[i].Uuid)
-		obj.ObjList[i].Links = []LinkV1{
-			LinkV1{
-				Href:   baseUrl + "/country/" + obj.ObjList[i].Uuid,
-				Rel:    "CountryInfo",
-				Method: "GET",
-			},
-			LinkV1{
-				Href:   baseUrl + "/country/" + obj.ObjList[i].Uuid + "/perfums",
-				Rel:    "CountryPerfums",
-				Method: "GET",
-			},
-		}
-
-		if obj.ObjList[i].ImageId.Valid {
-			obj.ObjList[i].SmallImgUrl = baseUrl + "/image/" + obj.ObjList[i].ImageId.String + "/small"
-			obj.ObjList[i].LargeImgUrl = baseUrl + "/image/" + obj.ObjList[i].ImageId.String + "/large"
+	for _, uuid := range uuids {
+		if item, ok := items[uuid]; ok {
+			obj.ObjList = append(obj.ObjList, item.(CountryV1))
 		}
 	}
 
+	obj.Total = q.Total
+	obj.Offset = q.Base.Offset.Int64
+	obj.Amount = int64(len(obj.ObjList))
+
 	return obj, nil
 }
 
@@ -2495,24 +2887,22 @@ func (obj *CountriesSearchResultV1) Count(pParams interface{}) (int64, error) {
 		return 0, errors.New("invalid args")
 	}
 
-	params := pParams.(*SearchParams)
-
-	search := NewSearchQueryTemplateParams()
-	if err := search.ParseSearchParams(params); err != nil {
+	q, err := newSearchQuery(pParams)
+	if err != nil {
 		return 0, err
 	}
 
-	query := bytes.NewBufferString("")
-	if err := tmpl.ExecuteTemplate(query, "countries_search_count", &search); err != nil {
+	search := NewSearchQueryTemplateParams()
+	if err := search.ParseSearchParams(q.SearchParams); err != nil {
 		return 0, err
 	}
-
-	count, err := dbmap.SelectInt(query.String())
-	if err != nil {
-		return 0, err
+	if search.CountryUid == "" && search.Country == "" {
+		return 0, nil
 	}
 
-	return count, nil
+	args := bindArgs{}
+	condition := searchCondition(&args, "countries", search.CountryUid, search.Country, q)
+	return searchCount("countries", condition, args)
 }
 
 func (obj *CountriesSearchResultV1) ExtraCount(uids []string) (int64, error) {
@@ -2532,13 +2922,8 @@ type GroupsSearchResultV1 struct {
 	Amount  int64     `db:"-" json:"amount"`
 }
 
-func NewGroupsSearchResultFactory(version string) Objecter {
-	switch version {
-	case "v1":
-		return &GroupsSearchResultV1{ObjList: make([]GroupV1, 0)}
-	}
-
-	return nil
+func NewGroupsSearchResultFactory(version string) (Objecter, error) {
+	return NewObjecter("groups_search", version)
 }
 
 func (obj *GroupsSearchResultV1) MakeObj(pParams interface{}) (Objecter, error) {
@@ -2546,50 +2931,40 @@ func (obj *GroupsSearchResultV1) MakeObj(pParams interface{}) (Objecter, error)
 		return nil, errors.New("invalid args")
 	}
 
-	params := pParams.(*SearchParams)
+	q, err := newSearchQuery(pParams)
+	if err != nil {
+		return nil, err
+	}
 
 	search := NewSearchQueryTemplateParams()
-	if err := search.ParseSearchParams(params); err != nil {
+	if err := search.ParseSearchParams(q.SearchParams); err != nil {
 		return nil, err
 	}
 	if search.GroupUid == "" && search.Group == "" {
 		// return empty object
 		return obj, nil
 	}
-	search.Order = "groups." + search.GroupsName
-	query := bytes.NewBufferString("")
-	if err := tmpl.ExecuteTemplate(query, "groups_search", &search); err != nil {
+
+	args := bindArgs{}
+	condition := searchCondition(&args, "groups", search.GroupUid, search.Group, q)
+	uuids, err := searchUuids("groups", condition, args, &q.Base)
+	if err != nil {
 		return nil, err
 	}
-	if _, err = dbmap.Select(&obj.ObjList, query.String()); err != nil {
+	items, err := searchedItems(&GroupsV1{}, uuids, q)
+	if err != nil {
 		return nil, err
 	}
-
-	obj.Total = params.Total
-	obj.Offset = params.Base.Offset.Int64
-	obj.Amount = int64(len(obj.ObjList))
-
-	for i := 0; i < len(obj.ObjList); i++ {
-		obj.ObjList[i].PerfumsCount, _ = GetPerfumsCount("groups", obj.ObjList[i].Uuid)
-		obj.ObjList[i].Links = []LinkV1{
-			LinkV1{
-				Href:   baseUrl + "/group/" + obj.ObjList[i].Uuid,
-				Rel:    "GroupInfo",
-				Method: "GET",
-			},
-			LinkV1{
-				Href:   baseUrl + "/group/" + obj.ObjList[i].Uuid + "/perfums",
-				Rel:    "GroupPerfums",
-				Method: "GET",
-			},
-		}
-
-		if obj.ObjList[i].ImageId.Valid {
-			obj.ObjList[i].SmallImgUrl = baseUrl + "/image/" + obj.ObjList[i].ImageId.String + "/small"
-			obj.ObjList[i].LargeImgUrl = baseUrl + "/image/" + obj.ObjList[i].ImageId.String + "/large"
+	for _, uuid := range uuids {
+		if item, ok := items[uuid]; ok {
+			obj.ObjList = append(obj.ObjList, item.(GroupV1))
 		}
 	}
 
+	obj.Total = q.Total
+	obj.Offset = q.Base.Offset.Int64
+	obj.Amount = int64(len(obj.ObjList))
+
 	return obj, nil
 }
 
@@ -2602,31 +2977,142 @@ func (obj *GroupsSearchResultV1) Count(pParams interface{}) (int64, error) {
 		return 0, errors.New("invalid args")
 	}
 
-	params := pParams.(*SearchParams)
+	q, err := newSearchQuery(pParams)
+	if err != nil {
+		return 0, err
+	}
 
 	search := NewSearchQueryTemplateParams()
-	if err := search.ParseSearchParams(params); err != nil {
+	if err := search.ParseSearchParams(q.SearchParams); err != nil {
 		return 0, err
 	}
+	if search.GroupUid == "" && search.Group == "" {
+		return 0, nil
+	}
 
-	query := bytes.NewBufferString("")
-	if err := tmpl.ExecuteTemplate(query, "groups_search_count", &search); err != nil {
-		return 0, err
+	args := bindArgs{}
+	condition := searchCondition(&args, "groups", search.GroupUid, search.Group, q)
+	return searchCount("groups", condition, args)
+}
+
+func (obj *GroupsSearchResultV1) ExtraCount(uids []string) (int64, error) {
+	return 0, nil
+}
+
+func (obj *GroupsSearchResultV1) Json(w http.ResponseWriter, status int) error {
+	render := render.New()
+	return render.JSON(w, status, obj)
+}
+
+// NoteSearchParams matches Query against the names of notes, stored or
+// translated into Locales, or names a note by one of its aliases. It finds
+// the notes in one of Statuses (active only by default).
+type NoteSearchParams struct {
+	Base     BaseParams
+	Total    int64
+	Query    string
+	Locales  []string
+	Statuses []string
+}
+
+// NotesSearchResultV1
+type NotesSearchResultV1 struct {
+	ObjList []NoteV1       `db:"-" json:"notes_list"`
+	Aliases []AliasMatchV1 `db:"-" json:"aliases,omitempty"`
+	Total   int64          `db:"-" json:"total"`
+	Offset  int64          `db:"-" json:"offset"`
+	Amount  int64          `db:"-" json:"amount"`
+}
+
+func NewNotesSearchResultFactory(version string) (Objecter, error) {
+	return NewObjecter("notes_search", version)
+}
+
+func (obj *NotesSearchResultV1) MakeObj(pParams interface{}) (Objecter, error) {
+	if pParams == nil {
+		return nil, errors.New("invalid args")
 	}
 
-	count, err := dbmap.SelectInt(query.String())
+	params := pParams.(*NoteSearchParams)
+	if params.Query == "" {
+		// return empty object
+		return obj, nil
+	}
+
+	q, condition, args, match, err := newNoteSearchQuery(params)
 	if err != nil {
-		return 0, err
+		return nil, err
+	}
+	if match != nil {
+		obj.Aliases = []AliasMatchV1{*match}
+	}
+	uuids, err := searchUuids("notes", condition, args, &params.Base)
+	if err != nil {
+		return nil, err
+	}
+	items, err := searchedItems(&NotesV1{}, uuids, q)
+	if err != nil {
+		return nil, err
+	}
+	for _, uuid := range uuids {
+		if item, ok := items[uuid]; ok {
+			obj.ObjList = append(obj.ObjList, item.(NoteV1))
+		}
 	}
 
-	return count, nil
+	obj.Total = params.Total
+	obj.Offset = params.Base.Offset.Int64
+	obj.Amount = int64(len(obj.ObjList))
+
+	return obj, nil
 }
 
-func (obj *GroupsSearchResultV1) ExtraCount(uids []string) (int64, error) {
+// newNoteSearchQuery matches the note params names by an alias, when it is
+// one, or by its names otherwise, and returns the alias matched.
+func newNoteSearchQuery(params *NoteSearchParams) (*searchQuery, string, bindArgs, *AliasMatchV1, error) {
+	if err := checkStatuses(params.Statuses); err != nil {
+		return nil, "", nil, nil, err
+	}
+	match, err := searchAlias("note", params.Query)
+	if err != nil {
+		return nil, "", nil, nil, err
+	}
+	uid := ""
+	if match != nil {
+		uid = match.EntityUuid
+	}
+	q := localizedSearchQuery(&SearchParams{Base: params.Base, Total: params.Total}, params.Locales)
+	q.statuses = params.Statuses
+	args := bindArgs{}
+	return q, searchCondition(&args, "notes", uid, params.Query, q), args, match, nil
+}
+
+func (obj *NotesSearchResultV1) MakeExtraObj(params *MakeObjParams, uids []string) (Objecter, error) {
+	return obj, nil
+}
+
+func (obj *NotesSearchResultV1) Count(pParams interface{}) (int64, error) {
+	if pParams == nil {
+		return 0, errors.New("invalid args")
+	}
+
+	params := pParams.(*NoteSearchParams)
+	if params.Query == "" {
+		return 0, nil
+	}
+
+	_, condition, args, _, err := newNoteSearchQuery(params)
+	if err != nil {
+		return 0, err
+	}
+	return searchCount("notes", condition, args)
+}
+
+func (obj *NotesSearchResultV1) ExtraCount(uids []string) (int64, error) {
 	return 0, nil
 }
 
-func (obj *GroupsSearchResultV1) Json(w http.ResponseWriter, status int) error {
+func (obj *NotesSearchResultV1) Json(w http.ResponseWriter, status int) error {
 	render := render.New()
 	return render.JSON(w, status, obj)
 }
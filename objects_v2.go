@@ -0,0 +1,370 @@
+package objects
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/unrolled/render"
+	"net/http"
+)
+
+// TaxonomyRefV2 is a taxonomy item nested into a v2 object in place of the
+// flat <kind>_id/<kind>_name pair of v1.
+type TaxonomyRefV2 struct {
+	Id    string   `json:"id"`
+	Name  string   `json:"name"`
+	Links []LinkV1 `json:"links"`
+}
+
+// newTaxonomyRefV2 builds the reference with the same links the v1 list of
+// kind emits for the item, e.g. /brand/{id} BrandInfo and /brand/{id}/perfums
+// BrandPerfums. There is no reference without an item: it is nil for an
+// empty uuid.
+func newTaxonomyRefV2(kind, rel, uuid, name string) *TaxonomyRefV2 {
+	if uuid == "" {
+		return nil
+	}
+	return &TaxonomyRefV2{
+		Id:   uuid,
+		Name: name,
+		Links: []LinkV1{
+			LinkV1{
+				Href:   baseUrl + "/" + kind + "/" + uuid,
+				Rel:    rel + "Info",
+				Method: "GET",
+			},
+			LinkV1{
+				Href:   baseUrl + "/" + kind + "/" + uuid + "/perfums",
+				Rel:    rel + "Perfums",
+				Method: "GET",
+			},
+		},
+	}
+}
+
+func taxonomyRefsV2(kind, rel string, items []TaxonomyItemRefV1) []TaxonomyRefV2 {
+	refs := make([]TaxonomyRefV2, 0, len(items))
+	for _, item := range items {
+		if ref := newTaxonomyRefV2(kind, rel, item.Uuid, item.Name); ref != nil {
+			refs = append(refs, *ref)
+		}
+	}
+	return refs
+}
+
+// PerfumInfoV2 ...
+type PerfumInfoV2 struct {
+	Uuid            string            `json:"id"`
+	Name            string            `json:"name"`
+	Status          string            `json:"status"`
+	DescriptionUuid string            `json:"description_id"`
+	Description     string            `json:"description"`
+	Year            int64             `json:"year"`
+	Brand           *TaxonomyRefV2    `json:"brand,omitempty"`
+	Gender          *TaxonomyRefV2    `json:"gender,omitempty"`
+	Group           *TaxonomyRefV2    `json:"group,omitempty"`
+	Country         *TaxonomyRefV2    `json:"country,omitempty"`
+	Season          *TaxonomyRefV2    `json:"season,omitempty"`
+	TimeOfDay       *TaxonomyRefV2    `json:"timeofday,omitempty"`
+	Seasons         []TaxonomyRefV2   `json:"seasons"`
+	TimesOfDay      []TaxonomyRefV2   `json:"times_of_day"`
+	Tags            []TaxonomyRefV2   `json:"tags"`
+	Type            *TaxonomyRefV2    `json:"type,omitempty"`
+	StarsUuid       *string           `json:"stars_id"`
+	StarsAverage    float64           `json:"stars_average"`
+	StarsCount      int64             `json:"stars_count"`
+	ShopUuid        *string           `json:"shop_id"`
+	ParentUuid      *string           `json:"parent_id"`
+	FamilyRelation  string            `json:"family_relation"`
+	Perfumers       []PerfumerRefV1   `json:"perfumers"`
+	Locales         map[string]string `json:"locales,omitempty"`
+	Links           []LinkV1          `json:"links"`
+	Image           *ImageRefV2       `json:"image"`
+}
+
+// NewPerfumInfoV2 reshapes info. A deleted perfum is a tombstone, as in v1:
+// only its id, status and link to itself are kept.
+func NewPerfumInfoV2(info *PerfumInfoV1, image *ImageRefV2) *PerfumInfoV2 {
+	if info.Status == StatusDeleted {
+		return &PerfumInfoV2{Uuid: info.Uuid, Status: info.Status, Links: info.Links}
+	}
+	obj := &PerfumInfoV2{
+		Uuid:            info.Uuid,
+		Name:            info.Name,
+		Status:          info.Status,
+		DescriptionUuid: info.DescriptionUuid,
+		Description:     info.Description,
+		Year:            info.Year,
+		Brand:           newTaxonomyRefV2("brand", "Brand", info.BrandUuid, info.BrandName),
+		Gender:          newTaxonomyRefV2("gender", "Gender", info.GenderUuid, info.GenderName),
+		Group:           newTaxonomyRefV2("group", "Group", info.GroupUuid, info.GroupName),
+		Country:         newTaxonomyRefV2("country", "Country", info.CountryUuid, info.CountryName),
+		Season:          newTaxonomyRefV2("season", "Season", info.SeasonUuid, info.SeasonName),
+		TimeOfDay:       newTaxonomyRefV2("timeofday", "Timeofday", info.TsodUuid, info.TsodName),
+		Type:            newTaxonomyRefV2("type", "Type", info.TypeUuid, info.TypeName),
+		StarsAverage:    info.StarsAverage,
+		StarsCount:      info.StarsCount,
+		Locales:         info.Locales,
+		Links:           info.Links,
+		Image:           image,
+	}
+	if info.StarsUuid.Valid {
+		obj.StarsUuid = &info.StarsUuid.String
+	}
+	if info.ShopUuid.Valid {
+		obj.ShopUuid = &info.ShopUuid.String
+	}
+	if info.ParentUuid.Valid {
+		obj.ParentUuid = &info.ParentUuid.String
+	}
+	obj.FamilyRelation = info.FamilyRelation
+	obj.Perfumers = info.Perfumers
+	obj.Seasons = taxonomyRefsV2("season", "Season", info.Seasons)
+	obj.TimesOfDay = taxonomyRefsV2("timeofday", "Timeofday", info.TimesOfDay)
+	obj.Tags = taxonomyRefsV2("tag", "Tag", info.Tags)
+
+	return obj
+}
+
+// PerfumsInfoV2 is served from the same queries as PerfumsInfoV1, only the
+// shape of the items differs.
+type PerfumsInfoV2 struct {
+	ObjList []PerfumInfoV2 `db:"-" json:"perfums_info_list"`
+	Total   int64          `db:"-" json:"total"`
+	Offset  int64          `db:"-" json:"offset"`
+	Amount  int64          `db:"-" json:"amount"`
+}
+
+func (obj *PerfumsInfoV2) MakeObj(pParams interface{}) (Objecter, error) {
+	if pParams == nil {
+		return nil, errors.New("invalid args")
+	}
+
+	infos := &PerfumsInfoV1{ObjList: make([]PerfumInfoV1, 0)}
+	if _, err := infos.MakeObj(pParams); err != nil {
+		return nil, err
+	}
+
+	imageUuids := []string{}
+	for _, info := range infos.ObjList {
+		if info.ImgUuid.Valid {
+			imageUuids = appendUnique(imageUuids, info.ImgUuid.String)
+		}
+	}
+	images, err := imageRefsV2(imageUuids)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range infos.ObjList {
+		obj.ObjList = append(obj.ObjList, *NewPerfumInfoV2(&infos.ObjList[i], images[infos.ObjList[i].ImgUuid.String]))
+	}
+	obj.Total = infos.Total
+	obj.Offset = infos.Offset
+	obj.Amount = infos.Amount
+
+	return obj, nil
+}
+
+func (obj *PerfumsInfoV2) MakeExtraObj(params *MakeObjParams, uids []string) (Objecter, error) {
+	if params == nil || len(uids) == 0 {
+		return nil, errors.New("invalid args")
+	}
+
+	// compositions have no v2 shape, they are served as in v1
+	params.Base.Version = "v1"
+	infos := &PerfumsInfoV1{ObjList: make([]PerfumInfoV1, 0)}
+	return infos.MakeExtraObj(params, uids)
+}
+
+func (obj *PerfumsInfoV2) Count(pParams interface{}) (int64, error) {
+	infos := &PerfumsInfoV1{}
+	return infos.Count(pParams)
+}
+
+func (obj *PerfumsInfoV2) ExtraCount(uids []string) (int64, error) {
+	infos := &PerfumsInfoV1{}
+	return infos.ExtraCount(uids)
+}
+
+func (obj *PerfumsInfoV2) ExtraCountStatuses(uids []string, statuses []string) (int64, error) {
+	infos := &PerfumsInfoV1{}
+	return infos.ExtraCountStatuses(uids, statuses)
+}
+
+func (obj *PerfumsInfoV2) Json(w http.ResponseWriter, status int) error {
+	render := render.New()
+	return render.JSON(w, status, obj)
+}
+
+// TaxonomyV2 is the item of every taxonomy in v2, brands to types alike.
+type TaxonomyV2 struct {
+	Uuid         string            `json:"id"`
+	Name         string            `json:"name"`
+	Status       string            `json:"status"`
+	PerfumsCount int64             `json:"perfums_count"`
+	Locales      map[string]string `json:"locales,omitempty"`
+	Links        []LinkV1          `json:"links"`
+	Image        *ImageRefV2       `json:"image"`
+}
+
+// TaxonomiesV2 serves the taxonomy Kind (e.g. "brands") from its v1 queries.
+type TaxonomiesV2 struct {
+	Kind    string       `db:"-" json:"-"`
+	ObjList []TaxonomyV2 `db:"-" json:"taxonomy_list"`
+	Total   int64        `db:"-" json:"total"`
+	Offset  int64        `db:"-" json:"offset"`
+	Amount  int64        `db:"-" json:"amount"`
+}
+
+// taxonomyKinds are the collections TaxonomiesV2 is registered for.
+var taxonomyKinds = []string{
+	"brands", "components", "countries", "genders", "groups",
+	"notes", "perfumers", "seasons", "times_of_day", "types",
+}
+
+type taxonomyItemV1 struct {
+	uuid         string
+	name         string
+	perfumsCount int64
+	locales      map[string]string
+	links        []LinkV1
+	imageId      sql.NullString
+	status       string
+}
+
+// taxonomyItemsV1 flattens the v1 collection of any taxonomy.
+func taxonomyItemsV1(obj Objecter) []taxonomyItemV1 {
+	items := []taxonomyItemV1{}
+	switch list := obj.(type) {
+	case *BrandsV1:
+		for _, i := range list.ObjList {
+			items = append(items, taxonomyItemV1{i.Uuid, i.Name, i.PerfumsCount, i.Locales, i.Links, i.ImageId, i.Status})
+		}
+	case *ComponentsV1:
+		for _, i := range list.ObjList {
+			items = append(items, taxonomyItemV1{i.Uuid, i.Name, i.PerfumsCount, i.Locales, i.Links, i.ImageId, i.Status})
+		}
+	case *CountriesV1:
+		for _, i := range list.ObjList {
+			items = append(items, taxonomyItemV1{i.Uuid, i.Name, i.PerfumsCount, i.Locales, i.Links, i.ImageId, i.Status})
+		}
+	case *GendersV1:
+		for _, i := range list.ObjList {
+			items = append(items, taxonomyItemV1{i.Uuid, i.Name, i.PerfumsCount, i.Locales, i.Links, i.ImageId, i.Status})
+		}
+	case *GroupsV1:
+		for _, i := range list.ObjList {
+			items = append(items, taxonomyItemV1{i.Uuid, i.Name, i.PerfumsCount, i.Locales, i.Links, i.ImageId, i.Status})
+		}
+	case *NotesV1:
+		for _, i := range list.ObjList {
+			items = append(items, taxonomyItemV1{i.Uuid, i.Name, i.PerfumsCount, i.Locales, i.Links, i.ImageId, i.Status})
+		}
+	case *PerfumersV1:
+		for _, i := range list.ObjList {
+			items = append(items, taxonomyItemV1{i.Uuid, i.Name, i.PerfumsCount, i.Locales, i.Links, i.ImageId, i.Status})
+		}
+	case *SeasonsV1:
+		for _, i := range list.ObjList {
+			items = append(items, taxonomyItemV1{i.Uuid, i.Name, i.PerfumsCount, i.Locales, i.Links, i.ImageId, i.Status})
+		}
+	case *TimesOfDayV1:
+		for _, i := range list.ObjList {
+			items = append(items, taxonomyItemV1{i.Uuid, i.Name, i.PerfumsCount, i.Locales, i.Links, i.ImageId, i.Status})
+		}
+	case *TypesV1:
+		for _, i := range list.ObjList {
+			items = append(items, taxonomyItemV1{i.Uuid, i.Name, i.PerfumsCount, i.Locales, i.Links, i.ImageId, i.Status})
+		}
+	}
+	return items
+}
+
+func (obj *TaxonomiesV2) v1() (Objecter, error) {
+	return NewObjecter(obj.Kind, "v1")
+}
+
+func (obj *TaxonomiesV2) MakeObj(pParams interface{}) (Objecter, error) {
+	if pParams == nil {
+		return nil, errors.New("invalid args")
+	}
+
+	v1, err := obj.v1()
+	if err != nil {
+		return nil, err
+	}
+	list, err := v1.MakeObj(pParams)
+	if err != nil {
+		return nil, err
+	}
+
+	items := taxonomyItemsV1(list)
+	imageUuids := []string{}
+	for _, item := range items {
+		if item.imageId.Valid {
+			imageUuids = appendUnique(imageUuids, item.imageId.String)
+		}
+	}
+	images, err := imageRefsV2(imageUuids)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range items {
+		obj.ObjList = append(obj.ObjList, TaxonomyV2{
+			Uuid:         item.uuid,
+			Name:         item.name,
+			Status:       item.status,
+			PerfumsCount: item.perfumsCount,
+			Locales:      item.locales,
+			Links:        item.links,
+			Image:        images[item.imageId.String],
+		})
+	}
+
+	params := pParams.(*MakeObjParams)
+	obj.Total = params.Total
+	obj.Offset = params.Base.Offset.Int64
+	obj.Amount = int64(len(obj.ObjList))
+
+	return obj, nil
+}
+
+// MakeExtraObj lists the perfums of the items uids in the version of
+// params, as v1 does.
+func (obj *TaxonomiesV2) MakeExtraObj(params *MakeObjParams, uids []string) (Objecter, error) {
+	v1, err := obj.v1()
+	if err != nil {
+		return nil, err
+	}
+	return v1.MakeExtraObj(params, uids)
+}
+
+func (obj *TaxonomiesV2) Count(pParams interface{}) (int64, error) {
+	v1, err := obj.v1()
+	if err != nil {
+		return 0, err
+	}
+	return v1.Count(pParams)
+}
+
+func (obj *TaxonomiesV2) ExtraCount(uids []string) (int64, error) {
+	v1, err := obj.v1()
+	if err != nil {
+		return 0, err
+	}
+	return v1.ExtraCount(uids)
+}
+
+func (obj *TaxonomiesV2) ExtraCountParams(params *MakeObjParams, uids []string) (int64, error) {
+	v1, err := obj.v1()
+	if err != nil {
+		return 0, err
+	}
+	return ExtraCountFor(v1, params, uids)
+}
+
+func (obj *TaxonomiesV2) Json(w http.ResponseWriter, status int) error {
+	render := render.New()
+	return render.JSON(w, status, obj)
+}
@@ -0,0 +1,54 @@
+package objects
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestNewPerfumInfoV2Refs(t *testing.T) {
+	info := &PerfumInfoV1{
+		Uuid:      "p1",
+		Name:      "No 5",
+		BrandUuid: "b1",
+		BrandName: "Chanel",
+		Seasons:   []TaxonomyItemRefV1{{Uuid: "s1", Name: "Spring"}, {Name: "broken"}},
+	}
+	obj := NewPerfumInfoV2(info, nil)
+	if obj.Brand == nil || obj.Brand.Id != "b1" || len(obj.Brand.Links) != 2 {
+		t.Errorf("brand %+v", obj.Brand)
+	}
+	if obj.Group != nil || obj.Type != nil {
+		t.Errorf("refs of no item: group %+v, type %+v", obj.Group, obj.Type)
+	}
+	if len(obj.Seasons) != 1 || obj.Seasons[0].Id != "s1" {
+		t.Errorf("seasons %+v", obj.Seasons)
+	}
+
+	b, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), `"brand":{"id":"b1"`) || strings.Contains(string(b), `"group"`) {
+		t.Errorf("json %s", b)
+	}
+}
+
+func TestNewPerfumInfoV2Tombstone(t *testing.T) {
+	self := LinkV1{Href: "/perfum/p1", Rel: "PerfumInfo", Method: "GET"}
+	info := &PerfumInfoV1{
+		Uuid:      "p1",
+		Status:    StatusDeleted,
+		Name:      "No 5",
+		BrandUuid: "b1",
+		Tags:      []TaxonomyItemRefV1{{Uuid: "t1", Name: "iconic"}},
+		Links:     []LinkV1{self},
+	}
+	obj := NewPerfumInfoV2(info, &ImageRefV2{})
+	if obj.Uuid != "p1" || obj.Status != StatusDeleted || len(obj.Links) != 1 || obj.Links[0] != self {
+		t.Errorf("tombstone %+v", obj)
+	}
+	if obj.Name != "" || obj.Brand != nil || obj.Tags != nil || obj.Image != nil {
+		t.Errorf("tombstone keeps the perfum: %+v", obj)
+	}
+}
@@ -0,0 +1,5 @@
+// Package objectspb holds the protobuf messages and gRPC service mirroring
+// the objects collections.
+package objectspb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative objects.proto
@@ -0,0 +1,3038 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: objects.proto
+
+package objectspb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Link struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Href   string `protobuf:"bytes,1,opt,name=href,proto3" json:"href,omitempty"`
+	Rel    string `protobuf:"bytes,2,opt,name=rel,proto3" json:"rel,omitempty"`
+	Method string `protobuf:"bytes,3,opt,name=method,proto3" json:"method,omitempty"`
+}
+
+func (x *Link) Reset() {
+	*x = Link{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_objects_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Link) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Link) ProtoMessage() {}
+
+func (x *Link) ProtoReflect() protoreflect.Message {
+	mi := &file_objects_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Link.ProtoReflect.Descriptor instead.
+func (*Link) Descriptor() ([]byte, []int) {
+	return file_objects_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Link) GetHref() string {
+	if x != nil {
+		return x.Href
+	}
+	return ""
+}
+
+func (x *Link) GetRel() string {
+	if x != nil {
+		return x.Rel
+	}
+	return ""
+}
+
+func (x *Link) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+type PerfumInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id             string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name           string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	DescriptionId  string   `protobuf:"bytes,3,opt,name=description_id,json=descriptionId,proto3" json:"description_id,omitempty"`
+	Description    string   `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Year           int64    `protobuf:"varint,5,opt,name=year,proto3" json:"year,omitempty"`
+	BrandId        string   `protobuf:"bytes,6,opt,name=brand_id,json=brandId,proto3" json:"brand_id,omitempty"`
+	BrandName      string   `protobuf:"bytes,7,opt,name=brand_name,json=brandName,proto3" json:"brand_name,omitempty"`
+	GenderId       string   `protobuf:"bytes,8,opt,name=gender_id,json=genderId,proto3" json:"gender_id,omitempty"`
+	GenderName     string   `protobuf:"bytes,9,opt,name=gender_name,json=genderName,proto3" json:"gender_name,omitempty"`
+	GroupId        string   `protobuf:"bytes,10,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	GroupName      string   `protobuf:"bytes,11,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
+	CountryId      string   `protobuf:"bytes,12,opt,name=country_id,json=countryId,proto3" json:"country_id,omitempty"`
+	CountryName    string   `protobuf:"bytes,13,opt,name=country_name,json=countryName,proto3" json:"country_name,omitempty"`
+	SeasonId       string   `protobuf:"bytes,14,opt,name=season_id,json=seasonId,proto3" json:"season_id,omitempty"`
+	SeasonName     string   `protobuf:"bytes,15,opt,name=season_name,json=seasonName,proto3" json:"season_name,omitempty"`
+	TsodId         string   `protobuf:"bytes,16,opt,name=tsod_id,json=tsodId,proto3" json:"tsod_id,omitempty"`
+	TsodName       string   `protobuf:"bytes,17,opt,name=tsod_name,json=tsodName,proto3" json:"tsod_name,omitempty"`
+	TypeId         string   `protobuf:"bytes,18,opt,name=type_id,json=typeId,proto3" json:"type_id,omitempty"`
+	TypeName       string   `protobuf:"bytes,19,opt,name=type_name,json=typeName,proto3" json:"type_name,omitempty"`
+	StarsId        string   `protobuf:"bytes,20,opt,name=stars_id,json=starsId,proto3" json:"stars_id,omitempty"`
+	ShopId         string   `protobuf:"bytes,21,opt,name=shop_id,json=shopId,proto3" json:"shop_id,omitempty"`
+	Links          []*Link  `protobuf:"bytes,22,rep,name=links,proto3" json:"links,omitempty"`
+	SmallImgUrl    string   `protobuf:"bytes,23,opt,name=small_img_url,json=smallImgUrl,proto3" json:"small_img_url,omitempty"`
+	LargeImgUrl    string   `protobuf:"bytes,24,opt,name=large_img_url,json=largeImgUrl,proto3" json:"large_img_url,omitempty"`
+	ParentId       string   `protobuf:"bytes,25,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	FamilyRelation string   `protobuf:"bytes,26,opt,name=family_relation,json=familyRelation,proto3" json:"family_relation,omitempty"`
+	PerfumerIds    []string `protobuf:"bytes,27,rep,name=perfumer_ids,json=perfumerIds,proto3" json:"perfumer_ids,omitempty"`
+	// Every season and time of day of the perfum, season_id and tsod_id
+	// first.
+	SeasonIds []string `protobuf:"bytes,28,rep,name=season_ids,json=seasonIds,proto3" json:"season_ids,omitempty"`
+	TsodIds   []string `protobuf:"bytes,29,rep,name=tsod_ids,json=tsodIds,proto3" json:"tsod_ids,omitempty"`
+	TagIds    []string `protobuf:"bytes,30,rep,name=tag_ids,json=tagIds,proto3" json:"tag_ids,omitempty"`
+	// Lifecycle state: active, discontinued, archived or deleted.
+	Status string `protobuf:"bytes,31,opt,name=status,proto3" json:"status,omitempty"`
+}
+
+func (x *PerfumInfo) Reset() {
+	*x = PerfumInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_objects_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PerfumInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PerfumInfo) ProtoMessage() {}
+
+func (x *PerfumInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_objects_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PerfumInfo.ProtoReflect.Descriptor instead.
+func (*PerfumInfo) Descriptor() ([]byte, []int) {
+	return file_objects_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PerfumInfo) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PerfumInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PerfumInfo) GetDescriptionId() string {
+	if x != nil {
+		return x.DescriptionId
+	}
+	return ""
+}
+
+func (x *PerfumInfo) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *PerfumInfo) GetYear() int64 {
+	if x != nil {
+		return x.Year
+	}
+	return 0
+}
+
+func (x *PerfumInfo) GetBrandId() string {
+	if x != nil {
+		return x.BrandId
+	}
+	return ""
+}
+
+func (x *PerfumInfo) GetBrandName() string {
+	if x != nil {
+		return x.BrandName
+	}
+	return ""
+}
+
+func (x *PerfumInfo) GetGenderId() string {
+	if x != nil {
+		return x.GenderId
+	}
+	return ""
+}
+
+func (x *PerfumInfo) GetGenderName() string {
+	if x != nil {
+		return x.GenderName
+	}
+	return ""
+}
+
+func (x *PerfumInfo) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *PerfumInfo) GetGroupName() string {
+	if x != nil {
+		return x.GroupName
+	}
+	return ""
+}
+
+func (x *PerfumInfo) GetCountryId() string {
+	if x != nil {
+		return x.CountryId
+	}
+	return ""
+}
+
+func (x *PerfumInfo) GetCountryName() string {
+	if x != nil {
+		return x.CountryName
+	}
+	return ""
+}
+
+func (x *PerfumInfo) GetSeasonId() string {
+	if x != nil {
+		return x.SeasonId
+	}
+	return ""
+}
+
+func (x *PerfumInfo) GetSeasonName() string {
+	if x != nil {
+		return x.SeasonName
+	}
+	return ""
+}
+
+func (x *PerfumInfo) GetTsodId() string {
+	if x != nil {
+		return x.TsodId
+	}
+	return ""
+}
+
+func (x *PerfumInfo) GetTsodName() string {
+	if x != nil {
+		return x.TsodName
+	}
+	return ""
+}
+
+func (x *PerfumInfo) GetTypeId() string {
+	if x != nil {
+		return x.TypeId
+	}
+	return ""
+}
+
+func (x *PerfumInfo) GetTypeName() string {
+	if x != nil {
+		return x.TypeName
+	}
+	return ""
+}
+
+func (x *PerfumInfo) GetStarsId() string {
+	if x != nil {
+		return x.StarsId
+	}
+	return ""
+}
+
+func (x *PerfumInfo) GetShopId() string {
+	if x != nil {
+		return x.ShopId
+	}
+	return ""
+}
+
+func (x *PerfumInfo) GetLinks() []*Link {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+func (x *PerfumInfo) GetSmallImgUrl() string {
+	if x != nil {
+		return x.SmallImgUrl
+	}
+	return ""
+}
+
+func (x *PerfumInfo) GetLargeImgUrl() string {
+	if x != nil {
+		return x.LargeImgUrl
+	}
+	return ""
+}
+
+func (x *PerfumInfo) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *PerfumInfo) GetFamilyRelation() string {
+	if x != nil {
+		return x.FamilyRelation
+	}
+	return ""
+}
+
+func (x *PerfumInfo) GetPerfumerIds() []string {
+	if x != nil {
+		return x.PerfumerIds
+	}
+	return nil
+}
+
+func (x *PerfumInfo) GetSeasonIds() []string {
+	if x != nil {
+		return x.SeasonIds
+	}
+	return nil
+}
+
+func (x *PerfumInfo) GetTsodIds() []string {
+	if x != nil {
+		return x.TsodIds
+	}
+	return nil
+}
+
+func (x *PerfumInfo) GetTagIds() []string {
+	if x != nil {
+		return x.TagIds
+	}
+	return nil
+}
+
+func (x *PerfumInfo) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type ComponentItem struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ComponentId   string   `protobuf:"bytes,1,opt,name=component_id,json=componentId,proto3" json:"component_id,omitempty"`
+	ComponentName string   `protobuf:"bytes,2,opt,name=component_name,json=componentName,proto3" json:"component_name,omitempty"`
+	Links         []*Link  `protobuf:"bytes,3,rep,name=links,proto3" json:"links,omitempty"`
+	Intensity     *int64   `protobuf:"varint,4,opt,name=intensity,proto3,oneof" json:"intensity,omitempty"`
+	Percentage    *float64 `protobuf:"fixed64,5,opt,name=percentage,proto3,oneof" json:"percentage,omitempty"`
+}
+
+func (x *ComponentItem) Reset() {
+	*x = ComponentItem{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_objects_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ComponentItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComponentItem) ProtoMessage() {}
+
+func (x *ComponentItem) ProtoReflect() protoreflect.Message {
+	mi := &file_objects_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComponentItem.ProtoReflect.Descriptor instead.
+func (*ComponentItem) Descriptor() ([]byte, []int) {
+	return file_objects_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ComponentItem) GetComponentId() string {
+	if x != nil {
+		return x.ComponentId
+	}
+	return ""
+}
+
+func (x *ComponentItem) GetComponentName() string {
+	if x != nil {
+		return x.ComponentName
+	}
+	return ""
+}
+
+func (x *ComponentItem) GetLinks() []*Link {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+func (x *ComponentItem) GetIntensity() int64 {
+	if x != nil && x.Intensity != nil {
+		return *x.Intensity
+	}
+	return 0
+}
+
+func (x *ComponentItem) GetPercentage() float64 {
+	if x != nil && x.Percentage != nil {
+		return *x.Percentage
+	}
+	return 0
+}
+
+type NoteItem struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NoteId         string           `protobuf:"bytes,1,opt,name=note_id,json=noteId,proto3" json:"note_id,omitempty"`
+	NoteName       string           `protobuf:"bytes,2,opt,name=note_name,json=noteName,proto3" json:"note_name,omitempty"`
+	Components     []*ComponentItem `protobuf:"bytes,3,rep,name=components,proto3" json:"components,omitempty"`
+	Links          []*Link          `protobuf:"bytes,4,rep,name=links,proto3" json:"links,omitempty"`
+	ComponentCount int64            `protobuf:"varint,5,opt,name=component_count,json=componentCount,proto3" json:"component_count,omitempty"`
+	// Pyramid level: "top", "heart", "base" or empty when unknown.
+	Level    string `protobuf:"bytes,6,opt,name=level,proto3" json:"level,omitempty"`
+	Position int64  `protobuf:"varint,7,opt,name=position,proto3" json:"position,omitempty"`
+}
+
+func (x *NoteItem) Reset() {
+	*x = NoteItem{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_objects_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NoteItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NoteItem) ProtoMessage() {}
+
+func (x *NoteItem) ProtoReflect() protoreflect.Message {
+	mi := &file_objects_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NoteItem.ProtoReflect.Descriptor instead.
+func (*NoteItem) Descriptor() ([]byte, []int) {
+	return file_objects_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *NoteItem) GetNoteId() string {
+	if x != nil {
+		return x.NoteId
+	}
+	return ""
+}
+
+func (x *NoteItem) GetNoteName() string {
+	if x != nil {
+		return x.NoteName
+	}
+	return ""
+}
+
+func (x *NoteItem) GetComponents() []*ComponentItem {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
+func (x *NoteItem) GetLinks() []*Link {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+func (x *NoteItem) GetComponentCount() int64 {
+	if x != nil {
+		return x.ComponentCount
+	}
+	return 0
+}
+
+func (x *NoteItem) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *NoteItem) GetPosition() int64 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+type PerfumComposition struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Info            *PerfumInfo `protobuf:"bytes,1,opt,name=info,proto3" json:"info,omitempty"`
+	Notes           []*NoteItem `protobuf:"bytes,2,rep,name=notes,proto3" json:"notes,omitempty"`
+	TotalComponents int64       `protobuf:"varint,3,opt,name=total_components,json=totalComponents,proto3" json:"total_components,omitempty"`
+}
+
+func (x *PerfumComposition) Reset() {
+	*x = PerfumComposition{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_objects_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PerfumComposition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PerfumComposition) ProtoMessage() {}
+
+func (x *PerfumComposition) ProtoReflect() protoreflect.Message {
+	mi := &file_objects_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PerfumComposition.ProtoReflect.Descriptor instead.
+func (*PerfumComposition) Descriptor() ([]byte, []int) {
+	return file_objects_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PerfumComposition) GetInfo() *PerfumInfo {
+	if x != nil {
+		return x.Info
+	}
+	return nil
+}
+
+func (x *PerfumComposition) GetNotes() []*NoteItem {
+	if x != nil {
+		return x.Notes
+	}
+	return nil
+}
+
+func (x *PerfumComposition) GetTotalComponents() int64 {
+	if x != nil {
+		return x.TotalComponents
+	}
+	return 0
+}
+
+type Brand struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id           string  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name         string  `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	PerfumsCount int64   `protobuf:"varint,3,opt,name=perfums_count,json=perfumsCount,proto3" json:"perfums_count,omitempty"`
+	Links        []*Link `protobuf:"bytes,4,rep,name=links,proto3" json:"links,omitempty"`
+	SmallImgUrl  string  `protobuf:"bytes,5,opt,name=small_img_url,json=smallImgUrl,proto3" json:"small_img_url,omitempty"`
+	LargeImgUrl  string  `protobuf:"bytes,6,opt,name=large_img_url,json=largeImgUrl,proto3" json:"large_img_url,omitempty"`
+}
+
+func (x *Brand) Reset() {
+	*x = Brand{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_objects_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Brand) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Brand) ProtoMessage() {}
+
+func (x *Brand) ProtoReflect() protoreflect.Message {
+	mi := &file_objects_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Brand.ProtoReflect.Descriptor instead.
+func (*Brand) Descriptor() ([]byte, []int) {
+	return file_objects_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Brand) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Brand) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Brand) GetPerfumsCount() int64 {
+	if x != nil {
+		return x.PerfumsCount
+	}
+	return 0
+}
+
+func (x *Brand) GetLinks() []*Link {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+func (x *Brand) GetSmallImgUrl() string {
+	if x != nil {
+		return x.SmallImgUrl
+	}
+	return ""
+}
+
+func (x *Brand) GetLargeImgUrl() string {
+	if x != nil {
+		return x.LargeImgUrl
+	}
+	return ""
+}
+
+type Component struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id           string  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name         string  `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	PerfumsCount int64   `protobuf:"varint,3,opt,name=perfums_count,json=perfumsCount,proto3" json:"perfums_count,omitempty"`
+	Links        []*Link `protobuf:"bytes,4,rep,name=links,proto3" json:"links,omitempty"`
+	SmallImgUrl  string  `protobuf:"bytes,5,opt,name=small_img_url,json=smallImgUrl,proto3" json:"small_img_url,omitempty"`
+	LargeImgUrl  string  `protobuf:"bytes,6,opt,name=large_img_url,json=largeImgUrl,proto3" json:"large_img_url,omitempty"`
+}
+
+func (x *Component) Reset() {
+	*x = Component{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_objects_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Component) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Component) ProtoMessage() {}
+
+func (x *Component) ProtoReflect() protoreflect.Message {
+	mi := &file_objects_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Component.ProtoReflect.Descriptor instead.
+func (*Component) Descriptor() ([]byte, []int) {
+	return file_objects_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Component) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Component) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Component) GetPerfumsCount() int64 {
+	if x != nil {
+		return x.PerfumsCount
+	}
+	return 0
+}
+
+func (x *Component) GetLinks() []*Link {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+func (x *Component) GetSmallImgUrl() string {
+	if x != nil {
+		return x.SmallImgUrl
+	}
+	return ""
+}
+
+func (x *Component) GetLargeImgUrl() string {
+	if x != nil {
+		return x.LargeImgUrl
+	}
+	return ""
+}
+
+type Country struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id           string  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name         string  `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	PerfumsCount int64   `protobuf:"varint,3,opt,name=perfums_count,json=perfumsCount,proto3" json:"perfums_count,omitempty"`
+	Links        []*Link `protobuf:"bytes,4,rep,name=links,proto3" json:"links,omitempty"`
+	SmallImgUrl  string  `protobuf:"bytes,5,opt,name=small_img_url,json=smallImgUrl,proto3" json:"small_img_url,omitempty"`
+	LargeImgUrl  string  `protobuf:"bytes,6,opt,name=large_img_url,json=largeImgUrl,proto3" json:"large_img_url,omitempty"`
+}
+
+func (x *Country) Reset() {
+	*x = Country{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_objects_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Country) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Country) ProtoMessage() {}
+
+func (x *Country) ProtoReflect() protoreflect.Message {
+	mi := &file_objects_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Country.ProtoReflect.Descriptor instead.
+func (*Country) Descriptor() ([]byte, []int) {
+	return file_objects_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Country) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Country) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Country) GetPerfumsCount() int64 {
+	if x != nil {
+		return x.PerfumsCount
+	}
+	return 0
+}
+
+func (x *Country) GetLinks() []*Link {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+func (x *Country) GetSmallImgUrl() string {
+	if x != nil {
+		return x.SmallImgUrl
+	}
+	return ""
+}
+
+func (x *Country) GetLargeImgUrl() string {
+	if x != nil {
+		return x.LargeImgUrl
+	}
+	return ""
+}
+
+type Gender struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id           string  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name         string  `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	PerfumsCount int64   `protobuf:"varint,3,opt,name=perfums_count,json=perfumsCount,proto3" json:"perfums_count,omitempty"`
+	Links        []*Link `protobuf:"bytes,4,rep,name=links,proto3" json:"links,omitempty"`
+	SmallImgUrl  string  `protobuf:"bytes,5,opt,name=small_img_url,json=smallImgUrl,proto3" json:"small_img_url,omitempty"`
+	LargeImgUrl  string  `protobuf:"bytes,6,opt,name=large_img_url,json=largeImgUrl,proto3" json:"large_img_url,omitempty"`
+}
+
+func (x *Gender) Reset() {
+	*x = Gender{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_objects_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Gender) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Gender) ProtoMessage() {}
+
+func (x *Gender) ProtoReflect() protoreflect.Message {
+	mi := &file_objects_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Gender.ProtoReflect.Descriptor instead.
+func (*Gender) Descriptor() ([]byte, []int) {
+	return file_objects_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Gender) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Gender) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Gender) GetPerfumsCount() int64 {
+	if x != nil {
+		return x.PerfumsCount
+	}
+	return 0
+}
+
+func (x *Gender) GetLinks() []*Link {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+func (x *Gender) GetSmallImgUrl() string {
+	if x != nil {
+		return x.SmallImgUrl
+	}
+	return ""
+}
+
+func (x *Gender) GetLargeImgUrl() string {
+	if x != nil {
+		return x.LargeImgUrl
+	}
+	return ""
+}
+
+type Group struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id           string  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name         string  `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	PerfumsCount int64   `protobuf:"varint,3,opt,name=perfums_count,json=perfumsCount,proto3" json:"perfums_count,omitempty"`
+	Links        []*Link `protobuf:"bytes,4,rep,name=links,proto3" json:"links,omitempty"`
+	SmallImgUrl  string  `protobuf:"bytes,5,opt,name=small_img_url,json=smallImgUrl,proto3" json:"small_img_url,omitempty"`
+	LargeImgUrl  string  `protobuf:"bytes,6,opt,name=large_img_url,json=largeImgUrl,proto3" json:"large_img_url,omitempty"`
+}
+
+func (x *Group) Reset() {
+	*x = Group{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_objects_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Group) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Group) ProtoMessage() {}
+
+func (x *Group) ProtoReflect() protoreflect.Message {
+	mi := &file_objects_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Group.ProtoReflect.Descriptor instead.
+func (*Group) Descriptor() ([]byte, []int) {
+	return file_objects_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Group) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Group) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Group) GetPerfumsCount() int64 {
+	if x != nil {
+		return x.PerfumsCount
+	}
+	return 0
+}
+
+func (x *Group) GetLinks() []*Link {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+func (x *Group) GetSmallImgUrl() string {
+	if x != nil {
+		return x.SmallImgUrl
+	}
+	return ""
+}
+
+func (x *Group) GetLargeImgUrl() string {
+	if x != nil {
+		return x.LargeImgUrl
+	}
+	return ""
+}
+
+type Note struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id           string  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name         string  `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	PerfumsCount int64   `protobuf:"varint,3,opt,name=perfums_count,json=perfumsCount,proto3" json:"perfums_count,omitempty"`
+	Links        []*Link `protobuf:"bytes,4,rep,name=links,proto3" json:"links,omitempty"`
+	SmallImgUrl  string  `protobuf:"bytes,5,opt,name=small_img_url,json=smallImgUrl,proto3" json:"small_img_url,omitempty"`
+	LargeImgUrl  string  `protobuf:"bytes,6,opt,name=large_img_url,json=largeImgUrl,proto3" json:"large_img_url,omitempty"`
+}
+
+func (x *Note) Reset() {
+	*x = Note{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_objects_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Note) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Note) ProtoMessage() {}
+
+func (x *Note) ProtoReflect() protoreflect.Message {
+	mi := &file_objects_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Note.ProtoReflect.Descriptor instead.
+func (*Note) Descriptor() ([]byte, []int) {
+	return file_objects_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Note) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Note) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Note) GetPerfumsCount() int64 {
+	if x != nil {
+		return x.PerfumsCount
+	}
+	return 0
+}
+
+func (x *Note) GetLinks() []*Link {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+func (x *Note) GetSmallImgUrl() string {
+	if x != nil {
+		return x.SmallImgUrl
+	}
+	return ""
+}
+
+func (x *Note) GetLargeImgUrl() string {
+	if x != nil {
+		return x.LargeImgUrl
+	}
+	return ""
+}
+
+type Season struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id           string  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name         string  `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	PerfumsCount int64   `protobuf:"varint,3,opt,name=perfums_count,json=perfumsCount,proto3" json:"perfums_count,omitempty"`
+	Links        []*Link `protobuf:"bytes,4,rep,name=links,proto3" json:"links,omitempty"`
+	SmallImgUrl  string  `protobuf:"bytes,5,opt,name=small_img_url,json=smallImgUrl,proto3" json:"small_img_url,omitempty"`
+	LargeImgUrl  string  `protobuf:"bytes,6,opt,name=large_img_url,json=largeImgUrl,proto3" json:"large_img_url,omitempty"`
+}
+
+func (x *Season) Reset() {
+	*x = Season{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_objects_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Season) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Season) ProtoMessage() {}
+
+func (x *Season) ProtoReflect() protoreflect.Message {
+	mi := &file_objects_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Season.ProtoReflect.Descriptor instead.
+func (*Season) Descriptor() ([]byte, []int) {
+	return file_objects_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Season) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Season) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Season) GetPerfumsCount() int64 {
+	if x != nil {
+		return x.PerfumsCount
+	}
+	return 0
+}
+
+func (x *Season) GetLinks() []*Link {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+func (x *Season) GetSmallImgUrl() string {
+	if x != nil {
+		return x.SmallImgUrl
+	}
+	return ""
+}
+
+func (x *Season) GetLargeImgUrl() string {
+	if x != nil {
+		return x.LargeImgUrl
+	}
+	return ""
+}
+
+type TimeOfDay struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id           string  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name         string  `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	PerfumsCount int64   `protobuf:"varint,3,opt,name=perfums_count,json=perfumsCount,proto3" json:"perfums_count,omitempty"`
+	Links        []*Link `protobuf:"bytes,4,rep,name=links,proto3" json:"links,omitempty"`
+	SmallImgUrl  string  `protobuf:"bytes,5,opt,name=small_img_url,json=smallImgUrl,proto3" json:"small_img_url,omitempty"`
+	LargeImgUrl  string  `protobuf:"bytes,6,opt,name=large_img_url,json=largeImgUrl,proto3" json:"large_img_url,omitempty"`
+}
+
+func (x *TimeOfDay) Reset() {
+	*x = TimeOfDay{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_objects_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TimeOfDay) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimeOfDay) ProtoMessage() {}
+
+func (x *TimeOfDay) ProtoReflect() protoreflect.Message {
+	mi := &file_objects_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimeOfDay.ProtoReflect.Descriptor instead.
+func (*TimeOfDay) Descriptor() ([]byte, []int) {
+	return file_objects_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *TimeOfDay) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TimeOfDay) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TimeOfDay) GetPerfumsCount() int64 {
+	if x != nil {
+		return x.PerfumsCount
+	}
+	return 0
+}
+
+func (x *TimeOfDay) GetLinks() []*Link {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+func (x *TimeOfDay) GetSmallImgUrl() string {
+	if x != nil {
+		return x.SmallImgUrl
+	}
+	return ""
+}
+
+func (x *TimeOfDay) GetLargeImgUrl() string {
+	if x != nil {
+		return x.LargeImgUrl
+	}
+	return ""
+}
+
+type Type struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id           string  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name         string  `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	PerfumsCount int64   `protobuf:"varint,3,opt,name=perfums_count,json=perfumsCount,proto3" json:"perfums_count,omitempty"`
+	Links        []*Link `protobuf:"bytes,4,rep,name=links,proto3" json:"links,omitempty"`
+	SmallImgUrl  string  `protobuf:"bytes,5,opt,name=small_img_url,json=smallImgUrl,proto3" json:"small_img_url,omitempty"`
+	LargeImgUrl  string  `protobuf:"bytes,6,opt,name=large_img_url,json=largeImgUrl,proto3" json:"large_img_url,omitempty"`
+}
+
+func (x *Type) Reset() {
+	*x = Type{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_objects_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Type) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Type) ProtoMessage() {}
+
+func (x *Type) ProtoReflect() protoreflect.Message {
+	mi := &file_objects_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Type.ProtoReflect.Descriptor instead.
+func (*Type) Descriptor() ([]byte, []int) {
+	return file_objects_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Type) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Type) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Type) GetPerfumsCount() int64 {
+	if x != nil {
+		return x.PerfumsCount
+	}
+	return 0
+}
+
+func (x *Type) GetLinks() []*Link {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+func (x *Type) GetSmallImgUrl() string {
+	if x != nil {
+		return x.SmallImgUrl
+	}
+	return ""
+}
+
+func (x *Type) GetLargeImgUrl() string {
+	if x != nil {
+		return x.LargeImgUrl
+	}
+	return ""
+}
+
+type Perfumer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id           string  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name         string  `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	PerfumsCount int64   `protobuf:"varint,3,opt,name=perfums_count,json=perfumsCount,proto3" json:"perfums_count,omitempty"`
+	Links        []*Link `protobuf:"bytes,4,rep,name=links,proto3" json:"links,omitempty"`
+	SmallImgUrl  string  `protobuf:"bytes,5,opt,name=small_img_url,json=smallImgUrl,proto3" json:"small_img_url,omitempty"`
+	LargeImgUrl  string  `protobuf:"bytes,6,opt,name=large_img_url,json=largeImgUrl,proto3" json:"large_img_url,omitempty"`
+}
+
+func (x *Perfumer) Reset() {
+	*x = Perfumer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_objects_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Perfumer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Perfumer) ProtoMessage() {}
+
+func (x *Perfumer) ProtoReflect() protoreflect.Message {
+	mi := &file_objects_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Perfumer.ProtoReflect.Descriptor instead.
+func (*Perfumer) Descriptor() ([]byte, []int) {
+	return file_objects_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Perfumer) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Perfumer) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Perfumer) GetPerfumsCount() int64 {
+	if x != nil {
+		return x.PerfumsCount
+	}
+	return 0
+}
+
+func (x *Perfumer) GetLinks() []*Link {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+func (x *Perfumer) GetSmallImgUrl() string {
+	if x != nil {
+		return x.SmallImgUrl
+	}
+	return ""
+}
+
+func (x *Perfumer) GetLargeImgUrl() string {
+	if x != nil {
+		return x.LargeImgUrl
+	}
+	return ""
+}
+
+type Tag struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id           string  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name         string  `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Kind         string  `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	PerfumsCount int64   `protobuf:"varint,4,opt,name=perfums_count,json=perfumsCount,proto3" json:"perfums_count,omitempty"`
+	Links        []*Link `protobuf:"bytes,5,rep,name=links,proto3" json:"links,omitempty"`
+}
+
+func (x *Tag) Reset() {
+	*x = Tag{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_objects_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Tag) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tag) ProtoMessage() {}
+
+func (x *Tag) ProtoReflect() protoreflect.Message {
+	mi := &file_objects_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tag.ProtoReflect.Descriptor instead.
+func (*Tag) Descriptor() ([]byte, []int) {
+	return file_objects_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Tag) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Tag) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Tag) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Tag) GetPerfumsCount() int64 {
+	if x != nil {
+		return x.PerfumsCount
+	}
+	return 0
+}
+
+func (x *Tag) GetLinks() []*Link {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+type ListRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kind   string   `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Offset int64    `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Limit  int64    `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Ids    []string `protobuf:"bytes,4,rep,name=ids,proto3" json:"ids,omitempty"`
+	// Lifecycle states listed instead of the active ones only.
+	Statuses []string `protobuf:"bytes,5,rep,name=statuses,proto3" json:"statuses,omitempty"`
+}
+
+func (x *ListRequest) Reset() {
+	*x = ListRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_objects_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRequest) ProtoMessage() {}
+
+func (x *ListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_objects_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRequest.ProtoReflect.Descriptor instead.
+func (*ListRequest) Descriptor() ([]byte, []int) {
+	return file_objects_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ListRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *ListRequest) GetStatuses() []string {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+type GetRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kind string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Id   string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetRequest) Reset() {
+	*x = GetRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_objects_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRequest) ProtoMessage() {}
+
+func (x *GetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_objects_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRequest.ProtoReflect.Descriptor instead.
+func (*GetRequest) Descriptor() ([]byte, []int) {
+	return file_objects_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *GetRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type SearchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kind   string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Query  string `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
+	Offset int64  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	Limit  int64  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Locales whose translated names are searched too, most preferred first.
+	Locales []string `protobuf:"bytes,5,rep,name=locales,proto3" json:"locales,omitempty"`
+	// Lifecycle states of the items found, active only when empty.
+	Statuses []string `protobuf:"bytes,6,rep,name=statuses,proto3" json:"statuses,omitempty"`
+}
+
+func (x *SearchRequest) Reset() {
+	*x = SearchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_objects_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchRequest) ProtoMessage() {}
+
+func (x *SearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_objects_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchRequest.ProtoReflect.Descriptor instead.
+func (*SearchRequest) Descriptor() ([]byte, []int) {
+	return file_objects_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SearchRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *SearchRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SearchRequest) GetLocales() []string {
+	if x != nil {
+		return x.Locales
+	}
+	return nil
+}
+
+func (x *SearchRequest) GetStatuses() []string {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+type CountRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kind string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+}
+
+func (x *CountRequest) Reset() {
+	*x = CountRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_objects_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountRequest) ProtoMessage() {}
+
+func (x *CountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_objects_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountRequest.ProtoReflect.Descriptor instead.
+func (*CountRequest) Descriptor() ([]byte, []int) {
+	return file_objects_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CountRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+type CountResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Count int64 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *CountResponse) Reset() {
+	*x = CountResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_objects_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountResponse) ProtoMessage() {}
+
+func (x *CountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_objects_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountResponse.ProtoReflect.Descriptor instead.
+func (*CountResponse) Descriptor() ([]byte, []int) {
+	return file_objects_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CountResponse) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// ListResponse carries the paging envelope and the list matching the
+// requested kind; the other lists stay empty.
+type ListResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Total       int64         `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Offset      int64         `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Amount      int64         `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	PerfumsInfo []*PerfumInfo `protobuf:"bytes,4,rep,name=perfums_info,json=perfumsInfo,proto3" json:"perfums_info,omitempty"`
+	Brands      []*Brand      `protobuf:"bytes,5,rep,name=brands,proto3" json:"brands,omitempty"`
+	Components  []*Component  `protobuf:"bytes,6,rep,name=components,proto3" json:"components,omitempty"`
+	Countries   []*Country    `protobuf:"bytes,7,rep,name=countries,proto3" json:"countries,omitempty"`
+	Genders     []*Gender     `protobuf:"bytes,8,rep,name=genders,proto3" json:"genders,omitempty"`
+	Groups      []*Group      `protobuf:"bytes,9,rep,name=groups,proto3" json:"groups,omitempty"`
+	Notes       []*Note       `protobuf:"bytes,10,rep,name=notes,proto3" json:"notes,omitempty"`
+	Seasons     []*Season     `protobuf:"bytes,11,rep,name=seasons,proto3" json:"seasons,omitempty"`
+	TimesOfDay  []*TimeOfDay  `protobuf:"bytes,12,rep,name=times_of_day,json=timesOfDay,proto3" json:"times_of_day,omitempty"`
+	Types       []*Type       `protobuf:"bytes,13,rep,name=types,proto3" json:"types,omitempty"`
+	Perfumers   []*Perfumer   `protobuf:"bytes,14,rep,name=perfumers,proto3" json:"perfumers,omitempty"`
+	Tags        []*Tag        `protobuf:"bytes,15,rep,name=tags,proto3" json:"tags,omitempty"`
+}
+
+func (x *ListResponse) Reset() {
+	*x = ListResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_objects_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListResponse) ProtoMessage() {}
+
+func (x *ListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_objects_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListResponse.ProtoReflect.Descriptor instead.
+func (*ListResponse) Descriptor() ([]byte, []int) {
+	return file_objects_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListResponse) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListResponse) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *ListResponse) GetPerfumsInfo() []*PerfumInfo {
+	if x != nil {
+		return x.PerfumsInfo
+	}
+	return nil
+}
+
+func (x *ListResponse) GetBrands() []*Brand {
+	if x != nil {
+		return x.Brands
+	}
+	return nil
+}
+
+func (x *ListResponse) GetComponents() []*Component {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
+func (x *ListResponse) GetCountries() []*Country {
+	if x != nil {
+		return x.Countries
+	}
+	return nil
+}
+
+func (x *ListResponse) GetGenders() []*Gender {
+	if x != nil {
+		return x.Genders
+	}
+	return nil
+}
+
+func (x *ListResponse) GetGroups() []*Group {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+func (x *ListResponse) GetNotes() []*Note {
+	if x != nil {
+		return x.Notes
+	}
+	return nil
+}
+
+func (x *ListResponse) GetSeasons() []*Season {
+	if x != nil {
+		return x.Seasons
+	}
+	return nil
+}
+
+func (x *ListResponse) GetTimesOfDay() []*TimeOfDay {
+	if x != nil {
+		return x.TimesOfDay
+	}
+	return nil
+}
+
+func (x *ListResponse) GetTypes() []*Type {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *ListResponse) GetPerfumers() []*Perfumer {
+	if x != nil {
+		return x.Perfumers
+	}
+	return nil
+}
+
+func (x *ListResponse) GetTags() []*Tag {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type GetResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Item:
+	//	*GetResponse_Composition
+	//	*GetResponse_Brand
+	//	*GetResponse_Component
+	//	*GetResponse_Country
+	//	*GetResponse_Gender
+	//	*GetResponse_Group
+	//	*GetResponse_Note
+	//	*GetResponse_Season
+	//	*GetResponse_TimeOfDay
+	//	*GetResponse_Type
+	//	*GetResponse_Perfumer
+	//	*GetResponse_Tag
+	Item isGetResponse_Item `protobuf_oneof:"item"`
+}
+
+func (x *GetResponse) Reset() {
+	*x = GetResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_objects_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetResponse) ProtoMessage() {}
+
+func (x *GetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_objects_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetResponse.ProtoReflect.Descriptor instead.
+func (*GetResponse) Descriptor() ([]byte, []int) {
+	return file_objects_proto_rawDescGZIP(), []int{22}
+}
+
+func (m *GetResponse) GetItem() isGetResponse_Item {
+	if m != nil {
+		return m.Item
+	}
+	return nil
+}
+
+func (x *GetResponse) GetComposition() *PerfumComposition {
+	if x, ok := x.GetItem().(*GetResponse_Composition); ok {
+		return x.Composition
+	}
+	return nil
+}
+
+func (x *GetResponse) GetBrand() *Brand {
+	if x, ok := x.GetItem().(*GetResponse_Brand); ok {
+		return x.Brand
+	}
+	return nil
+}
+
+func (x *GetResponse) GetComponent() *Component {
+	if x, ok := x.GetItem().(*GetResponse_Component); ok {
+		return x.Component
+	}
+	return nil
+}
+
+func (x *GetResponse) GetCountry() *Country {
+	if x, ok := x.GetItem().(*GetResponse_Country); ok {
+		return x.Country
+	}
+	return nil
+}
+
+func (x *GetResponse) GetGender() *Gender {
+	if x, ok := x.GetItem().(*GetResponse_Gender); ok {
+		return x.Gender
+	}
+	return nil
+}
+
+func (x *GetResponse) GetGroup() *Group {
+	if x, ok := x.GetItem().(*GetResponse_Group); ok {
+		return x.Group
+	}
+	return nil
+}
+
+func (x *GetResponse) GetNote() *Note {
+	if x, ok := x.GetItem().(*GetResponse_Note); ok {
+		return x.Note
+	}
+	return nil
+}
+
+func (x *GetResponse) GetSeason() *Season {
+	if x, ok := x.GetItem().(*GetResponse_Season); ok {
+		return x.Season
+	}
+	return nil
+}
+
+func (x *GetResponse) GetTimeOfDay() *TimeOfDay {
+	if x, ok := x.GetItem().(*GetResponse_TimeOfDay); ok {
+		return x.TimeOfDay
+	}
+	return nil
+}
+
+func (x *GetResponse) GetType() *Type {
+	if x, ok := x.GetItem().(*GetResponse_Type); ok {
+		return x.Type
+	}
+	return nil
+}
+
+func (x *GetResponse) GetPerfumer() *Perfumer {
+	if x, ok := x.GetItem().(*GetResponse_Perfumer); ok {
+		return x.Perfumer
+	}
+	return nil
+}
+
+func (x *GetResponse) GetTag() *Tag {
+	if x, ok := x.GetItem().(*GetResponse_Tag); ok {
+		return x.Tag
+	}
+	return nil
+}
+
+type isGetResponse_Item interface {
+	isGetResponse_Item()
+}
+
+type GetResponse_Composition struct {
+	Composition *PerfumComposition `protobuf:"bytes,1,opt,name=composition,proto3,oneof"`
+}
+
+type GetResponse_Brand struct {
+	Brand *Brand `protobuf:"bytes,2,opt,name=brand,proto3,oneof"`
+}
+
+type GetResponse_Component struct {
+	Component *Component `protobuf:"bytes,3,opt,name=component,proto3,oneof"`
+}
+
+type GetResponse_Country struct {
+	Country *Country `protobuf:"bytes,4,opt,name=country,proto3,oneof"`
+}
+
+type GetResponse_Gender struct {
+	Gender *Gender `protobuf:"bytes,5,opt,name=gender,proto3,oneof"`
+}
+
+type GetResponse_Group struct {
+	Group *Group `protobuf:"bytes,6,opt,name=group,proto3,oneof"`
+}
+
+type GetResponse_Note struct {
+	Note *Note `protobuf:"bytes,7,opt,name=note,proto3,oneof"`
+}
+
+type GetResponse_Season struct {
+	Season *Season `protobuf:"bytes,8,opt,name=season,proto3,oneof"`
+}
+
+type GetResponse_TimeOfDay struct {
+	TimeOfDay *TimeOfDay `protobuf:"bytes,9,opt,name=time_of_day,json=timeOfDay,proto3,oneof"`
+}
+
+type GetResponse_Type struct {
+	Type *Type `protobuf:"bytes,10,opt,name=type,proto3,oneof"`
+}
+
+type GetResponse_Perfumer struct {
+	Perfumer *Perfumer `protobuf:"bytes,11,opt,name=perfumer,proto3,oneof"`
+}
+
+type GetResponse_Tag struct {
+	Tag *Tag `protobuf:"bytes,12,opt,name=tag,proto3,oneof"`
+}
+
+func (*GetResponse_Composition) isGetResponse_Item() {}
+
+func (*GetResponse_Brand) isGetResponse_Item() {}
+
+func (*GetResponse_Component) isGetResponse_Item() {}
+
+func (*GetResponse_Country) isGetResponse_Item() {}
+
+func (*GetResponse_Gender) isGetResponse_Item() {}
+
+func (*GetResponse_Group) isGetResponse_Item() {}
+
+func (*GetResponse_Note) isGetResponse_Item() {}
+
+func (*GetResponse_Season) isGetResponse_Item() {}
+
+func (*GetResponse_TimeOfDay) isGetResponse_Item() {}
+
+func (*GetResponse_Type) isGetResponse_Item() {}
+
+func (*GetResponse_Perfumer) isGetResponse_Item() {}
+
+func (*GetResponse_Tag) isGetResponse_Item() {}
+
+var File_objects_proto protoreflect.FileDescriptor
+
+var file_objects_proto_rawDesc = []byte{
+	0x0a, 0x0d, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x0a, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x22, 0x44, 0x0a, 0x04, 0x4c,
+	0x69, 0x6e, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x72, 0x65, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x68, 0x72, 0x65, 0x66, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x65, 0x6c, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x72, 0x65, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74,
+	0x68, 0x6f, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f,
+	0x64, 0x22, 0xa3, 0x07, 0x0a, 0x0a, 0x50, 0x65, 0x72, 0x66, 0x75, 0x6d, 0x49, 0x6e, 0x66, 0x6f,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x64, 0x65,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x64,
+	0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a,
+	0x04, 0x79, 0x65, 0x61, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x79, 0x65, 0x61,
+	0x72, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x72, 0x61, 0x6e, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x72, 0x61, 0x6e, 0x64, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a,
+	0x62, 0x72, 0x61, 0x6e, 0x64, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x62, 0x72, 0x61, 0x6e, 0x64, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x67,
+	0x65, 0x6e, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x67, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x67, 0x65, 0x6e, 0x64,
+	0x65, 0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x67,
+	0x65, 0x6e, 0x64, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x67, 0x72, 0x6f,
+	0x75, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x67, 0x72, 0x6f,
+	0x75, 0x70, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x5f, 0x69,
+	0x64, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79,
+	0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x5f, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72,
+	0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x5f,
+	0x69, 0x64, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x5f, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x73, 0x6f, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x10,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x73, 0x6f, 0x64, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09,
+	0x74, 0x73, 0x6f, 0x64, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x74, 0x73, 0x6f, 0x64, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x79, 0x70,
+	0x65, 0x5f, 0x69, 0x64, 0x18, 0x12, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x79, 0x70, 0x65,
+	0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x13, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x79, 0x70, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12,
+	0x19, 0x0a, 0x08, 0x73, 0x74, 0x61, 0x72, 0x73, 0x5f, 0x69, 0x64, 0x18, 0x14, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x73, 0x74, 0x61, 0x72, 0x73, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x73, 0x68,
+	0x6f, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x15, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x68, 0x6f,
+	0x70, 0x49, 0x64, 0x12, 0x26, 0x0a, 0x05, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x18, 0x16, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x4c, 0x69, 0x6e, 0x6b, 0x52, 0x05, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x12, 0x22, 0x0a, 0x0d, 0x73,
+	0x6d, 0x61, 0x6c, 0x6c, 0x5f, 0x69, 0x6d, 0x67, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x17, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x73, 0x6d, 0x61, 0x6c, 0x6c, 0x49, 0x6d, 0x67, 0x55, 0x72, 0x6c, 0x12,
+	0x22, 0x0a, 0x0d, 0x6c, 0x61, 0x72, 0x67, 0x65, 0x5f, 0x69, 0x6d, 0x67, 0x5f, 0x75, 0x72, 0x6c,
+	0x18, 0x18, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6c, 0x61, 0x72, 0x67, 0x65, 0x49, 0x6d, 0x67,
+	0x55, 0x72, 0x6c, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64,
+	0x18, 0x19, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x49, 0x64,
+	0x12, 0x27, 0x0a, 0x0f, 0x66, 0x61, 0x6d, 0x69, 0x6c, 0x79, 0x5f, 0x72, 0x65, 0x6c, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x66, 0x61, 0x6d, 0x69, 0x6c,
+	0x79, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x65, 0x72,
+	0x66, 0x75, 0x6d, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x1b, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x0b, 0x70, 0x65, 0x72, 0x66, 0x75, 0x6d, 0x65, 0x72, 0x49, 0x64, 0x73, 0x12, 0x1d, 0x0a, 0x0a,
+	0x73, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x1c, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x09, 0x73, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x49, 0x64, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x74,
+	0x73, 0x6f, 0x64, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x1d, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x74,
+	0x73, 0x6f, 0x64, 0x49, 0x64, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x61, 0x67, 0x5f, 0x69, 0x64,
+	0x73, 0x18, 0x1e, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x74, 0x61, 0x67, 0x49, 0x64, 0x73, 0x12,
+	0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x1f, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0xe6, 0x01, 0x0a, 0x0d, 0x43, 0x6f, 0x6d, 0x70,
+	0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6d,
+	0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x25, 0x0a, 0x0e,
+	0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x26, 0x0a, 0x05, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x4c, 0x69, 0x6e, 0x6b, 0x52, 0x05, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x12, 0x21, 0x0a, 0x09, 0x69,
+	0x6e, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00,
+	0x52, 0x09, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x79, 0x88, 0x01, 0x01, 0x12, 0x23,
+	0x0a, 0x0a, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x01, 0x48, 0x01, 0x52, 0x0a, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67, 0x65,
+	0x88, 0x01, 0x01, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x74,
+	0x79, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67, 0x65,
+	0x22, 0xfe, 0x01, 0x0a, 0x08, 0x4e, 0x6f, 0x74, 0x65, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x17, 0x0a,
+	0x07, 0x6e, 0x6f, 0x74, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x6e, 0x6f, 0x74, 0x65, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x6f, 0x74, 0x65, 0x5f, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x6f, 0x74, 0x65, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x49, 0x74,
+	0x65, 0x6d, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x26,
+	0x0a, 0x05, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e,
+	0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x52,
+	0x05, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e,
+	0x65, 0x6e, 0x74, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0e, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12,
+	0x14, 0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x6c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x22, 0x96, 0x01, 0x0a, 0x11, 0x50, 0x65, 0x72, 0x66, 0x75, 0x6d, 0x43, 0x6f, 0x6d, 0x70,
+	0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2a, 0x0a, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x50, 0x65, 0x72, 0x66, 0x75, 0x6d, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x04, 0x69,
+	0x6e, 0x66, 0x6f, 0x12, 0x2a, 0x0a, 0x05, 0x6e, 0x6f, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x4e, 0x6f, 0x74, 0x65, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x05, 0x6e, 0x6f, 0x74, 0x65, 0x73, 0x12,
+	0x29, 0x0a, 0x10, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65,
+	0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x74, 0x6f, 0x74, 0x61, 0x6c,
+	0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x22, 0xc0, 0x01, 0x0a, 0x05, 0x42,
+	0x72, 0x61, 0x6e, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x65, 0x72, 0x66,
+	0x75, 0x6d, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0c, 0x70, 0x65, 0x72, 0x66, 0x75, 0x6d, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x26, 0x0a,
+	0x05, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6f,
+	0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x52, 0x05,
+	0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x12, 0x22, 0x0a, 0x0d, 0x73, 0x6d, 0x61, 0x6c, 0x6c, 0x5f, 0x69,
+	0x6d, 0x67, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x6d,
+	0x61, 0x6c, 0x6c, 0x49, 0x6d, 0x67, 0x55, 0x72, 0x6c, 0x12, 0x22, 0x0a, 0x0d, 0x6c, 0x61, 0x72,
+	0x67, 0x65, 0x5f, 0x69, 0x6d, 0x67, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x6c, 0x61, 0x72, 0x67, 0x65, 0x49, 0x6d, 0x67, 0x55, 0x72, 0x6c, 0x22, 0xc4, 0x01,
+	0x0a, 0x09, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x23, 0x0a, 0x0d, 0x70, 0x65, 0x72, 0x66, 0x75, 0x6d, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x70, 0x65, 0x72, 0x66, 0x75, 0x6d, 0x73, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x26, 0x0a, 0x05, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x52, 0x05, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x12, 0x22, 0x0a, 0x0d,
+	0x73, 0x6d, 0x61, 0x6c, 0x6c, 0x5f, 0x69, 0x6d, 0x67, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x6d, 0x61, 0x6c, 0x6c, 0x49, 0x6d, 0x67, 0x55, 0x72, 0x6c,
+	0x12, 0x22, 0x0a, 0x0d, 0x6c, 0x61, 0x72, 0x67, 0x65, 0x5f, 0x69, 0x6d, 0x67, 0x5f, 0x75, 0x72,
+	0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6c, 0x61, 0x72, 0x67, 0x65, 0x49, 0x6d,
+	0x67, 0x55, 0x72, 0x6c, 0x22, 0xc2, 0x01, 0x0a, 0x07, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x65, 0x72, 0x66, 0x75, 0x6d, 0x73, 0x5f,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x70, 0x65, 0x72,
+	0x66, 0x75, 0x6d, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x26, 0x0a, 0x05, 0x6c, 0x69, 0x6e,
+	0x6b, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63,
+	0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x52, 0x05, 0x6c, 0x69, 0x6e, 0x6b,
+	0x73, 0x12, 0x22, 0x0a, 0x0d, 0x73, 0x6d, 0x61, 0x6c, 0x6c, 0x5f, 0x69, 0x6d, 0x67, 0x5f, 0x75,
+	0x72, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x6d, 0x61, 0x6c, 0x6c, 0x49,
+	0x6d, 0x67, 0x55, 0x72, 0x6c, 0x12, 0x22, 0x0a, 0x0d, 0x6c, 0x61, 0x72, 0x67, 0x65, 0x5f, 0x69,
+	0x6d, 0x67, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6c, 0x61,
+	0x72, 0x67, 0x65, 0x49, 0x6d, 0x67, 0x55, 0x72, 0x6c, 0x22, 0xc1, 0x01, 0x0a, 0x06, 0x47, 0x65,
+	0x6e, 0x64, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x65, 0x72, 0x66,
+	0x75, 0x6d, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0c, 0x70, 0x65, 0x72, 0x66, 0x75, 0x6d, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x26, 0x0a,
+	0x05, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6f,
+	0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x52, 0x05,
+	0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x12, 0x22, 0x0a, 0x0d, 0x73, 0x6d, 0x61, 0x6c, 0x6c, 0x5f, 0x69,
+	0x6d, 0x67, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x6d,
+	0x61, 0x6c, 0x6c, 0x49, 0x6d, 0x67, 0x55, 0x72, 0x6c, 0x12, 0x22, 0x0a, 0x0d, 0x6c, 0x61, 0x72,
+	0x67, 0x65, 0x5f, 0x69, 0x6d, 0x67, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x6c, 0x61, 0x72, 0x67, 0x65, 0x49, 0x6d, 0x67, 0x55, 0x72, 0x6c, 0x22, 0xc0, 0x01,
+	0x0a, 0x05, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x70,
+	0x65, 0x72, 0x66, 0x75, 0x6d, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0c, 0x70, 0x65, 0x72, 0x66, 0x75, 0x6d, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x12, 0x26, 0x0a, 0x05, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x10, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x6e,
+	0x6b, 0x52, 0x05, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x12, 0x22, 0x0a, 0x0d, 0x73, 0x6d, 0x61, 0x6c,
+	0x6c, 0x5f, 0x69, 0x6d, 0x67, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x73, 0x6d, 0x61, 0x6c, 0x6c, 0x49, 0x6d, 0x67, 0x55, 0x72, 0x6c, 0x12, 0x22, 0x0a, 0x0d,
+	0x6c, 0x61, 0x72, 0x67, 0x65, 0x5f, 0x69, 0x6d, 0x67, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x6c, 0x61, 0x72, 0x67, 0x65, 0x49, 0x6d, 0x67, 0x55, 0x72, 0x6c,
+	0x22, 0xbf, 0x01, 0x0a, 0x04, 0x4e, 0x6f, 0x74, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x23, 0x0a,
+	0x0d, 0x70, 0x65, 0x72, 0x66, 0x75, 0x6d, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x70, 0x65, 0x72, 0x66, 0x75, 0x6d, 0x73, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x12, 0x26, 0x0a, 0x05, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x10, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c,
+	0x69, 0x6e, 0x6b, 0x52, 0x05, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x12, 0x22, 0x0a, 0x0d, 0x73, 0x6d,
+	0x61, 0x6c, 0x6c, 0x5f, 0x69, 0x6d, 0x67, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x73, 0x6d, 0x61, 0x6c, 0x6c, 0x49, 0x6d, 0x67, 0x55, 0x72, 0x6c, 0x12, 0x22,
+	0x0a, 0x0d, 0x6c, 0x61, 0x72, 0x67, 0x65, 0x5f, 0x69, 0x6d, 0x67, 0x5f, 0x75, 0x72, 0x6c, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6c, 0x61, 0x72, 0x67, 0x65, 0x49, 0x6d, 0x67, 0x55,
+	0x72, 0x6c, 0x22, 0xc1, 0x01, 0x0a, 0x06, 0x53, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x65, 0x72, 0x66, 0x75, 0x6d, 0x73, 0x5f, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x70, 0x65, 0x72, 0x66, 0x75, 0x6d,
+	0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x26, 0x0a, 0x05, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x52, 0x05, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x12, 0x22,
+	0x0a, 0x0d, 0x73, 0x6d, 0x61, 0x6c, 0x6c, 0x5f, 0x69, 0x6d, 0x67, 0x5f, 0x75, 0x72, 0x6c, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x6d, 0x61, 0x6c, 0x6c, 0x49, 0x6d, 0x67, 0x55,
+	0x72, 0x6c, 0x12, 0x22, 0x0a, 0x0d, 0x6c, 0x61, 0x72, 0x67, 0x65, 0x5f, 0x69, 0x6d, 0x67, 0x5f,
+	0x75, 0x72, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6c, 0x61, 0x72, 0x67, 0x65,
+	0x49, 0x6d, 0x67, 0x55, 0x72, 0x6c, 0x22, 0xc4, 0x01, 0x0a, 0x09, 0x54, 0x69, 0x6d, 0x65, 0x4f,
+	0x66, 0x44, 0x61, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x65, 0x72, 0x66,
+	0x75, 0x6d, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0c, 0x70, 0x65, 0x72, 0x66, 0x75, 0x6d, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x26, 0x0a,
+	0x05, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6f,
+	0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x52, 0x05,
+	0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x12, 0x22, 0x0a, 0x0d, 0x73, 0x6d, 0x61, 0x6c, 0x6c, 0x5f, 0x69,
+	0x6d, 0x67, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x6d,
+	0x61, 0x6c, 0x6c, 0x49, 0x6d, 0x67, 0x55, 0x72, 0x6c, 0x12, 0x22, 0x0a, 0x0d, 0x6c, 0x61, 0x72,
+	0x67, 0x65, 0x5f, 0x69, 0x6d, 0x67, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x6c, 0x61, 0x72, 0x67, 0x65, 0x49, 0x6d, 0x67, 0x55, 0x72, 0x6c, 0x22, 0xbf, 0x01,
+	0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x65,
+	0x72, 0x66, 0x75, 0x6d, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0c, 0x70, 0x65, 0x72, 0x66, 0x75, 0x6d, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12,
+	0x26, 0x0a, 0x05, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10,
+	0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x6e, 0x6b,
+	0x52, 0x05, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x12, 0x22, 0x0a, 0x0d, 0x73, 0x6d, 0x61, 0x6c, 0x6c,
+	0x5f, 0x69, 0x6d, 0x67, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x73, 0x6d, 0x61, 0x6c, 0x6c, 0x49, 0x6d, 0x67, 0x55, 0x72, 0x6c, 0x12, 0x22, 0x0a, 0x0d, 0x6c,
+	0x61, 0x72, 0x67, 0x65, 0x5f, 0x69, 0x6d, 0x67, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x6c, 0x61, 0x72, 0x67, 0x65, 0x49, 0x6d, 0x67, 0x55, 0x72, 0x6c, 0x22,
+	0xc3, 0x01, 0x0a, 0x08, 0x50, 0x65, 0x72, 0x66, 0x75, 0x6d, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x23, 0x0a, 0x0d, 0x70, 0x65, 0x72, 0x66, 0x75, 0x6d, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x70, 0x65, 0x72, 0x66, 0x75, 0x6d, 0x73,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x26, 0x0a, 0x05, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x52, 0x05, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x12, 0x22, 0x0a,
+	0x0d, 0x73, 0x6d, 0x61, 0x6c, 0x6c, 0x5f, 0x69, 0x6d, 0x67, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x6d, 0x61, 0x6c, 0x6c, 0x49, 0x6d, 0x67, 0x55, 0x72,
+	0x6c, 0x12, 0x22, 0x0a, 0x0d, 0x6c, 0x61, 0x72, 0x67, 0x65, 0x5f, 0x69, 0x6d, 0x67, 0x5f, 0x75,
+	0x72, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6c, 0x61, 0x72, 0x67, 0x65, 0x49,
+	0x6d, 0x67, 0x55, 0x72, 0x6c, 0x22, 0x8a, 0x01, 0x0a, 0x03, 0x54, 0x61, 0x67, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x65, 0x72, 0x66, 0x75, 0x6d, 0x73,
+	0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x70, 0x65,
+	0x72, 0x66, 0x75, 0x6d, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x26, 0x0a, 0x05, 0x6c, 0x69,
+	0x6e, 0x6b, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6f, 0x62, 0x6a, 0x65,
+	0x63, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x52, 0x05, 0x6c, 0x69, 0x6e,
+	0x6b, 0x73, 0x22, 0x7d, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6c, 0x69,
+	0x6d, 0x69, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x03, 0x69, 0x64, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x65,
+	0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x65,
+	0x73, 0x22, 0x30, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b,
+	0x69, 0x6e, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x69, 0x64, 0x22, 0x9d, 0x01, 0x0a, 0x0d, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x65,
+	0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12,
+	0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x18, 0x0a,
+	0x07, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07,
+	0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x65, 0x73, 0x22, 0x22, 0x0a, 0x0c, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x22, 0x25, 0x0a, 0x0d, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x8d,
+	0x05, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05,
+	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x61,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x39, 0x0a, 0x0c, 0x70, 0x65, 0x72, 0x66, 0x75, 0x6d, 0x73,
+	0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6f, 0x62,
+	0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x72, 0x66, 0x75, 0x6d, 0x49,
+	0x6e, 0x66, 0x6f, 0x52, 0x0b, 0x70, 0x65, 0x72, 0x66, 0x75, 0x6d, 0x73, 0x49, 0x6e, 0x66, 0x6f,
+	0x12, 0x29, 0x0a, 0x06, 0x62, 0x72, 0x61, 0x6e, 0x64, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x11, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x72,
+	0x61, 0x6e, 0x64, 0x52, 0x06, 0x62, 0x72, 0x61, 0x6e, 0x64, 0x73, 0x12, 0x35, 0x0a, 0x0a, 0x63,
+	0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x15, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d,
+	0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e,
+	0x74, 0x73, 0x12, 0x31, 0x0a, 0x09, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18,
+	0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x2c, 0x0a, 0x07, 0x67, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x73,
+	0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x52, 0x07, 0x67, 0x65, 0x6e, 0x64,
+	0x65, 0x72, 0x73, 0x12, 0x29, 0x0a, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18, 0x09, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x26,
+	0x0a, 0x05, 0x6e, 0x6f, 0x74, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e,
+	0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x6f, 0x74, 0x65, 0x52,
+	0x05, 0x6e, 0x6f, 0x74, 0x65, 0x73, 0x12, 0x2c, 0x0a, 0x07, 0x73, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x52, 0x07, 0x73, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x73, 0x12, 0x37, 0x0a, 0x0c, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x5f, 0x6f, 0x66,
+	0x5f, 0x64, 0x61, 0x79, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6f, 0x62, 0x6a,
+	0x65, 0x63, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x4f, 0x66, 0x44, 0x61,
+	0x79, 0x52, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x4f, 0x66, 0x44, 0x61, 0x79, 0x12, 0x26, 0x0a,
+	0x05, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6f,
+	0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x05,
+	0x74, 0x79, 0x70, 0x65, 0x73, 0x12, 0x32, 0x0a, 0x09, 0x70, 0x65, 0x72, 0x66, 0x75, 0x6d, 0x65,
+	0x72, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63,
+	0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x72, 0x66, 0x75, 0x6d, 0x65, 0x72, 0x52, 0x09,
+	0x70, 0x65, 0x72, 0x66, 0x75, 0x6d, 0x65, 0x72, 0x73, 0x12, 0x23, 0x0a, 0x04, 0x74, 0x61, 0x67,
+	0x73, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x67, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x22, 0xd4,
+	0x04, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x41,
+	0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x50, 0x65, 0x72, 0x66, 0x75, 0x6d, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x48, 0x00, 0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x29, 0x0a, 0x05, 0x62, 0x72, 0x61, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x11, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x72,
+	0x61, 0x6e, 0x64, 0x48, 0x00, 0x52, 0x05, 0x62, 0x72, 0x61, 0x6e, 0x64, 0x12, 0x35, 0x0a, 0x09,
+	0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x15, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d,
+	0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e,
+	0x65, 0x6e, 0x74, 0x12, 0x2f, 0x0a, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x48, 0x00, 0x52, 0x07, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x2c, 0x0a, 0x06, 0x67, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x47, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x48, 0x00, 0x52, 0x06, 0x67, 0x65, 0x6e, 0x64,
+	0x65, 0x72, 0x12, 0x29, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x11, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47,
+	0x72, 0x6f, 0x75, 0x70, 0x48, 0x00, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x26, 0x0a,
+	0x04, 0x6e, 0x6f, 0x74, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6f, 0x62,
+	0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x6f, 0x74, 0x65, 0x48, 0x00, 0x52,
+	0x04, 0x6e, 0x6f, 0x74, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x06, 0x73, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x12, 0x37, 0x0a, 0x0b, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x6f, 0x66, 0x5f, 0x64,
+	0x61, 0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63,
+	0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x4f, 0x66, 0x44, 0x61, 0x79, 0x48,
+	0x00, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x4f, 0x66, 0x44, 0x61, 0x79, 0x12, 0x26, 0x0a, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6f, 0x62, 0x6a,
+	0x65, 0x63, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x48, 0x00, 0x52, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x12, 0x32, 0x0a, 0x08, 0x70, 0x65, 0x72, 0x66, 0x75, 0x6d, 0x65, 0x72,
+	0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x72, 0x66, 0x75, 0x6d, 0x65, 0x72, 0x48, 0x00, 0x52, 0x08,
+	0x70, 0x65, 0x72, 0x66, 0x75, 0x6d, 0x65, 0x72, 0x12, 0x23, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18,
+	0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x54, 0x61, 0x67, 0x48, 0x00, 0x52, 0x03, 0x74, 0x61, 0x67, 0x42, 0x06, 0x0a,
+	0x04, 0x69, 0x74, 0x65, 0x6d, 0x32, 0xf9, 0x01, 0x0a, 0x07, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74,
+	0x73, 0x12, 0x39, 0x0a, 0x04, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x17, 0x2e, 0x6f, 0x62, 0x6a, 0x65,
+	0x63, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x18, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x36, 0x0a, 0x03,
+	0x47, 0x65, 0x74, 0x12, 0x16, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x6f, 0x62,
+	0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3d, 0x0a, 0x06, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x19,
+	0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x61, 0x72,
+	0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x6f, 0x62, 0x6a, 0x65,
+	0x63, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x05, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x18, 0x2e, 0x6f,
+	0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x42, 0x30, 0x5a, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x72, 0x70, 0x69, 0x73, 0x6b, 0x75, 0x6e, 0x2f, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2f,
+	0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x70, 0x62, 0x3b, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74,
+	0x73, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_objects_proto_rawDescOnce sync.Once
+	file_objects_proto_rawDescData = file_objects_proto_rawDesc
+)
+
+func file_objects_proto_rawDescGZIP() []byte {
+	file_objects_proto_rawDescOnce.Do(func() {
+		file_objects_proto_rawDescData = protoimpl.X.CompressGZIP(file_objects_proto_rawDescData)
+	})
+	return file_objects_proto_rawDescData
+}
+
+var file_objects_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_objects_proto_goTypes = []any{
+	(*Link)(nil),              // 0: objects.v1.Link
+	(*PerfumInfo)(nil),        // 1: objects.v1.PerfumInfo
+	(*ComponentItem)(nil),     // 2: objects.v1.ComponentItem
+	(*NoteItem)(nil),          // 3: objects.v1.NoteItem
+	(*PerfumComposition)(nil), // 4: objects.v1.PerfumComposition
+	(*Brand)(nil),             // 5: objects.v1.Brand
+	(*Component)(nil),         // 6: objects.v1.Component
+	(*Country)(nil),           // 7: objects.v1.Country
+	(*Gender)(nil),            // 8: objects.v1.Gender
+	(*Group)(nil),             // 9: objects.v1.Group
+	(*Note)(nil),              // 10: objects.v1.Note
+	(*Season)(nil),            // 11: objects.v1.Season
+	(*TimeOfDay)(nil),         // 12: objects.v1.TimeOfDay
+	(*Type)(nil),              // 13: objects.v1.Type
+	(*Perfumer)(nil),          // 14: objects.v1.Perfumer
+	(*Tag)(nil),               // 15: objects.v1.Tag
+	(*ListRequest)(nil),       // 16: objects.v1.ListRequest
+	(*GetRequest)(nil),        // 17: objects.v1.GetRequest
+	(*SearchRequest)(nil),     // 18: objects.v1.SearchRequest
+	(*CountRequest)(nil),      // 19: objects.v1.CountRequest
+	(*CountResponse)(nil),     // 20: objects.v1.CountResponse
+	(*ListResponse)(nil),      // 21: objects.v1.ListResponse
+	(*GetResponse)(nil),       // 22: objects.v1.GetResponse
+}
+var file_objects_proto_depIdxs = []int32{
+	0,  // 0: objects.v1.PerfumInfo.links:type_name -> objects.v1.Link
+	0,  // 1: objects.v1.ComponentItem.links:type_name -> objects.v1.Link
+	2,  // 2: objects.v1.NoteItem.components:type_name -> objects.v1.ComponentItem
+	0,  // 3: objects.v1.NoteItem.links:type_name -> objects.v1.Link
+	1,  // 4: objects.v1.PerfumComposition.info:type_name -> objects.v1.PerfumInfo
+	3,  // 5: objects.v1.PerfumComposition.notes:type_name -> objects.v1.NoteItem
+	0,  // 6: objects.v1.Brand.links:type_name -> objects.v1.Link
+	0,  // 7: objects.v1.Component.links:type_name -> objects.v1.Link
+	0,  // 8: objects.v1.Country.links:type_name -> objects.v1.Link
+	0,  // 9: objects.v1.Gender.links:type_name -> objects.v1.Link
+	0,  // 10: objects.v1.Group.links:type_name -> objects.v1.Link
+	0,  // 11: objects.v1.Note.links:type_name -> objects.v1.Link
+	0,  // 12: objects.v1.Season.links:type_name -> objects.v1.Link
+	0,  // 13: objects.v1.TimeOfDay.links:type_name -> objects.v1.Link
+	0,  // 14: objects.v1.Type.links:type_name -> objects.v1.Link
+	0,  // 15: objects.v1.Perfumer.links:type_name -> objects.v1.Link
+	0,  // 16: objects.v1.Tag.links:type_name -> objects.v1.Link
+	1,  // 17: objects.v1.ListResponse.perfums_info:type_name -> objects.v1.PerfumInfo
+	5,  // 18: objects.v1.ListResponse.brands:type_name -> objects.v1.Brand
+	6,  // 19: objects.v1.ListResponse.components:type_name -> objects.v1.Component
+	7,  // 20: objects.v1.ListResponse.countries:type_name -> objects.v1.Country
+	8,  // 21: objects.v1.ListResponse.genders:type_name -> objects.v1.Gender
+	9,  // 22: objects.v1.ListResponse.groups:type_name -> objects.v1.Group
+	10, // 23: objects.v1.ListResponse.notes:type_name -> objects.v1.Note
+	11, // 24: objects.v1.ListResponse.seasons:type_name -> objects.v1.Season
+	12, // 25: objects.v1.ListResponse.times_of_day:type_name -> objects.v1.TimeOfDay
+	13, // 26: objects.v1.ListResponse.types:type_name -> objects.v1.Type
+	14, // 27: objects.v1.ListResponse.perfumers:type_name -> objects.v1.Perfumer
+	15, // 28: objects.v1.ListResponse.tags:type_name -> objects.v1.Tag
+	4,  // 29: objects.v1.GetResponse.composition:type_name -> objects.v1.PerfumComposition
+	5,  // 30: objects.v1.GetResponse.brand:type_name -> objects.v1.Brand
+	6,  // 31: objects.v1.GetResponse.component:type_name -> objects.v1.Component
+	7,  // 32: objects.v1.GetResponse.country:type_name -> objects.v1.Country
+	8,  // 33: objects.v1.GetResponse.gender:type_name -> objects.v1.Gender
+	9,  // 34: objects.v1.GetResponse.group:type_name -> objects.v1.Group
+	10, // 35: objects.v1.GetResponse.note:type_name -> objects.v1.Note
+	11, // 36: objects.v1.GetResponse.season:type_name -> objects.v1.Season
+	12, // 37: objects.v1.GetResponse.time_of_day:type_name -> objects.v1.TimeOfDay
+	13, // 38: objects.v1.GetResponse.type:type_name -> objects.v1.Type
+	14, // 39: objects.v1.GetResponse.perfumer:type_name -> objects.v1.Perfumer
+	15, // 40: objects.v1.GetResponse.tag:type_name -> objects.v1.Tag
+	16, // 41: objects.v1.Objects.List:input_type -> objects.v1.ListRequest
+	17, // 42: objects.v1.Objects.Get:input_type -> objects.v1.GetRequest
+	18, // 43: objects.v1.Objects.Search:input_type -> objects.v1.SearchRequest
+	19, // 44: objects.v1.Objects.Count:input_type -> objects.v1.CountRequest
+	21, // 45: objects.v1.Objects.List:output_type -> objects.v1.ListResponse
+	22, // 46: objects.v1.Objects.Get:output_type -> objects.v1.GetResponse
+	21, // 47: objects.v1.Objects.Search:output_type -> objects.v1.ListResponse
+	20, // 48: objects.v1.Objects.Count:output_type -> objects.v1.CountResponse
+	45, // [45:49] is the sub-list for method output_type
+	41, // [41:45] is the sub-list for method input_type
+	41, // [41:41] is the sub-list for extension type_name
+	41, // [41:41] is the sub-list for extension extendee
+	0,  // [0:41] is the sub-list for field type_name
+}
+
+func init() { file_objects_proto_init() }
+func file_objects_proto_init() {
+	if File_objects_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_objects_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*Link); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_objects_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*PerfumInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_objects_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*ComponentItem); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_objects_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*NoteItem); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_objects_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*PerfumComposition); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_objects_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*Brand); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_objects_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*Component); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_objects_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*Country); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_objects_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*Gender); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_objects_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*Group); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_objects_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*Note); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_objects_proto_msgTypes[11].Exporter = func(v any, i int) any {
+			switch v := v.(*Season); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_objects_proto_msgTypes[12].Exporter = func(v any, i int) any {
+			switch v := v.(*TimeOfDay); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_objects_proto_msgTypes[13].Exporter = func(v any, i int) any {
+			switch v := v.(*Type); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_objects_proto_msgTypes[14].Exporter = func(v any, i int) any {
+			switch v := v.(*Perfumer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_objects_proto_msgTypes[15].Exporter = func(v any, i int) any {
+			switch v := v.(*Tag); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_objects_proto_msgTypes[16].Exporter = func(v any, i int) any {
+			switch v := v.(*ListRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_objects_proto_msgTypes[17].Exporter = func(v any, i int) any {
+			switch v := v.(*GetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_objects_proto_msgTypes[18].Exporter = func(v any, i int) any {
+			switch v := v.(*SearchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_objects_proto_msgTypes[19].Exporter = func(v any, i int) any {
+			switch v := v.(*CountRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_objects_proto_msgTypes[20].Exporter = func(v any, i int) any {
+			switch v := v.(*CountResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_objects_proto_msgTypes[21].Exporter = func(v any, i int) any {
+			switch v := v.(*ListResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_objects_proto_msgTypes[22].Exporter = func(v any, i int) any {
+			switch v := v.(*GetResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_objects_proto_msgTypes[2].OneofWrappers = []any{}
+	file_objects_proto_msgTypes[22].OneofWrappers = []any{
+		(*GetResponse_Composition)(nil),
+		(*GetResponse_Brand)(nil),
+		(*GetResponse_Component)(nil),
+		(*GetResponse_Country)(nil),
+		(*GetResponse_Gender)(nil),
+		(*GetResponse_Group)(nil),
+		(*GetResponse_Note)(nil),
+		(*GetResponse_Season)(nil),
+		(*GetResponse_TimeOfDay)(nil),
+		(*GetResponse_Type)(nil),
+		(*GetResponse_Perfumer)(nil),
+		(*GetResponse_Tag)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_objects_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   23,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_objects_proto_goTypes,
+		DependencyIndexes: file_objects_proto_depIdxs,
+		MessageInfos:      file_objects_proto_msgTypes,
+	}.Build()
+	File_objects_proto = out.File
+	file_objects_proto_rawDesc = nil
+	file_objects_proto_goTypes = nil
+	file_objects_proto_depIdxs = nil
+}
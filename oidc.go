@@ -0,0 +1,287 @@
+package objects
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var ErrUnknownKey = errors.New("unknown signing key")
+
+const (
+	defaultClockSkew = time.Minute
+	// jwksMinRefresh limits how often an unknown kid makes JwksSource fetch
+	// the key set again.
+	jwksMinRefresh = time.Minute
+)
+
+// JwksKey is a single JSON Web Key; only RSA keys are used.
+type JwksKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// Audience is the aud claim, which is either a single string or a list.
+type Audience []string
+
+// Has reports whether aud is one of the audiences.
+func (a Audience) Has(aud string) bool {
+	for _, v := range a {
+		if v == aud {
+			return true
+		}
+	}
+	return false
+}
+
+func (a Audience) MarshalJSON() ([]byte, error) {
+	if len(a) == 1 {
+		return json.Marshal(a[0])
+	}
+	return json.Marshal([]string(a))
+}
+
+func (a *Audience) UnmarshalJSON(data []byte) error {
+	var one string
+	if err := json.Unmarshal(data, &one); err == nil {
+		*a = Audience{one}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*a = Audience(list)
+	return nil
+}
+
+// Jwks ...
+type Jwks struct {
+	Keys []JwksKey `json:"keys"`
+}
+
+// ParseJwks returns the RSA signing keys of a JWKS document by kid.
+func ParseJwks(data []byte) (map[string]*rsa.PublicKey, error) {
+	jwks := Jwks{}
+	if err := json.Unmarshal(data, &jwks); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]*rsa.PublicKey)
+	for _, key := range jwks.Keys {
+		if key.Kty != "RSA" || (key.Use != "" && key.Use != "sig") {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(key.N)
+		if err != nil {
+			return nil, fmt.Errorf("jwks key %q: %v", key.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(key.E)
+		if err != nil {
+			return nil, fmt.Errorf("jwks key %q: %v", key.Kid, err)
+		}
+		keys[key.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+
+	return keys, nil
+}
+
+// JwksSource holds the keys of a JWKS file or URL. Keys from a URL are
+// fetched on first use and again when a token names an unknown kid.
+type JwksSource struct {
+	url    string
+	client *http.Client
+
+	mu      sync.Mutex
+	keys    map[string]*rsa.PublicKey
+	fetched time.Time
+	// err is the outcome of the last fetch
+	err error
+}
+
+func NewJwksFileSource(path string) (*JwksSource, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := ParseJwks(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &JwksSource{keys: keys}, nil
+}
+
+func NewJwksUrlSource(url string, client *http.Client) *JwksSource {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &JwksSource{url: url, client: client}
+}
+
+// Key returns the key named kid. An empty kid matches a sole key.
+func (s *JwksSource) Key(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if key := s.lookup(kid); key != nil {
+		return key, nil
+	}
+	if s.url == "" {
+		return nil, ErrUnknownKey
+	}
+	if !s.fetched.IsZero() && time.Since(s.fetched) < jwksMinRefresh {
+		if s.err != nil {
+			return nil, s.err
+		}
+		return nil, ErrUnknownKey
+	}
+
+	// a failed fetch counts too, so a provider that is down is not asked
+	// again for every token
+	s.fetched = time.Now()
+	s.err = s.fetch(ctx)
+	if s.err != nil {
+		return nil, s.err
+	}
+	if key := s.lookup(kid); key != nil {
+		return key, nil
+	}
+	return nil, ErrUnknownKey
+}
+
+func (s *JwksSource) lookup(kid string) *rsa.PublicKey {
+	if kid == "" && len(s.keys) == 1 {
+		for _, key := range s.keys {
+			return key
+		}
+	}
+	return s.keys[kid]
+}
+
+func (s *JwksSource) fetch(ctx context.Context) error {
+	req, err := http.NewRequest("GET", s.url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("jwks %s: %s", s.url, resp.Status)
+	}
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	keys, err := ParseJwks(data)
+	if err != nil {
+		return err
+	}
+
+	s.keys = keys
+	return nil
+}
+
+// OidcConfig ...
+type OidcConfig struct {
+	Issuer   string
+	Audience string
+	// Skew is the clock difference tolerated on exp and iat, a minute by
+	// default.
+	Skew time.Duration
+	// Now is used in place of time.Now when set.
+	Now func() time.Time
+}
+
+// OidcVerifier validates ID tokens of a third-party issuer and maps them to
+// local users.
+type OidcVerifier struct {
+	config OidcConfig
+	keys   *JwksSource
+	users  UserStore
+}
+
+func NewOidcVerifier(config OidcConfig, keys *JwksSource, users UserStore) (*OidcVerifier, error) {
+	if config.Issuer == "" || config.Audience == "" || keys == nil || users == nil {
+		return nil, errors.New("invalid args")
+	}
+	if config.Skew == 0 {
+		config.Skew = defaultClockSkew
+	}
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+
+	return &OidcVerifier{config: config, keys: keys, users: users}, nil
+}
+
+// Verify checks the signature of idToken against the JWKS and its
+// iss/aud/exp/iat claims against the config.
+func (v *OidcVerifier) Verify(ctx context.Context, idToken string) (*IdTokenClaims, error) {
+	claims := &IdTokenClaims{}
+	parser := jwt.Parser{
+		ValidMethods:         []string{jwt.SigningMethodRS256.Alg()},
+		SkipClaimsValidation: true,
+	}
+	_, err := parser.ParseWithClaims(idToken, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return v.keys.Key(ctx, kid)
+	})
+	if err != nil {
+		if verr, ok := err.(*jwt.ValidationError); ok && verr.Inner == ErrUnknownKey {
+			return nil, ErrUnknownKey
+		}
+		return nil, ErrInvalidToken
+	}
+
+	if claims.Iss != v.config.Issuer || !claims.Aud.Has(v.config.Audience) || claims.Sub == "" {
+		return nil, ErrInvalidToken
+	}
+	if err := claims.validAt(v.config.Now(), v.config.Skew); err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+// User verifies idToken and returns the local user of its issuer and
+// subject, creating it on first sight. The user_id claim is not trusted:
+// users are told apart by issuer and subject only.
+func (v *OidcVerifier) User(ctx context.Context, idToken string) (*User, error) {
+	claims, err := v.Verify(ctx, idToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return userForIdentity(v.users, Identity{Issuer: claims.Iss, Subject: claims.Sub}, "", v.config.Now())
+}
+
+// Exchange implements AuthCodeExchanger for clients that log in with an
+// ID token in place of an auth_code.
+func (v *OidcVerifier) Exchange(ctx context.Context, idToken string) (Identity, error) {
+	claims, err := v.Verify(ctx, idToken)
+	if err != nil {
+		return Identity{}, err
+	}
+	return Identity{Issuer: claims.Iss, Subject: claims.Sub}, nil
+}
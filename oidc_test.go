@@ -0,0 +1,223 @@
+package objects
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/golang-jwt/jwt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testIdp serves the JWKS of its keys and signs ID tokens with them.
+type testIdp struct {
+	keys    map[string]*rsa.PrivateKey
+	fail    bool
+	fetches int32
+	server  *httptest.Server
+}
+
+func newTestIdp(t *testing.T, kids ...string) *testIdp {
+	idp := &testIdp{keys: make(map[string]*rsa.PrivateKey)}
+	for _, kid := range kids {
+		idp.addKey(t, kid)
+	}
+	idp.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&idp.fetches, 1)
+		if idp.fail {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		jwks := Jwks{}
+		for kid, key := range idp.keys {
+			jwks.Keys = append(jwks.Keys, JwksKey{
+				Kid: kid,
+				Kty: "RSA",
+				Alg: "RS256",
+				Use: "sig",
+				N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			})
+		}
+		json.NewEncoder(w).Encode(jwks)
+	}))
+	t.Cleanup(idp.server.Close)
+	return idp
+}
+
+func (idp *testIdp) addKey(t *testing.T, kid string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	idp.keys[kid] = key
+}
+
+func (idp *testIdp) sign(t *testing.T, kid string, claims *IdTokenClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(idp.keys[kid])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+var oidcNow = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func newTestVerifier(t *testing.T, idp *testIdp) (*OidcVerifier, *JwksSource) {
+	keys := NewJwksUrlSource(idp.server.URL, idp.server.Client())
+	verifier, err := NewOidcVerifier(OidcConfig{
+		Issuer:   "https://idp.test",
+		Audience: "objects",
+		Now:      func() time.Time { return oidcNow },
+	}, keys, NewMemoryUserStore())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return verifier, keys
+}
+
+func idClaims(sub, userId string, aud ...string) *IdTokenClaims {
+	return &IdTokenClaims{
+		Iss:    "https://idp.test",
+		Sub:    sub,
+		Aud:    Audience(aud),
+		Iat:    float64(oidcNow.Unix()),
+		Exp:    float64(oidcNow.Add(time.Hour).Unix()),
+		UserId: userId,
+	}
+}
+
+func TestAudienceJson(t *testing.T) {
+	tests := []struct {
+		data string
+		aud  Audience
+	}{
+		{`"objects"`, Audience{"objects"}},
+		{`["web","objects"]`, Audience{"web", "objects"}},
+	}
+	for _, test := range tests {
+		var aud Audience
+		if err := json.Unmarshal([]byte(test.data), &aud); err != nil {
+			t.Fatal(err)
+		}
+		if !aud.Has("objects") || len(aud) != len(test.aud) {
+			t.Errorf("%s decoded as %v", test.data, aud)
+		}
+		data, err := json.Marshal(aud)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != test.data {
+			t.Errorf("%v encoded as %s, want %s", aud, data, test.data)
+		}
+	}
+}
+
+func TestOidcVerify(t *testing.T) {
+	idp := newTestIdp(t, "k1")
+	verifier, _ := newTestVerifier(t, idp)
+
+	tests := []struct {
+		claims *IdTokenClaims
+		err    error
+	}{
+		{idClaims("alice", "", "objects"), nil},
+		{idClaims("alice", "", "web", "objects"), nil},
+		{idClaims("alice", "", "web"), ErrInvalidToken},
+		{idClaims("", "", "objects"), ErrInvalidToken},
+	}
+	for _, test := range tests {
+		_, err := verifier.Verify(context.Background(), idp.sign(t, "k1", test.claims))
+		if err != test.err {
+			t.Errorf("Verify(aud %v, sub %q) = %v, want %v", test.claims.Aud, test.claims.Sub, err, test.err)
+		}
+	}
+
+	expired := idClaims("alice", "", "objects")
+	expired.Exp = float64(oidcNow.Add(-defaultClockSkew - time.Second).Unix())
+	if _, err := verifier.Verify(context.Background(), idp.sign(t, "k1", expired)); err != ErrTokenExpired {
+		t.Errorf("Verify(expired) = %v, want %v", err, ErrTokenExpired)
+	}
+}
+
+func TestOidcKeyRotation(t *testing.T) {
+	idp := newTestIdp(t, "k1")
+	verifier, keys := newTestVerifier(t, idp)
+
+	if _, err := verifier.Verify(context.Background(), idp.sign(t, "k1", idClaims("alice", "", "objects"))); err != nil {
+		t.Fatal(err)
+	}
+
+	idp.addKey(t, "k2")
+	token := idp.sign(t, "k2", idClaims("alice", "", "objects"))
+	if _, err := verifier.Verify(context.Background(), token); err != ErrUnknownKey {
+		t.Fatalf("Verify(new kid) right after a fetch = %v, want %v", err, ErrUnknownKey)
+	}
+
+	keys.fetched = keys.fetched.Add(-jwksMinRefresh)
+	if _, err := verifier.Verify(context.Background(), token); err != nil {
+		t.Fatalf("Verify(new kid) after refresh = %v", err)
+	}
+	if n := atomic.LoadInt32(&idp.fetches); n != 2 {
+		t.Fatalf("jwks fetched %d times, want 2", n)
+	}
+}
+
+func TestOidcFailedFetchThrottled(t *testing.T) {
+	idp := newTestIdp(t, "k1")
+	idp.fail = true
+	verifier, keys := newTestVerifier(t, idp)
+
+	token := idp.sign(t, "k1", idClaims("alice", "", "objects"))
+	for i := 0; i < 3; i++ {
+		if _, err := verifier.Verify(context.Background(), token); err == nil {
+			t.Fatal("Verify succeeded without keys")
+		}
+	}
+	if n := atomic.LoadInt32(&idp.fetches); n != 1 {
+		t.Fatalf("jwks fetched %d times while failing, want 1", n)
+	}
+
+	idp.fail = false
+	keys.fetched = keys.fetched.Add(-jwksMinRefresh)
+	if _, err := verifier.Verify(context.Background(), token); err != nil {
+		t.Fatalf("Verify after recovery = %v", err)
+	}
+}
+
+func TestOidcUser(t *testing.T) {
+	idp := newTestIdp(t, "k1")
+	verifier, _ := newTestVerifier(t, idp)
+
+	user, err := verifier.User(context.Background(), idp.sign(t, "k1", idClaims("alice", "chosen-id", "objects")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.UserId == "chosen-id" || user.Subject != "alice" {
+		t.Fatalf("unexpected user %+v", user)
+	}
+
+	again, err := verifier.User(context.Background(), idp.sign(t, "k1", idClaims("alice", "other-id", "objects")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.UserId != user.UserId {
+		t.Fatalf("same subject mapped to %q, want %q", again.UserId, user.UserId)
+	}
+
+	bob, err := verifier.User(context.Background(), idp.sign(t, "k1", idClaims("bob", user.UserId, "objects")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bob.UserId == user.UserId {
+		t.Fatal("another subject took over the user by its user_id claim")
+	}
+}
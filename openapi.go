@@ -0,0 +1,381 @@
+package objects
+
+import (
+	"database/sql"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+)
+
+//go:generate go run ./cmd/openapi-gen -o openapi.json
+
+// openApiTypes lists every type served as JSON; their schemas are derived
+// from the json struct tags.
+var openApiTypes = []interface{}{
+	LinkV1{},
+	PerfumInfoV1{}, PerfumsInfoV1{},
+	ComponentItemV1{}, NoteItemV1{}, PerfumCompositionV1{}, PerfumsCompositionV1{},
+	BrandV1{}, BrandsV1{},
+	ComponentV1{}, ComponentsV1{},
+	CountryV1{}, CountriesV1{},
+	GenderV1{}, GendersV1{},
+	GroupV1{}, GroupsV1{},
+	NoteV1{}, NotesV1{},
+	SeasonV1{}, SeasonsV1{},
+	TimeOfDayV1{}, TimesOfDayV1{},
+	TypeV1{}, TypesV1{},
+	PerfumsSearchResultV1{}, BrandsSearchResultV1{}, ComponentsSearchResultV1{},
+	CountriesSearchResultV1{}, GroupsSearchResultV1{}, NotesSearchResultV1{},
+	BatchItemReq{}, BatchItemV1{}, BatchV1{},
+	UserReq{}, LoginReq{}, LoginResp{}, UserResp{},
+	UserPerfumReq{}, UserPerfumV1{}, UserPerfumsV1{},
+	ReviewReq{}, ReviewV1{}, ReviewsV1{}, StarsV1{}, StarsListV1{},
+	ShopV1{}, ShopsV1{}, OfferV1{}, OffersV1{}, PerfumsFilterV1{},
+	ImageRenditionV1{}, ImageV1{}, ImagesV1{},
+	TranslationReq{}, TranslationV1{}, TranslationsV1{},
+	AliasReq{}, AliasV1{}, AliasesV1{}, AliasMatchV1{},
+	MergeReq{}, MergeV1{},
+	VariantV1{}, FamilyMemberV1{}, PerfumFamilyV1{},
+	PerfumerRefV1{}, PerfumerV1{}, PerfumersV1{}, PerfumersSearchResultV1{},
+	TaxonomyItemRefV1{},
+	TagV1{}, TagsV1{}, TagCloudItemV1{}, TagCloudV1{},
+	EntityUpdateReq{}, CompositionItemReq{}, AuditChangeV1{}, AuditEntryV1{}, AuditLogV1{},
+	OutboxEventV1{}, WebhookReq{}, WebhookSubscriptionV1{}, WebhookPayloadV1{},
+}
+
+// OpenApiPath describes one GET resource reachable through LinkV1 hrefs.
+type OpenApiPath struct {
+	Path        string
+	OperationId string
+	Schema      string
+	// Relations maps the Rel of the links emitted in the response to the
+	// operation they point at and the response field holding the id.
+	Relations map[string]OpenApiRelation
+}
+
+// OpenApiRelation ...
+type OpenApiRelation struct {
+	OperationId string
+	IdField     string
+}
+
+var perfumRelations = map[string]OpenApiRelation{
+	"PerfumInfo":       {OperationId: "getPerfum", IdField: "id"},
+	"PerfumReviews":    {OperationId: "getPerfumReviews", IdField: "id"},
+	"PerfumParent":     {OperationId: "getPerfum", IdField: "parent_id"},
+	"PerfumFamily":     {OperationId: "getPerfumFamily", IdField: "id"},
+	"StarsInfo":        {OperationId: "getStars", IdField: "stars_id"},
+	"ShopInfo":         {OperationId: "getShop", IdField: "shop_id"},
+	"PerfumOffers":     {OperationId: "getPerfumOffers", IdField: "id"},
+	"BrandInfo":        {OperationId: "getBrand", IdField: "brand_id"},
+	"BrandPerfums":     {OperationId: "getBrandPerfums", IdField: "brand_id"},
+	"CountryInfo":      {OperationId: "getCountry", IdField: "country_id"},
+	"CountryPerfums":   {OperationId: "getCountryPerfums", IdField: "country_id"},
+	"GenderInfo":       {OperationId: "getGender", IdField: "gender_id"},
+	"GenderPerfums":    {OperationId: "getGenderPerfums", IdField: "gender_id"},
+	"GroupInfo":        {OperationId: "getGroup", IdField: "group_id"},
+	"GroupPerfums":     {OperationId: "getGroupPerfums", IdField: "group_id"},
+	"SeasonInfo":       {OperationId: "getSeason", IdField: "season_id"},
+	"SeasonPerfums":    {OperationId: "getSeasonPerfums", IdField: "season_id"},
+	"TimeofdayInfo":    {OperationId: "getTimeofday", IdField: "tsod_id"},
+	"TimeofdayPerfums": {OperationId: "getTimeofdayPerfums", IdField: "tsod_id"},
+	"TypeInfo":         {OperationId: "getType", IdField: "type_id"},
+	"TypePerfums":      {OperationId: "getTypePerfums", IdField: "type_id"},
+}
+
+func openApiTaxonomyPaths(kind, name, schema string) []OpenApiPath {
+	relations := map[string]OpenApiRelation{
+		name + "Info":    {OperationId: "get" + name, IdField: "id"},
+		name + "Perfums": {OperationId: "get" + name + "Perfums", IdField: "id"},
+	}
+	return []OpenApiPath{
+		{Path: "/" + kind + "/{id}", OperationId: "get" + name, Schema: schema, Relations: relations},
+		{Path: "/" + kind + "/{id}/perfums", OperationId: "get" + name + "Perfums", Schema: "PerfumsInfoV1"},
+	}
+}
+
+func openApiPaths() []OpenApiPath {
+	paths := []OpenApiPath{
+		{Path: "/perfum/{id}", OperationId: "getPerfum", Schema: "PerfumsCompositionV1", Relations: perfumRelations},
+	}
+	paths = append(paths,
+		OpenApiPath{Path: "/perfum/{id}/reviews", OperationId: "getPerfumReviews", Schema: "ReviewsV1"},
+		OpenApiPath{Path: "/perfum/{id}/offers", OperationId: "getPerfumOffers", Schema: "OffersV1"},
+		OpenApiPath{Path: "/translations/{id}", OperationId: "getTranslations", Schema: "TranslationsV1"},
+		OpenApiPath{Path: "/aliases/{id}", OperationId: "getAliases", Schema: "AliasesV1"},
+		OpenApiPath{Path: "/history/{id}", OperationId: "getHistory", Schema: "AuditLogV1"},
+		OpenApiPath{
+			Path:        "/perfum/{id}/family",
+			OperationId: "getPerfumFamily",
+			Schema:      "PerfumFamilyV1",
+			Relations: map[string]OpenApiRelation{
+				"PerfumInfo":   {OperationId: "getPerfum", IdField: "id"},
+				"PerfumParent": {OperationId: "getPerfum", IdField: "parent_id"},
+			},
+		},
+		OpenApiPath{
+			Path:        "/image/{id}",
+			OperationId: "getImage",
+			Schema:      "ImagesV1",
+			Relations: map[string]OpenApiRelation{
+				"ImageInfo": {OperationId: "getImage", IdField: "id"},
+			},
+		},
+		OpenApiPath{
+			Path:        "/stars/{id}",
+			OperationId: "getStars",
+			Schema:      "StarsListV1",
+			Relations: map[string]OpenApiRelation{
+				"StarsInfo":     {OperationId: "getStars", IdField: "id"},
+				"PerfumReviews": {OperationId: "getPerfumReviews", IdField: "perfum_id"},
+			},
+		},
+	)
+	paths = append(paths, openApiTaxonomyPaths("brand", "Brand", "BrandsV1")...)
+	paths = append(paths, openApiTaxonomyPaths("component", "Component", "ComponentsV1")...)
+	paths = append(paths, openApiTaxonomyPaths("country", "Country", "CountriesV1")...)
+	paths = append(paths, openApiTaxonomyPaths("gender", "Gender", "GendersV1")...)
+	paths = append(paths, openApiTaxonomyPaths("group", "Group", "GroupsV1")...)
+	paths = append(paths, openApiTaxonomyPaths("note", "Note", "NotesV1")...)
+	paths = append(paths, openApiTaxonomyPaths("perfumer", "Perfumer", "PerfumersV1")...)
+	paths = append(paths, openApiTaxonomyPaths("season", "Season", "SeasonsV1")...)
+	paths = append(paths, openApiTaxonomyPaths("shop", "Shop", "ShopsV1")...)
+	paths = append(paths, openApiTaxonomyPaths("tag", "Tag", "TagsV1")...)
+	paths = append(paths, openApiTaxonomyPaths("timeofday", "Timeofday", "TimesOfDayV1")...)
+	paths = append(paths, openApiTaxonomyPaths("type", "Type", "TypesV1")...)
+	paths = append(paths, OpenApiPath{
+		Path:        "/user/{id}",
+		OperationId: "getUser",
+		Schema:      "UserResp",
+		Relations: map[string]OpenApiRelation{
+			"UserInfo":    {OperationId: "getUser", IdField: "user_id"},
+			"UserPerfums": {OperationId: "getUserPerfums", IdField: "user_id"},
+		},
+	})
+	paths = append(paths, OpenApiPath{
+		Path:        "/user/{id}/perfums",
+		OperationId: "getUserPerfums",
+		Schema:      "UserPerfumsV1",
+	})
+	return paths
+}
+
+var (
+	nullStringType = reflect.TypeOf(sql.NullString{})
+	timeType       = reflect.TypeOf(time.Time{})
+	objectsPkgPath = reflect.TypeOf(LinkV1{}).PkgPath()
+)
+
+// openApiSchemas collects component schemas, adding every named type of
+// this package on first reference.
+type openApiSchemas map[string]interface{}
+
+// OpenApiSpec returns the OpenAPI 3 document describing the V1 objects.
+func OpenApiSpec() map[string]interface{} {
+	schemas := openApiSchemas{
+		"Paging": map[string]interface{}{
+			"type":     "object",
+			"required": []string{"total", "offset", "amount"},
+			"properties": map[string]interface{}{
+				"total":  map[string]interface{}{"type": "integer", "format": "int64"},
+				"offset": map[string]interface{}{"type": "integer", "format": "int64"},
+				"amount": map[string]interface{}{"type": "integer", "format": "int64"},
+			},
+		},
+	}
+	for _, v := range openApiTypes {
+		schemas.ref(reflect.TypeOf(v))
+	}
+
+	paths := map[string]interface{}{}
+	for _, p := range openApiPaths() {
+		response := map[string]interface{}{
+			"description": p.Schema,
+			"content": map[string]interface{}{
+				"application/json": map[string]interface{}{
+					"schema": openApiRef(p.Schema),
+				},
+			},
+		}
+		if len(p.Relations) > 0 {
+			links := map[string]interface{}{}
+			for rel, target := range p.Relations {
+				links[rel] = map[string]interface{}{
+					"operationId": target.OperationId,
+					"parameters": map[string]interface{}{
+						"id": "$response.body#/" + target.IdField,
+					},
+				}
+			}
+			response["links"] = links
+		}
+
+		paths[p.Path] = map[string]interface{}{
+			"get": map[string]interface{}{
+				"operationId": p.OperationId,
+				"parameters": []interface{}{
+					map[string]interface{}{
+						"name":     "id",
+						"in":       "path",
+						"required": true,
+						"schema":   map[string]interface{}{"type": "string", "format": "uuid"},
+					},
+				},
+				"responses": map[string]interface{}{
+					"200": response,
+				},
+			},
+		}
+	}
+
+	return map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":   "objects",
+			"version": "v1",
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": map[string]interface{}(schemas),
+		},
+	}
+}
+
+// OpenApiJson renders OpenApiSpec the way openapi.json is stored.
+func OpenApiJson() ([]byte, error) {
+	data, err := json.MarshalIndent(OpenApiSpec(), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+func openApiRef(name string) map[string]interface{} {
+	return map[string]interface{}{"$ref": "#/components/schemas/" + name}
+}
+
+func (schemas openApiSchemas) ref(t reflect.Type) map[string]interface{} {
+	if _, done := schemas[t.Name()]; !done {
+		// reserve the name first, the type may refer to itself
+		schemas[t.Name()] = nil
+		schemas[t.Name()] = schemas.object(t)
+	}
+	return openApiRef(t.Name())
+}
+
+// object describes t; collection wrappers are expressed as the Paging
+// envelope plus their list.
+func (schemas openApiSchemas) object(t reflect.Type) map[string]interface{} {
+	properties := map[string]interface{}{}
+	required := []string{}
+	schemas.collectFields(t, properties, &required)
+
+	paged := true
+	for _, name := range []string{"total", "offset", "amount"} {
+		if _, ok := properties[name]; !ok {
+			paged = false
+		}
+	}
+
+	schema := map[string]interface{}{"type": "object"}
+	if paged {
+		for _, name := range []string{"total", "offset", "amount"} {
+			delete(properties, name)
+		}
+		filtered := required[:0]
+		for _, name := range required {
+			if name != "total" && name != "offset" && name != "amount" {
+				filtered = append(filtered, name)
+			}
+		}
+		required = filtered
+	}
+	schema["properties"] = properties
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+
+	if paged {
+		return map[string]interface{}{
+			"allOf": []interface{}{openApiRef("Paging"), schema},
+		}
+	}
+	return schema
+}
+
+func (schemas openApiSchemas) collectFields(t reflect.Type, properties map[string]interface{}, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		if field.Anonymous && tag == "" {
+			schemas.collectFields(field.Type, properties, required)
+			continue
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+
+		name := field.Name
+		omitempty := false
+		if tag != "" {
+			parts := strings.Split(tag, ",")
+			if parts[0] != "" {
+				name = parts[0]
+			}
+			for _, opt := range parts[1:] {
+				if opt == "omitempty" {
+					omitempty = true
+				}
+			}
+		}
+
+		properties[name] = schemas.typeSchema(field.Type)
+		if !omitempty {
+			*required = append(*required, name)
+		}
+	}
+}
+
+func (schemas openApiSchemas) typeSchema(t reflect.Type) map[string]interface{} {
+	switch t {
+	case nullStringType:
+		return map[string]interface{}{"type": "string", "nullable": true}
+	case timeType:
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		schema := schemas.typeSchema(t.Elem())
+		if _, isRef := schema["$ref"]; isRef {
+			return map[string]interface{}{"allOf": []interface{}{schema}, "nullable": true}
+		}
+		schema["nullable"] = true
+		return schema
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int32:
+		return map[string]interface{}{"type": "integer", "format": "int32"}
+	case reflect.Int64:
+		return map[string]interface{}{"type": "integer", "format": "int64"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": schemas.typeSchema(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": schemas.typeSchema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() != "" && t.PkgPath() == objectsPkgPath {
+			return schemas.ref(t)
+		}
+		return schemas.object(t)
+	}
+
+	return map[string]interface{}{}
+}
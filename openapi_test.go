@@ -0,0 +1,23 @@
+package objects
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+)
+
+// TestOpenApiUpToDate fails when a served type changed and openapi.json was
+// not regenerated with go generate.
+func TestOpenApiUpToDate(t *testing.T) {
+	spec, err := OpenApiJson()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored, err := ioutil.ReadFile("openapi.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(stored, spec) {
+		t.Fatal("openapi.json is out of date, run go generate")
+	}
+}
@@ -0,0 +1,333 @@
+package objects
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"gopkg.in/gorp.v1"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+)
+
+// OutboxDelete is the operation of the events announcing an item is gone,
+// e.g. merged into another one. The other operations are the audit actions.
+const OutboxDelete = "delete"
+
+const defaultOutboxBatch = 100
+
+// outboxLock is the advisory lock serializing the writers of the outbox, so
+// that events commit in the order of their seq and a consumer reading after
+// a cursor never skips one committed late.
+const outboxLock = 0x6f7574626f78
+
+// OutboxEventV1 announces that the Kind item Uuid changed. Seq orders all the
+// events, Version the ones of the item.
+type OutboxEventV1 struct {
+	Seq       int64     `db:"seq" json:"seq"`
+	Kind      string    `db:"kind" json:"kind"`
+	Uuid      string    `db:"uuid" json:"id"`
+	Operation string    `db:"operation" json:"operation"`
+	Version   int64     `db:"version" json:"version"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
+
+// OutboxReader reads the events after a cursor, the seq of the last event
+// a consumer handled.
+type OutboxReader interface {
+	EventsAfter(ctx context.Context, cursor int64, limit int) ([]OutboxEventV1, error)
+}
+
+// CursorStore keeps the cursor of each consumer.
+type CursorStore interface {
+	Cursor(ctx context.Context, consumer string) (int64, error)
+	SaveCursor(ctx context.Context, consumer string, cursor int64) error
+}
+
+func init() {
+	template.Must(queries.Parse(`
+{{define "lock_outbox"}}
+SELECT pg_advisory_xact_lock($1)
+{{end}}
+
+{{define "insert_outbox_event"}}
+INSERT INTO outbox (kind, uuid, operation, version, created_at)
+SELECT $1, $2, $3, COALESCE(MAX(outbox.version), 0) + 1, $4 FROM outbox
+WHERE outbox.kind = $1 AND outbox.uuid = $2
+{{end}}
+
+{{define "select_outbox_events"}}
+SELECT seq, kind, uuid, operation, version, created_at FROM outbox
+WHERE outbox.seq > $1
+ORDER BY outbox.seq
+LIMIT $2
+{{end}}
+
+{{define "select_outbox_cursor"}}
+SELECT outbox_cursors.cursor FROM outbox_cursors WHERE outbox_cursors.consumer = $1
+{{end}}
+
+{{define "upsert_outbox_cursor"}}
+INSERT INTO outbox_cursors (consumer, cursor) VALUES ($1, $2)
+ON CONFLICT (consumer) DO UPDATE SET cursor = EXCLUDED.cursor
+{{end}}
+`))
+}
+
+// recordOutbox announces a change of the kind item uuid, in the transaction
+// of the change. Items are created outside this package, which has no insert
+// path of its own: whatever inserts one has to announce it through
+// recordAudit or recordOutbox, AuditUpdate being the operation of a new item,
+// or the consumers never learn of it.
+func recordOutbox(exec gorp.SqlExecutor, kind, uuid, operation string) error {
+	lockQuery, err := renderQuery("lock_outbox", nil)
+	if err != nil {
+		return err
+	}
+	query, err := renderQuery("insert_outbox_event", nil)
+	if err != nil {
+		return err
+	}
+	if _, err := exec.Exec(lockQuery, outboxLock); err != nil {
+		return err
+	}
+	_, err = exec.Exec(query, kind, uuid, operation, time.Now().UTC())
+	return err
+}
+
+// DbOutbox reads the outbox and keeps the cursors in the database.
+type DbOutbox struct{}
+
+func (DbOutbox) EventsAfter(ctx context.Context, cursor int64, limit int) ([]OutboxEventV1, error) {
+	query, err := renderQuery("select_outbox_events", nil)
+	if err != nil {
+		return nil, err
+	}
+	res := []OutboxEventV1{}
+	if _, err := dbmap.Select(&res, query, cursor, limit); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (DbOutbox) Cursor(ctx context.Context, consumer string) (int64, error) {
+	query, err := renderQuery("select_outbox_cursor", nil)
+	if err != nil {
+		return 0, err
+	}
+	cursor, err := dbmap.SelectNullInt(query, consumer)
+	if err != nil {
+		return 0, err
+	}
+	return cursor.Int64, nil
+}
+
+func (DbOutbox) SaveCursor(ctx context.Context, consumer string, cursor int64) error {
+	query, err := renderQuery("upsert_outbox_cursor", nil)
+	if err != nil {
+		return err
+	}
+	_, err = dbmap.Exec(query, consumer, cursor)
+	return err
+}
+
+// FileOutbox is an outbox on the local filesystem under Root, standing in
+// for DbOutbox in the tests of consumers. Events are appended to
+// events.jsonl, a cursor is kept in cursors/<consumer>.
+type FileOutbox struct {
+	Root string
+
+	mu sync.Mutex
+}
+
+func (o *FileOutbox) eventsPath() string {
+	return filepath.Join(o.Root, "events.jsonl")
+}
+
+func (o *FileOutbox) cursorPath(consumer string) (string, error) {
+	if consumer == "" || strings.ContainsAny(consumer, `/\`) || consumer == "." || consumer == ".." {
+		return "", errors.New("invalid consumer")
+	}
+	return filepath.Join(o.Root, "cursors", consumer), nil
+}
+
+func (o *FileOutbox) readEvents() ([]OutboxEventV1, error) {
+	f, err := os.Open(o.eventsPath())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var res []OutboxEventV1
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var event OutboxEventV1
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return nil, err
+		}
+		res = append(res, event)
+	}
+	return res, scanner.Err()
+}
+
+// Append announces a change of the kind item uuid, numbering it the way
+// recordOutbox does.
+func (o *FileOutbox) Append(ctx context.Context, kind, uuid, operation string) (*OutboxEventV1, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	events, err := o.readEvents()
+	if err != nil {
+		return nil, err
+	}
+	event := &OutboxEventV1{Seq: 1, Kind: kind, Uuid: uuid, Operation: operation, Version: 1, CreatedAt: time.Now().UTC()}
+	for _, e := range events {
+		if e.Seq >= event.Seq {
+			event.Seq = e.Seq + 1
+		}
+		if e.Kind == kind && e.Uuid == uuid && e.Version >= event.Version {
+			event.Version = e.Version + 1
+		}
+	}
+
+	line, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(o.Root, 0755); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(o.eventsPath(), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return event, f.Close()
+}
+
+func (o *FileOutbox) EventsAfter(ctx context.Context, cursor int64, limit int) ([]OutboxEventV1, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	events, err := o.readEvents()
+	if err != nil {
+		return nil, err
+	}
+	res := []OutboxEventV1{}
+	for _, event := range events {
+		if event.Seq > cursor && len(res) < limit {
+			res = append(res, event)
+		}
+	}
+	return res, nil
+}
+
+func (o *FileOutbox) Cursor(ctx context.Context, consumer string) (int64, error) {
+	path, err := o.cursorPath(consumer)
+	if err != nil {
+		return 0, err
+	}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+}
+
+func (o *FileOutbox) SaveCursor(ctx context.Context, consumer string, cursor int64) error {
+	path, err := o.cursorPath(consumer)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	// write aside and rename so a crash never leaves a partial cursor
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, []byte(strconv.FormatInt(cursor, 10)), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// OutboxConsumer hands the events of the outbox to a downstream service,
+// e.g. the search indexer, at least once: its cursor moves past an event
+// only after the event was handled, so an event handled when the consumer
+// stopped may come again.
+type OutboxConsumer struct {
+	Name    string
+	Events  OutboxReader
+	Cursors CursorStore
+	// BatchSize is the number of events read at once, 100 if zero.
+	BatchSize int
+}
+
+// Poll hands the events after the cursor of the consumer to handle, in
+// order, and returns how many were handled. It stops at the first one
+// handle fails, which is handed again by the next Poll.
+func (c *OutboxConsumer) Poll(ctx context.Context, handle func(context.Context, OutboxEventV1) error) (int, error) {
+	if c.Name == "" || c.Events == nil || c.Cursors == nil || handle == nil {
+		return 0, errors.New("invalid args")
+	}
+	limit := c.BatchSize
+	if limit <= 0 {
+		limit = defaultOutboxBatch
+	}
+
+	cursor, err := c.Cursors.Cursor(ctx, c.Name)
+	if err != nil {
+		return 0, err
+	}
+	events, err := c.Events.EventsAfter(ctx, cursor, limit)
+	if err != nil {
+		return 0, err
+	}
+	for i, event := range events {
+		if err := handle(ctx, event); err != nil {
+			return i, err
+		}
+		if err := c.Cursors.SaveCursor(ctx, c.Name, event.Seq); err != nil {
+			return i, err
+		}
+	}
+	return len(events), nil
+}
+
+// Run polls until ctx is done or handle fails, waiting interval whenever the
+// outbox has no new events.
+func (c *OutboxConsumer) Run(ctx context.Context, interval time.Duration, handle func(context.Context, OutboxEventV1) error) error {
+	for ctx.Err() == nil {
+		n, err := c.Poll(ctx, handle)
+		if err != nil {
+			return err
+		}
+		if n > 0 {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+	return ctx.Err()
+}
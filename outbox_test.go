@@ -0,0 +1,96 @@
+package objects
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func newTestOutbox(t *testing.T) *FileOutbox {
+	root, err := ioutil.TempDir("", "outbox")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(root) })
+	return &FileOutbox{Root: root}
+}
+
+func appendEvents(t *testing.T, outbox *FileOutbox, uuids ...string) {
+	for _, uuid := range uuids {
+		if _, err := outbox.Append(context.Background(), "perfum", uuid, AuditUpdate); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestOutboxConsumerResume(t *testing.T) {
+	outbox := newTestOutbox(t)
+	appendEvents(t, outbox, "p1", "p2", "p3")
+
+	var handled []string
+	failOn := "p2"
+	handle := func(ctx context.Context, event OutboxEventV1) error {
+		if event.Uuid == failOn {
+			return errors.New("indexer down")
+		}
+		handled = append(handled, event.Uuid)
+		return nil
+	}
+
+	consumer := &OutboxConsumer{Name: "indexer", Events: outbox, Cursors: outbox, BatchSize: 2}
+	if n, err := consumer.Poll(context.Background(), handle); n != 1 || err == nil {
+		t.Fatalf("Poll = %d, %v, want 1 and the handle error", n, err)
+	}
+	if cursor, err := outbox.Cursor(context.Background(), "indexer"); err != nil || cursor != 1 {
+		t.Fatalf("cursor after failure = %d, %v, want 1", cursor, err)
+	}
+
+	// a new consumer over the same files resumes at the failed event
+	failOn = ""
+	appendEvents(t, outbox, "p4")
+	restarted := &OutboxConsumer{Name: "indexer", Events: outbox, Cursors: outbox, BatchSize: 2}
+	for {
+		n, err := restarted.Poll(context.Background(), handle)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n == 0 {
+			break
+		}
+	}
+	want := []string{"p1", "p2", "p3", "p4"}
+	if len(handled) != len(want) {
+		t.Fatalf("handled %v, want %v", handled, want)
+	}
+	for i := range want {
+		if handled[i] != want[i] {
+			t.Fatalf("handled %v, want %v", handled, want)
+		}
+	}
+	if cursor, err := outbox.Cursor(context.Background(), "indexer"); err != nil || cursor != 4 {
+		t.Fatalf("final cursor = %d, %v, want 4", cursor, err)
+	}
+
+	// another consumer keeps a cursor of its own
+	other := &OutboxConsumer{Name: "cache", Events: outbox, Cursors: outbox}
+	if n, err := other.Poll(context.Background(), func(context.Context, OutboxEventV1) error { return nil }); n != 4 || err != nil {
+		t.Fatalf("other consumer Poll = %d, %v, want 4", n, err)
+	}
+}
+
+func TestFileOutboxVersions(t *testing.T) {
+	outbox := newTestOutbox(t)
+	appendEvents(t, outbox, "p1", "p2", "p1")
+	events, err := outbox.EventsAfter(context.Background(), 0, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 3 || events[2].Seq != 3 || events[2].Version != 2 || events[1].Version != 1 {
+		t.Fatalf("unexpected events %+v", events)
+	}
+	if _, err := outbox.Cursor(context.Background(), "../escape"); err == nil {
+		t.Fatal("cursor outside the root accepted")
+	}
+}
@@ -0,0 +1,387 @@
+package objects
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"github.com/unrolled/render"
+	"net/http"
+	"text/template"
+)
+
+// PerfumerV1 is a perfumer (a "nose"); a perfum may have several and a
+// perfumer many perfums, linked in perfum_perfumers.
+type PerfumerV1 struct {
+	Id           string            `db:"id" json:"-"`
+	Uuid         string            `db:"perfumer_uuid" json:"id"`
+	Name         string            `db:"name" json:"name"`
+	Status       string            `db:"-" json:"status"`
+	ImageId      sql.NullString    `db:"img_uuid" json:"-"`
+	PerfumsCount int64             `db:"perfums_count" json:"perfums_count"`
+	Links        []LinkV1          `db:"-" json:"links"`
+	Locales      map[string]string `db:"-" json:"locales,omitempty"`
+	SmallImgUrl  string            `db:"-" json:"small_img_url"`
+	LargeImgUrl  string            `db:"-" json:"large_img_url"`
+}
+
+// Perfumers ...
+type PerfumersV1 struct {
+	ObjList []PerfumerV1 `db:"-" json:"perfumers_list"`
+	Total   int64        `db:"-" json:"total"`
+	Offset  int64        `db:"-" json:"offset"`
+	Amount  int64        `db:"-" json:"amount"`
+}
+
+// PerfumersSearchResultV1 ...
+type PerfumersSearchResultV1 struct {
+	ObjList []PerfumerV1 `db:"-" json:"perfumers_list"`
+	Total   int64        `json:"total"`
+	Offset  int64        `json:"offset"`
+	Amount  int64        `json:"amount"`
+}
+
+// PerfumerRefV1 names a perfumer of a perfum.
+type PerfumerRefV1 struct {
+	Uuid       string `db:"perfumer_uuid" json:"id"`
+	Name       string `db:"name" json:"name"`
+	PerfumUuid string `db:"perfum_uuid" json:"-"`
+}
+
+// PerfumerSearchParams matches Query against the names of perfumers, stored
+// or translated into Locales, in one of Statuses (active only by default).
+type PerfumerSearchParams struct {
+	Base     BaseParams
+	Total    int64
+	Query    string
+	Locales  []string
+	Statuses []string
+}
+
+// PerfumerQueryParams ...
+type PerfumerQueryParams struct {
+	PageQueryParams
+	WhereConditionString string
+	// PerfumsCondition selects the perfums counted in perfums_count.
+	PerfumsCondition string
+}
+
+func init() {
+	template.Must(queries.Parse(`
+{{define "select_perfumers"}}
+SELECT perfumers.id, perfumers.uuid AS perfumer_uuid, perfumers.name, images.uuid AS img_uuid,
+	(SELECT COUNT(*) FROM perfum_perfumers
+		JOIN parfum_info ON parfum_info.uuid = perfum_perfumers.perfum_uuid
+		WHERE perfum_perfumers.perfumer_uuid = perfumers.uuid AND {{.PerfumsCondition}}) AS perfums_count
+FROM perfumers
+LEFT JOIN images ON images.id = perfumers.img_id
+{{if .WhereConditionString}}WHERE {{.WhereConditionString}}{{end}}
+ORDER BY perfumers.name
+LIMIT {{.Limit}} OFFSET {{.Offset}}
+{{end}}
+
+{{define "select_perfumers_count"}}
+SELECT COUNT(*) FROM perfumers
+{{if .WhereConditionString}}WHERE {{.WhereConditionString}}{{end}}
+{{end}}
+
+{{define "select_perfum_perfumers"}}
+SELECT perfum_perfumers.perfum_uuid, perfumers.uuid AS perfumer_uuid, perfumers.name
+FROM perfum_perfumers
+JOIN perfumers ON perfumers.uuid = perfum_perfumers.perfumer_uuid
+WHERE {{.WhereConditionString}}
+ORDER BY perfumers.name
+{{end}}
+`))
+}
+
+// perfumersPerfumsCondition selects the perfums of the perfumers uids.
+func perfumersPerfumsCondition(uids []string) string {
+	return "parfum_info.uuid IN (SELECT perfum_perfumers.perfum_uuid FROM perfum_perfumers WHERE " +
+		addIdsToQuery(uids, "perfum_perfumers.perfumer_uuid") + ")"
+}
+
+func perfumerLinks(uuid string) []LinkV1 {
+	return []LinkV1{
+		LinkV1{
+			Href:   baseUrl + "/perfumer/" + uuid,
+			Rel:    "PerfumerInfo",
+			Method: "GET",
+		},
+		LinkV1{
+			Href:   baseUrl + "/perfumer/" + uuid + "/perfums",
+			Rel:    "PerfumerPerfums",
+			Method: "GET",
+		},
+	}
+}
+
+// fillPerfumers completes the perfumers of list read from the database.
+func fillPerfumers(list []PerfumerV1) {
+	for i := range list {
+		list[i].Links = perfumerLinks(list[i].Uuid)
+		if list[i].ImageId.Valid {
+			list[i].SmallImgUrl, list[i].LargeImgUrl = imageUrlPair(list[i].ImageId.String)
+		}
+	}
+}
+
+// fillPerfumPerfumers sets the perfumers of each perfum of list and links
+// them.
+func fillPerfumPerfumers(list []PerfumInfoV1) error {
+	if len(list) == 0 {
+		return nil
+	}
+
+	uuids := make([]string, 0, len(list))
+	for i := range list {
+		uuids = append(uuids, list[i].Uuid)
+	}
+	queryParams := PerfumerQueryParams{WhereConditionString: addIdsToQuery(uuids, "perfum_perfumers.perfum_uuid")}
+	query, err := renderQuery("select_perfum_perfumers", queryParams)
+	if err != nil {
+		return err
+	}
+	var refs []PerfumerRefV1
+	if _, err := dbmap.Select(&refs, query); err != nil {
+		return err
+	}
+
+	byPerfum := make(map[string][]PerfumerRefV1)
+	for _, ref := range refs {
+		byPerfum[ref.PerfumUuid] = append(byPerfum[ref.PerfumUuid], ref)
+	}
+	for i := range list {
+		list[i].Perfumers = byPerfum[list[i].Uuid]
+		for _, ref := range list[i].Perfumers {
+			list[i].Links = append(list[i].Links, perfumerLinks(ref.Uuid)...)
+		}
+	}
+
+	return nil
+}
+
+func NewPerfumersFactory(version string) (Objecter, error) {
+	return NewObjecter("perfumers", version)
+}
+
+func (obj *PerfumersV1) MakeObj(pParams interface{}) (Objecter, error) {
+	if pParams == nil {
+		return nil, errors.New("invalid args")
+	}
+
+	params := pParams.(*MakeObjParams)
+
+	if err := checkStatuses(params.Statuses); err != nil {
+		return nil, err
+	}
+
+	queryParams := PerfumerQueryParams{
+		PageQueryParams:  newPageQueryParams("perfumers", &params.Base),
+		PerfumsCondition: statusCondition("parfum_info", params.Statuses),
+	}
+	if params.Base.Ids.Valid {
+		queryParams.WhereConditionString = addIdsToQuery(params.Base.Ids.String, "perfumers.uuid")
+	} else {
+		queryParams.WhereConditionString = statusCondition("perfumers", params.Statuses)
+	}
+	query, err := renderQuery("select_perfumers", queryParams)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := dbmap.Select(&obj.ObjList, query); err != nil {
+		return nil, err
+	}
+
+	obj.Total = params.Total
+	obj.Offset = queryParams.Offset
+	obj.Amount = int64(len(obj.ObjList))
+
+	fillPerfumers(obj.ObjList)
+	l := newLocalizer(params.Locales)
+	for i := range obj.ObjList {
+		l.add(&obj.ObjList[i].Locales, "name", obj.ObjList[i].Uuid, "name", &obj.ObjList[i].Name)
+	}
+	if err := l.resolve(); err != nil {
+		return nil, err
+	}
+	if err := fillStatus("perfumers", &obj.ObjList); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+// MakeExtraObj lists the perfums made by the perfumers uids.
+func (obj *PerfumersV1) MakeExtraObj(params *MakeObjParams, uids []string) (Objecter, error) {
+	if params == nil || len(uids) == 0 {
+		return nil, errors.New("invalid args")
+	}
+
+	params.DbQuery.WhereConditionString = perfumersPerfumsCondition(uids)
+
+	if err := setDbQueryBaseParams(&params.Base, &params.DbQuery); err != nil {
+		return nil, err
+	}
+
+	if params.Base.Ids.Valid {
+		params.DbQuery.AndConditionString = addIdsToQuery(params.Base.Ids.String, "parfum_info.uuid")
+	}
+
+	pinfos, err := NewObjecter("perfums_info", params.Base.Version)
+	if err != nil {
+		return nil, err
+	}
+	return pinfos.MakeObj(params)
+}
+
+func (obj *PerfumersV1) Count(pParams interface{}) (int64, error) {
+	if pParams == nil {
+		return 0, errors.New("invalid args")
+	}
+
+	statuses, err := paramsStatuses(pParams)
+	if err != nil {
+		return 0, err
+	}
+	queryParams := PerfumerQueryParams{WhereConditionString: statusCondition("perfumers", statuses)}
+	query, err := renderQuery("select_perfumers_count", queryParams)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := dbmap.SelectInt(query)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (obj *PerfumersV1) ExtraCount(uids []string) (int64, error) {
+	return obj.ExtraCountStatuses(uids, nil)
+}
+
+func (obj *PerfumersV1) ExtraCountStatuses(uids []string, statuses []string) (int64, error) {
+	if len(uids) == 0 {
+		return 0, errors.New("invalid args")
+	}
+	if err := checkStatuses(statuses); err != nil {
+		return 0, err
+	}
+
+	dbQuery := QueryTemplateParams{}
+	dbQuery.FromTableName = "parfum_info"
+	dbQuery.WhereConditionString = andCondition(perfumersPerfumsCondition(uids), perfumsStatusCondition("parfum_info", statuses))
+	query := bytes.NewBufferString("")
+	if err := tmpl.ExecuteTemplate(query, "select_count", &dbQuery); err != nil {
+		return 0, err
+	}
+
+	count, err := dbmap.SelectInt(query.String())
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (obj *PerfumersV1) Json(w http.ResponseWriter, status int) error {
+	render := render.New()
+	return render.JSON(w, status, obj)
+}
+
+func NewPerfumersSearchResultFactory(version string) (Objecter, error) {
+	return NewObjecter("perfumers_search", version)
+}
+
+func (obj *PerfumersSearchResultV1) MakeObj(pParams interface{}) (Objecter, error) {
+	if pParams == nil {
+		return nil, errors.New("invalid args")
+	}
+
+	params := pParams.(*PerfumerSearchParams)
+	if params.Query == "" {
+		// return empty object
+		return obj, nil
+	}
+
+	queryParams, args, err := newPerfumerSearchQuery(params)
+	if err != nil {
+		return nil, err
+	}
+	query, err := renderQuery("select_perfumers", queryParams)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := dbmap.Select(&obj.ObjList, query, args...); err != nil {
+		return nil, err
+	}
+
+	obj.Total = params.Total
+	obj.Offset = queryParams.Offset
+	obj.Amount = int64(len(obj.ObjList))
+
+	fillPerfumers(obj.ObjList)
+
+	return obj, nil
+}
+
+func newPerfumerSearchQuery(params *PerfumerSearchParams) (PerfumerQueryParams, bindArgs, error) {
+	if err := checkStatuses(params.Statuses); err != nil {
+		return PerfumerQueryParams{}, nil, err
+	}
+	var chain []string
+	if len(params.Locales) > 0 {
+		chain = LocaleChain(params.Locales)
+	}
+	args := bindArgs{}
+	condition := localizedNameCondition(&args, "perfumers", "%"+params.Query+"%", chain)
+	queryParams := PerfumerQueryParams{
+		PageQueryParams:      newPageQueryParams("perfumers", &params.Base),
+		WhereConditionString: andCondition(condition, statusCondition("perfumers", params.Statuses)),
+		PerfumsCondition:     statusCondition("parfum_info", params.Statuses),
+	}
+	return queryParams, args, nil
+}
+
+func (obj *PerfumersSearchResultV1) MakeExtraObj(params *MakeObjParams, uids []string) (Objecter, error) {
+	return obj, nil
+}
+
+func (obj *PerfumersSearchResultV1) Count(pParams interface{}) (int64, error) {
+	if pParams == nil {
+		return 0, errors.New("invalid args")
+	}
+
+	params := pParams.(*PerfumerSearchParams)
+	if params.Query == "" {
+		return 0, nil
+	}
+
+	queryParams, args, err := newPerfumerSearchQuery(params)
+	if err != nil {
+		return 0, err
+	}
+	query, err := renderQuery("select_perfumers_count", queryParams)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := dbmap.SelectInt(query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (obj *PerfumersSearchResultV1) ExtraCount(uids []string) (int64, error) {
+	return 0, nil
+}
+
+func (obj *PerfumersSearchResultV1) Json(w http.ResponseWriter, status int) error {
+	render := render.New()
+	return render.JSON(w, status, obj)
+}
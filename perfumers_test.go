@@ -0,0 +1,19 @@
+package objects
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSelectPerfumersImage(t *testing.T) {
+	query, err := renderQuery("select_perfumers", PerfumerQueryParams{PageQueryParams: PageQueryParams{Limit: 10}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// images are linked by id, as update_owner_image sets them
+	for _, want := range []string{"images.uuid AS img_uuid", "LEFT JOIN images ON images.id = perfumers.img_id"} {
+		if !strings.Contains(query, want) {
+			t.Errorf("no %q in %s", want, query)
+		}
+	}
+}
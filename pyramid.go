@@ -0,0 +1,209 @@
+package objects
+
+import (
+	"database/sql"
+	"errors"
+	"text/template"
+)
+
+// PyramidLevels are the levels of a note pyramid from the first to the last
+// smelled.
+var PyramidLevels = []string{"top", "heart", "base"}
+
+var ErrInvalidLevel = errors.New("invalid pyramid level")
+
+// NotePyramidDBRecordV1 places a note in the pyramid; Position orders the
+// notes of one level.
+type NotePyramidDBRecordV1 struct {
+	NoteUuid string `db:"note_uuid"`
+	Level    string `db:"level"`
+	Position int64  `db:"position"`
+}
+
+// ComponentWeightDBRecordV1 is the share of a component within a note of a
+// perfum. Intensity runs from 1 to 5, Percentage from 0 to 100; either may be
+// unknown.
+type ComponentWeightDBRecordV1 struct {
+	PerfumUuid    string          `db:"perfum_uuid"`
+	NoteUuid      string          `db:"note_uuid"`
+	ComponentUuid string          `db:"component_uuid"`
+	Intensity     sql.NullInt64   `db:"intensity"`
+	Percentage    sql.NullFloat64 `db:"percentage"`
+}
+
+// PyramidQueryParams ...
+type PyramidQueryParams struct {
+	WhereConditionString string
+}
+
+func init() {
+	template.Must(queries.Parse(`
+{{define "select_note_pyramid"}}
+SELECT note_uuid, level, position FROM note_pyramid
+WHERE {{.WhereConditionString}}
+{{end}}
+
+{{define "select_component_weights"}}
+SELECT perfum_uuid, note_uuid, component_uuid, intensity, percentage FROM component_weights
+WHERE {{.WhereConditionString}}
+{{end}}
+`))
+}
+
+// levelRank orders levels as PyramidLevels does, notes without a level go
+// last.
+func levelRank(level string) int {
+	for i, l := range PyramidLevels {
+		if l == level {
+			return i
+		}
+	}
+	return len(PyramidLevels)
+}
+
+// checkLevels rejects levels that are not PyramidLevels.
+func checkLevels(levels []string) error {
+	for _, level := range levels {
+		if levelRank(level) == len(PyramidLevels) {
+			return ErrInvalidLevel
+		}
+	}
+	return nil
+}
+
+type ByNotePyramid []NoteItemV1
+
+func (n ByNotePyramid) Len() int {
+	return len(n)
+}
+
+func (n ByNotePyramid) Swap(i, j int) {
+	n[i], n[j] = n[j], n[i]
+}
+
+func (n ByNotePyramid) Less(i, j int) bool {
+	if ri, rj := levelRank(n[i].Level), levelRank(n[j].Level); ri != rj {
+		return ri < rj
+	}
+	if n[i].Position != n[j].Position {
+		return n[i].Position < n[j].Position
+	}
+	return n[i].Name < n[j].Name
+}
+
+// ByComponentWeight puts the largest share first, components without a
+// weight follow by name.
+type ByComponentWeight []ComponentItemV1
+
+func (c ByComponentWeight) Len() int {
+	return len(c)
+}
+
+func (c ByComponentWeight) Swap(i, j int) {
+	c[i], c[j] = c[j], c[i]
+}
+
+func (c ByComponentWeight) Less(i, j int) bool {
+	pi, pj := c[i].Percentage, c[j].Percentage
+	if (pi != nil) != (pj != nil) {
+		return pi != nil
+	}
+	if pi != nil && *pi != *pj {
+		return *pi > *pj
+	}
+	ii, ij := c[i].Intensity, c[j].Intensity
+	if (ii != nil) != (ij != nil) {
+		return ii != nil
+	}
+	if ii != nil && *ii != *ij {
+		return *ii > *ij
+	}
+	return c[i].Name < c[j].Name
+}
+
+// fillPyramid sets the levels and weights of the notes of list and drops the
+// notes outside levels when any are given.
+func fillPyramid(list []PerfumCompositionV1, levels []string) error {
+	if err := checkLevels(levels); err != nil {
+		return err
+	}
+
+	noteUuids, perfumUuids := []string{}, []string{}
+	for _, compos := range list {
+		perfumUuids = appendUnique(perfumUuids, compos.Uuid)
+		for _, note := range compos.Notes {
+			noteUuids = appendUnique(noteUuids, note.Id)
+		}
+	}
+	if len(noteUuids) == 0 {
+		return nil
+	}
+
+	query, err := renderQuery("select_note_pyramid",
+		PyramidQueryParams{WhereConditionString: addIdsToQuery(noteUuids, "note_pyramid.note_uuid")})
+	if err != nil {
+		return err
+	}
+	var pyramid []NotePyramidDBRecordV1
+	if _, err := dbmap.Select(&pyramid, query); err != nil {
+		return err
+	}
+	notes := make(map[string]NotePyramidDBRecordV1, len(pyramid))
+	for _, p := range pyramid {
+		notes[p.NoteUuid] = p
+	}
+
+	query, err = renderQuery("select_component_weights",
+		PyramidQueryParams{WhereConditionString: addIdsToQuery(perfumUuids, "component_weights.perfum_uuid")})
+	if err != nil {
+		return err
+	}
+	var weights []ComponentWeightDBRecordV1
+	if _, err := dbmap.Select(&weights, query); err != nil {
+		return err
+	}
+	byComponent := make(map[string]ComponentWeightDBRecordV1, len(weights))
+	for _, w := range weights {
+		byComponent[w.PerfumUuid+"/"+w.NoteUuid+"/"+w.ComponentUuid] = w
+	}
+
+	for i := range list {
+		compos := &list[i]
+		kept := []NoteItemV1{}
+		compos.TotalComponents = 0
+		for _, note := range compos.Notes {
+			p := notes[note.Id]
+			note.Level, note.Position = p.Level, p.Position
+			if len(levels) > 0 && !containsString(levels, note.Level) {
+				continue
+			}
+			for k := range note.Components {
+				component := &note.Components[k]
+				w, ok := byComponent[compos.Uuid+"/"+note.Id+"/"+component.Id]
+				if !ok {
+					continue
+				}
+				if w.Intensity.Valid {
+					component.Intensity = &w.Intensity.Int64
+				}
+				if w.Percentage.Valid {
+					component.Percentage = &w.Percentage.Float64
+				}
+			}
+			compos.TotalComponents += note.ComponentCount
+			kept = append(kept, note)
+		}
+		compos.Notes = kept
+	}
+
+	return nil
+}
+
+func containsString(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+package objects
+
+import (
+	"database/sql/driver"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestByNotePyramid(t *testing.T) {
+	notes := []NoteItemV1{
+		{Id: "n1", Name: "vanilla", Level: "base", Position: 1},
+		{Id: "n2", Name: "musk"},
+		{Id: "n3", Name: "rose", Level: "heart", Position: 2},
+		{Id: "n4", Name: "bergamot", Level: "top", Position: 1},
+		{Id: "n5", Name: "jasmine", Level: "heart", Position: 1},
+		{Id: "n6", Name: "iris", Level: "heart", Position: 2},
+		{Id: "n7", Name: "amber"},
+	}
+	sort.Sort(ByNotePyramid(notes))
+
+	ids := []string{}
+	for _, note := range notes {
+		ids = append(ids, note.Id)
+	}
+	if got, want := strings.Join(ids, ","), "n4,n5,n6,n3,n1,n7,n2"; got != want {
+		t.Errorf("notes in order %s, want %s", got, want)
+	}
+}
+
+func TestByComponentWeight(t *testing.T) {
+	intensity := func(v int64) *int64 { return &v }
+	percentage := func(v float64) *float64 { return &v }
+	components := []ComponentItemV1{
+		{Id: "c1", Name: "linalool"},
+		{Id: "c2", Name: "citral", Intensity: intensity(5)},
+		{Id: "c3", Name: "geraniol", Percentage: percentage(10)},
+		{Id: "c4", Name: "eugenol", Percentage: percentage(30), Intensity: intensity(1)},
+		{Id: "c5", Name: "coumarin", Percentage: percentage(10), Intensity: intensity(4)},
+		{Id: "c6", Name: "benzoin", Intensity: intensity(2)},
+		{Id: "c7", Name: "ambrox"},
+	}
+	sort.Sort(ByComponentWeight(components))
+
+	ids := []string{}
+	for _, component := range components {
+		ids = append(ids, component.Id)
+	}
+	if got, want := strings.Join(ids, ","), "c4,c5,c3,c2,c6,c7,c1"; got != want {
+		t.Errorf("components in order %s, want %s", got, want)
+	}
+}
+
+func TestCheckLevels(t *testing.T) {
+	if err := checkLevels([]string{"top", "base"}); err != nil {
+		t.Error(err)
+	}
+	if err := checkLevels([]string{"top", "middle"}); err != ErrInvalidLevel {
+		t.Errorf("err = %v, want %v", err, ErrInvalidLevel)
+	}
+}
+
+func TestFillPyramid(t *testing.T) {
+	useFakeDB(t, func(query string, args []driver.Value) (*fakeResult, error) {
+		if strings.Contains(query, "FROM note_pyramid") {
+			return &fakeResult{
+				columns: []string{"note_uuid", "level", "position"},
+				rows:    [][]driver.Value{{"n1", "top", int64(1)}, {"n2", "base", int64(2)}},
+			}, nil
+		}
+		return &fakeResult{
+			columns: []string{"perfum_uuid", "note_uuid", "component_uuid", "intensity", "percentage"},
+			rows:    [][]driver.Value{{"p1", "n1", "c1", int64(3), nil}, {"p1", "n2", "c2", nil, 12.5}},
+		}, nil
+	})
+
+	newList := func() []PerfumCompositionV1 {
+		return []PerfumCompositionV1{{PerfumInfoV1: PerfumInfoV1{Uuid: "p1"}, Notes: []NoteItemV1{
+			{Id: "n1", ComponentCount: 1, Components: []ComponentItemV1{{Id: "c1"}}},
+			{Id: "n2", ComponentCount: 2, Components: []ComponentItemV1{{Id: "c2"}, {Id: "c3"}}},
+		}}}
+	}
+
+	all := newList()
+	if err := fillPyramid(all, nil); err != nil {
+		t.Fatal(err)
+	}
+	notes := all[0].Notes
+	if len(notes) != 2 || notes[0].Level != "top" || notes[1].Level != "base" || notes[1].Position != 2 ||
+		all[0].TotalComponents != 3 {
+		t.Fatalf("filled %+v", all[0])
+	}
+	if c := notes[0].Components[0]; c.Intensity == nil || *c.Intensity != 3 || c.Percentage != nil {
+		t.Errorf("component c1 weighted %+v", c)
+	}
+	if c := notes[1].Components[0]; c.Percentage == nil || *c.Percentage != 12.5 || c.Intensity != nil {
+		t.Errorf("component c2 weighted %+v", c)
+	}
+	if c := notes[1].Components[1]; c.Percentage != nil || c.Intensity != nil {
+		t.Errorf("component c3 without a weight got %+v", c)
+	}
+
+	base := newList()
+	if err := fillPyramid(base, []string{"base"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(base[0].Notes) != 1 || base[0].Notes[0].Id != "n2" || base[0].TotalComponents != 2 {
+		t.Errorf("base notes %+v", base[0])
+	}
+}
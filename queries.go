@@ -0,0 +1,110 @@
+package objects
+
+import (
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// defaultPageLimit caps lists whose queries are paged here rather than by
+// setDbQueryBaseParams.
+const defaultPageLimit = 20
+
+// kindTables maps the kind names used in links to their tables.
+var kindTables = map[string]string{
+	"perfum":    "parfum_info",
+	"brand":     "brands",
+	"component": "components",
+	"country":   "countries",
+	"gender":    "gender",
+	"group":     "groups",
+	"note":      "notes",
+	"perfumer":  "perfumers",
+	"season":    "seasons",
+	"tag":       "tags",
+	"timeofday": "times_of_day",
+	"type":      "types",
+}
+
+// queries holds the templates of the objects defined outside objects.go.
+// Values coming from clients are always passed as bind arguments.
+var queries = template.Must(template.New("queries").Parse(`
+{{define "select_uuids_by_name"}}
+SELECT {{.Table}}.uuid FROM {{.Table}}
+WHERE {{.WhereConditionString}}
+ORDER BY {{.Table}}.name
+LIMIT {{.Limit}} OFFSET {{.Offset}}
+{{end}}
+
+{{define "select_count_by_name"}}
+SELECT COUNT(*) FROM {{.Table}}
+WHERE {{.WhereConditionString}}
+{{end}}
+`))
+
+// PageQueryParams ...
+type PageQueryParams struct {
+	Table  string
+	Limit  int64
+	Offset int64
+}
+
+// NameQueryParams pages the items of Table whose name matches
+// WhereConditionString.
+type NameQueryParams struct {
+	PageQueryParams
+	WhereConditionString string
+}
+
+func newPageQueryParams(table string, base *BaseParams) PageQueryParams {
+	params := PageQueryParams{Table: table, Limit: defaultPageLimit}
+	if base.Limit.Valid && base.Limit.Int64 > 0 {
+		params.Limit = base.Limit.Int64
+	}
+	if base.Offset.Valid && base.Offset.Int64 > 0 {
+		params.Offset = base.Offset.Int64
+	}
+	return params
+}
+
+// renderQuery executes the named template of queries with data.
+func renderQuery(name string, data interface{}) (string, error) {
+	query := bytes.NewBufferString("")
+	if err := queries.ExecuteTemplate(query, name, data); err != nil {
+		return "", err
+	}
+	return query.String(), nil
+}
+
+// newUuid returns a random (version 4) uuid for rows created here.
+func newUuid() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
+
+// bindArgs collects the arguments of a query assembled in pieces.
+type bindArgs []interface{}
+
+// add appends v and returns its placeholder.
+func (a *bindArgs) add(v interface{}) string {
+	*a = append(*a, v)
+	return "$" + strconv.Itoa(len(*a))
+}
+
+// list adds every value of values and returns their placeholders joined
+// for an IN (...) clause.
+func (a *bindArgs) list(values []string) string {
+	placeholders := make([]string, 0, len(values))
+	for _, v := range values {
+		placeholders = append(placeholders, a.add(v))
+	}
+	return strings.Join(placeholders, ", ")
+}
@@ -0,0 +1,180 @@
+package objects
+
+import (
+	"errors"
+	"sort"
+	"sync"
+)
+
+var (
+	ErrUnknownKind    = errors.New("unknown kind")
+	ErrUnknownVersion = errors.New("unknown version")
+)
+
+// FactoryFunc creates an empty collection ready for MakeObj.
+type FactoryFunc func() Objecter
+
+var (
+	factoriesMu sync.RWMutex
+	factories   = make(map[string]map[string]FactoryFunc)
+)
+
+// RegisterFactory makes the collection of kind available in version.
+// Registering the same kind and version twice replaces the factory.
+func RegisterFactory(kind, version string, factory FactoryFunc) {
+	factoriesMu.Lock()
+	defer factoriesMu.Unlock()
+
+	if factories[kind] == nil {
+		factories[kind] = make(map[string]FactoryFunc)
+	}
+	factories[kind][version] = factory
+}
+
+// NewObjecter creates the collection of kind in version, failing with
+// ErrUnknownKind or ErrUnknownVersion instead of returning nil.
+func NewObjecter(kind, version string) (Objecter, error) {
+	factoriesMu.RLock()
+	defer factoriesMu.RUnlock()
+
+	versions, ok := factories[kind]
+	if !ok {
+		return nil, ErrUnknownKind
+	}
+	factory, ok := versions[version]
+	if !ok {
+		return nil, ErrUnknownVersion
+	}
+
+	return factory(), nil
+}
+
+// FactoryVersions returns the versions kind is served in.
+func FactoryVersions(kind string) []string {
+	factoriesMu.RLock()
+	defer factoriesMu.RUnlock()
+
+	versions := make([]string, 0, len(factories[kind]))
+	for version := range factories[kind] {
+		versions = append(versions, version)
+	}
+	sort.Strings(versions)
+
+	return versions
+}
+
+func init() {
+	RegisterFactory("perfums_info", "v1", func() Objecter {
+		return &PerfumsInfoV1{ObjList: make([]PerfumInfoV1, 0)}
+	})
+	RegisterFactory("perfums_composition", "v1", func() Objecter {
+		return &PerfumsCompositionV1{
+			ObjList: []PerfumCompositionV1{},
+		}
+	})
+	RegisterFactory("brands", "v1", func() Objecter {
+		return &BrandsV1{ObjList: make([]BrandV1, 0)}
+	})
+	RegisterFactory("components", "v1", func() Objecter {
+		return &ComponentsV1{ObjList: make([]ComponentV1, 0)}
+	})
+	RegisterFactory("countries", "v1", func() Objecter {
+		return &CountriesV1{ObjList: make([]CountryV1, 0)}
+	})
+	RegisterFactory("genders", "v1", func() Objecter {
+		return &GendersV1{ObjList: make([]GenderV1, 0)}
+	})
+	RegisterFactory("groups", "v1", func() Objecter {
+		return &GroupsV1{ObjList: make([]GroupV1, 0)}
+	})
+	RegisterFactory("notes", "v1", func() Objecter {
+		return &NotesV1{ObjList: make([]NoteV1, 0)}
+	})
+	RegisterFactory("seasons", "v1", func() Objecter {
+		return &SeasonsV1{ObjList: make([]SeasonV1, 0)}
+	})
+	RegisterFactory("times_of_day", "v1", func() Objecter {
+		return &TimesOfDayV1{ObjList: make([]TimeOfDayV1, 0)}
+	})
+	RegisterFactory("types", "v1", func() Objecter {
+		return &TypesV1{ObjList: make([]TypeV1, 0)}
+	})
+	RegisterFactory("perfums_search", "v1", func() Objecter {
+		return &PerfumsSearchResultV1{Links: make([]LinkV1, 0)}
+	})
+	RegisterFactory("brands_search", "v1", func() Objecter {
+		return &BrandsSearchResultV1{ObjList: make([]BrandV1, 0)}
+	})
+	RegisterFactory("components_search", "v1", func() Objecter {
+		return &ComponentsSearchResultV1{ObjList: make([]ComponentV1, 0)}
+	})
+	RegisterFactory("countries_search", "v1", func() Objecter {
+		return &CountriesSearchResultV1{ObjList: make([]CountryV1, 0)}
+	})
+	RegisterFactory("groups_search", "v1", func() Objecter {
+		return &GroupsSearchResultV1{ObjList: make([]GroupV1, 0)}
+	})
+	RegisterFactory("notes_search", "v1", func() Objecter {
+		return &NotesSearchResultV1{ObjList: make([]NoteV1, 0)}
+	})
+	RegisterFactory("perfums_info", "v2", func() Objecter {
+		return &PerfumsInfoV2{ObjList: make([]PerfumInfoV2, 0)}
+	})
+	for _, kind := range taxonomyKinds {
+		kind := kind
+		RegisterFactory(kind, "v2", func() Objecter {
+			return &TaxonomiesV2{Kind: kind, ObjList: make([]TaxonomyV2, 0)}
+		})
+	}
+	RegisterFactory("batch", "v1", func() Objecter {
+		return &BatchV1{ObjList: make([]BatchItemV1, 0)}
+	})
+	RegisterFactory("user_perfums", "v1", func() Objecter {
+		return &UserPerfumsV1{
+			PerfumsInfoV1: PerfumsInfoV1{ObjList: make([]PerfumInfoV1, 0)},
+			Items:         make([]UserPerfumV1, 0),
+		}
+	})
+	RegisterFactory("reviews", "v1", func() Objecter {
+		return &ReviewsV1{ObjList: make([]ReviewV1, 0)}
+	})
+	RegisterFactory("stars", "v1", func() Objecter {
+		return &StarsListV1{ObjList: make([]StarsV1, 0)}
+	})
+	RegisterFactory("shops", "v1", func() Objecter {
+		return &ShopsV1{ObjList: make([]ShopV1, 0)}
+	})
+	RegisterFactory("offers", "v1", func() Objecter {
+		return &OffersV1{ObjList: make([]OfferV1, 0)}
+	})
+	RegisterFactory("perfums_filter", "v1", func() Objecter {
+		return &PerfumsFilterV1{Links: make([]LinkV1, 0)}
+	})
+	RegisterFactory("images", "v1", func() Objecter {
+		return &ImagesV1{ObjList: make([]ImageV1, 0)}
+	})
+	RegisterFactory("translations", "v1", func() Objecter {
+		return &TranslationsV1{ObjList: make([]TranslationV1, 0)}
+	})
+	RegisterFactory("aliases", "v1", func() Objecter {
+		return &AliasesV1{ObjList: make([]AliasV1, 0)}
+	})
+	RegisterFactory("perfumers", "v1", func() Objecter {
+		return &PerfumersV1{ObjList: make([]PerfumerV1, 0)}
+	})
+	RegisterFactory("perfumers_search", "v1", func() Objecter {
+		return &PerfumersSearchResultV1{ObjList: make([]PerfumerV1, 0)}
+	})
+	RegisterFactory("tags", "v1", func() Objecter {
+		return &TagsV1{ObjList: make([]TagV1, 0)}
+	})
+	RegisterFactory("tag_cloud", "v1", func() Objecter {
+		return &TagCloudV1{ObjList: make([]TagCloudItemV1, 0)}
+	})
+	RegisterFactory("perfum_family", "v1", func() Objecter {
+		return &PerfumFamilyV1{ObjList: make([]FamilyMemberV1, 0)}
+	})
+	RegisterFactory("history", "v1", func() Objecter {
+		return &AuditLogV1{ObjList: make([]AuditEntryV1, 0)}
+	})
+}
@@ -0,0 +1,423 @@
+package objects
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/unrolled/render"
+	"net/http"
+	"text/template"
+	"time"
+)
+
+var ErrDuplicateReview = errors.New("perfum already reviewed by user")
+
+// ReviewReq is a review submitted by the authenticated user.
+type ReviewReq struct {
+	PerfumUuid string `json:"perfum_id"`
+	Stars      int64  `json:"stars"`
+	Text       string `json:"text"`
+}
+
+// ReviewV1 ...
+type ReviewV1 struct {
+	Uuid       string    `db:"uuid" json:"id"`
+	PerfumUuid string    `db:"perfum_uuid" json:"perfum_id"`
+	UserId     string    `db:"user_uuid" json:"user_id"`
+	Stars      int64     `db:"stars" json:"stars"`
+	Text       string    `db:"review_text" json:"text"`
+	CreatedAt  time.Time `db:"created_at" json:"created_at"`
+	Links      []LinkV1  `db:"-" json:"links"`
+}
+
+// ReviewsV1 is a page of the reviews of one perfum, newest first.
+type ReviewsV1 struct {
+	ObjList []ReviewV1 `db:"-" json:"reviews_list"`
+	Total   int64      `db:"-" json:"total"`
+	Offset  int64      `db:"-" json:"offset"`
+	Amount  int64      `db:"-" json:"amount"`
+}
+
+// StarsV1 is the aggregate of the reviews of a perfum, the object
+// PerfumInfoV1.StarsUuid refers to.
+type StarsV1 struct {
+	Uuid       string   `db:"uuid" json:"id"`
+	PerfumUuid string   `db:"perfum_uuid" json:"perfum_id"`
+	Average    float64  `db:"average" json:"average"`
+	Count      int64    `db:"count" json:"count"`
+	Links      []LinkV1 `db:"-" json:"links"`
+}
+
+type StarsListV1 struct {
+	ObjList []StarsV1 `db:"-" json:"stars_list"`
+	Total   int64     `db:"-" json:"total"`
+	Offset  int64     `db:"-" json:"offset"`
+	Amount  int64     `db:"-" json:"amount"`
+}
+
+func init() {
+	template.Must(queries.Parse(`
+{{define "insert_review"}}
+INSERT INTO reviews (uuid, perfum_uuid, user_uuid, stars, review_text, created_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (perfum_uuid, user_uuid) DO NOTHING
+{{end}}
+
+{{define "upsert_stars"}}
+INSERT INTO stars (uuid, perfum_uuid, average, count)
+VALUES ($2, $1, $3, 1)
+ON CONFLICT (perfum_uuid)
+DO UPDATE SET average = (stars.average * stars.count + EXCLUDED.average) / (stars.count + 1),
+	count = stars.count + 1
+{{end}}
+
+{{define "select_reviews"}}
+SELECT uuid, perfum_uuid, user_uuid, stars, review_text, created_at FROM reviews
+WHERE reviews.perfum_uuid = $1
+ORDER BY reviews.created_at DESC, reviews.uuid
+LIMIT {{.Limit}} OFFSET {{.Offset}}
+{{end}}
+
+{{define "select_reviews_count"}}
+SELECT COUNT(*) FROM reviews
+WHERE reviews.perfum_uuid = $1
+{{end}}
+
+{{define "select_stars"}}
+SELECT uuid, perfum_uuid, average, count FROM stars
+WHERE {{.}}
+{{end}}
+`))
+}
+
+// SubmitReview stores req as the review of the user whose claims are in ctx
+// and updates the stars of the perfum, announcing the perfum as updated. A
+// user reviews a perfum only once.
+func SubmitReview(ctx context.Context, req *ReviewReq) (*ReviewV1, error) {
+	claims, ok := ClaimsFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	if req == nil || req.PerfumUuid == "" {
+		return nil, errors.New("invalid args")
+	}
+	if req.Stars < 1 || req.Stars > 5 {
+		return nil, ErrInvalidRating
+	}
+
+	infos := &PerfumsInfoV1{}
+	count, err := infos.ExtraCount([]string{req.PerfumUuid})
+	if err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, ErrPerfumNotFound
+	}
+
+	reviewUuid, err := newUuid()
+	if err != nil {
+		return nil, err
+	}
+	starsUuid, err := newUuid()
+	if err != nil {
+		return nil, err
+	}
+	review := &ReviewV1{
+		Uuid:       reviewUuid,
+		PerfumUuid: req.PerfumUuid,
+		UserId:     claims.UserId,
+		Stars:      req.Stars,
+		Text:       req.Text,
+		CreatedAt:  time.Now().UTC(),
+	}
+
+	insertQuery, err := renderQuery("insert_review", nil)
+	if err != nil {
+		return nil, err
+	}
+	starsQuery, err := renderQuery("upsert_stars", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := dbmap.Begin()
+	if err != nil {
+		return nil, err
+	}
+	res, err := tx.Exec(insertQuery, review.Uuid, review.PerfumUuid, review.UserId, review.Stars, review.Text, review.CreatedAt)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if n, err := res.RowsAffected(); err != nil || n == 0 {
+		tx.Rollback()
+		if err != nil {
+			return nil, err
+		}
+		return nil, ErrDuplicateReview
+	}
+	// add the review to the aggregate instead of averaging the reviews
+	// again: the upsert locks the stars row, so concurrent reviews, which
+	// don't see each other under read committed, are added one by one
+	if _, err := tx.Exec(starsQuery, review.PerfumUuid, starsUuid, review.Stars); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	// the perfum info embeds the stars
+	if err := recordOutbox(tx, "perfum", review.PerfumUuid, AuditUpdate); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	review.Links = reviewLinks(review)
+	return review, nil
+}
+
+func reviewLinks(review *ReviewV1) []LinkV1 {
+	return []LinkV1{
+		LinkV1{
+			Href:   baseUrl + "/perfum/" + review.PerfumUuid,
+			Rel:    "PerfumInfo",
+			Method: "GET",
+		},
+		LinkV1{
+			Href:   baseUrl + "/perfum/" + review.PerfumUuid + "/reviews",
+			Rel:    "PerfumReviews",
+			Method: "GET",
+		},
+	}
+}
+
+func starsLinks(stars *StarsV1) []LinkV1 {
+	return []LinkV1{
+		LinkV1{
+			Href:   baseUrl + "/stars/" + stars.Uuid,
+			Rel:    "StarsInfo",
+			Method: "GET",
+		},
+		LinkV1{
+			Href:   baseUrl + "/perfum/" + stars.PerfumUuid + "/reviews",
+			Rel:    "PerfumReviews",
+			Method: "GET",
+		},
+	}
+}
+
+// fillPerfumStars embeds the stars of each perfum of list and links them.
+// Perfums without reviews get no stars, whatever stars_uuid they hold.
+func fillPerfumStars(list []PerfumInfoV1) error {
+	if len(list) == 0 {
+		return nil
+	}
+
+	uuids := make([]string, 0, len(list))
+	for i := range list {
+		uuids = append(uuids, list[i].Uuid)
+	}
+	query, err := renderQuery("select_stars", addIdsToQuery(uuids, "stars.perfum_uuid"))
+	if err != nil {
+		return err
+	}
+	var stars []StarsV1
+	if _, err := dbmap.Select(&stars, query); err != nil {
+		return err
+	}
+
+	byPerfum := make(map[string]StarsV1, len(stars))
+	for _, s := range stars {
+		byPerfum[s.PerfumUuid] = s
+	}
+	for i := range list {
+		s, found := byPerfum[list[i].Uuid]
+		if !found {
+			list[i].StarsAverage = 0
+			list[i].StarsCount = 0
+			list[i].StarsUuid = sql.NullString{}
+			continue
+		}
+		list[i].StarsAverage = s.Average
+		list[i].StarsCount = s.Count
+		list[i].StarsUuid.String = s.Uuid
+		list[i].StarsUuid.Valid = true
+		list[i].Links = append(list[i].Links, LinkV1{
+			Href:   baseUrl + "/stars/" + s.Uuid,
+			Rel:    "StarsInfo",
+			Method: "GET",
+		})
+	}
+
+	return nil
+}
+
+func NewReviewsFactory(version string) (Objecter, error) {
+	return NewObjecter("reviews", version)
+}
+
+// MakeObj lists the reviews of the perfum params.Id.
+func (obj *ReviewsV1) MakeObj(pParams interface{}) (Objecter, error) {
+	if pParams == nil {
+		return nil, errors.New("invalid args")
+	}
+	params := pParams.(*MakeObjParams)
+	if params.Id == "" {
+		return nil, errors.New("invalid args")
+	}
+
+	page := newPageQueryParams("reviews", &params.Base)
+	query, err := renderQuery("select_reviews", page)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := dbmap.Select(&obj.ObjList, query, params.Id); err != nil {
+		return nil, err
+	}
+
+	total, err := obj.Count(params)
+	if err != nil {
+		return nil, err
+	}
+
+	obj.Total = total
+	obj.Offset = page.Offset
+	obj.Amount = int64(len(obj.ObjList))
+
+	for i := range obj.ObjList {
+		obj.ObjList[i].Links = reviewLinks(&obj.ObjList[i])
+	}
+
+	return obj, nil
+}
+
+func (obj *ReviewsV1) MakeExtraObj(params *MakeObjParams, uids []string) (Objecter, error) {
+	return nil, errors.New("not supported")
+}
+
+func (obj *ReviewsV1) Count(pParams interface{}) (int64, error) {
+	if pParams == nil {
+		return 0, errors.New("invalid args")
+	}
+	params := pParams.(*MakeObjParams)
+
+	query, err := renderQuery("select_reviews_count", nil)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := dbmap.SelectInt(query, params.Id)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (obj *ReviewsV1) ExtraCount(uids []string) (int64, error) {
+	return 0, errors.New("not supported")
+}
+
+func (obj *ReviewsV1) Json(w http.ResponseWriter, status int) error {
+	render := render.New()
+	return render.JSON(w, status, obj)
+}
+
+func NewStarsFactory(version string) (Objecter, error) {
+	return NewObjecter("stars", version)
+}
+
+// MakeObj returns the stars params.Base.Ids.
+func (obj *StarsListV1) MakeObj(pParams interface{}) (Objecter, error) {
+	if pParams == nil {
+		return nil, errors.New("invalid args")
+	}
+	params := pParams.(*MakeObjParams)
+	if !params.Base.Ids.Valid {
+		return nil, errors.New("invalid args")
+	}
+
+	query, err := renderQuery("select_stars", addIdsToQuery(params.Base.Ids.String, "stars.uuid"))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := dbmap.Select(&obj.ObjList, query); err != nil {
+		return nil, err
+	}
+
+	obj.Total = int64(len(obj.ObjList))
+	obj.Amount = int64(len(obj.ObjList))
+
+	for i := range obj.ObjList {
+		obj.ObjList[i].Links = starsLinks(&obj.ObjList[i])
+	}
+
+	return obj, nil
+}
+
+// MakeExtraObj returns the stars of the perfums uids.
+func (obj *StarsListV1) MakeExtraObj(params *MakeObjParams, uids []string) (Objecter, error) {
+	if params == nil || len(uids) == 0 {
+		return nil, errors.New("invalid args")
+	}
+
+	query, err := renderQuery("select_stars", addIdsToQuery(uids, "stars.perfum_uuid"))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := dbmap.Select(&obj.ObjList, query); err != nil {
+		return nil, err
+	}
+
+	obj.Total = int64(len(obj.ObjList))
+	obj.Amount = int64(len(obj.ObjList))
+
+	for i := range obj.ObjList {
+		obj.ObjList[i].Links = starsLinks(&obj.ObjList[i])
+	}
+
+	return obj, nil
+}
+
+func (obj *StarsListV1) Count(pParams interface{}) (int64, error) {
+	dbQuery := QueryTemplateParams{}
+	dbQuery.FromTableName = "stars"
+	query := bytes.NewBufferString("")
+	if err := tmpl.ExecuteTemplate(query, "select_count", &dbQuery); err != nil {
+		return 0, err
+	}
+
+	count, err := dbmap.SelectInt(query.String())
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (obj *StarsListV1) ExtraCount(uids []string) (int64, error) {
+	if len(uids) == 0 {
+		return 0, errors.New("invalid args")
+	}
+
+	dbQuery := QueryTemplateParams{}
+	dbQuery.FromTableName = "stars"
+	dbQuery.WhereConditionString = addIdsToQuery(uids, "stars.perfum_uuid")
+	query := bytes.NewBufferString("")
+	if err := tmpl.ExecuteTemplate(query, "select_count", &dbQuery); err != nil {
+		return 0, err
+	}
+
+	count, err := dbmap.SelectInt(query.String())
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (obj *StarsListV1) Json(w http.ResponseWriter, status int) error {
+	render := render.New()
+	return render.JSON(w, status, obj)
+}
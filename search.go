@@ -0,0 +1,147 @@
+package objects
+
+import (
+	"errors"
+	"strings"
+)
+
+// LocalizedSearchParams are SearchParams whose names are matched against
+// their translations into Locales as well. The searches take either.
+type LocalizedSearchParams struct {
+	SearchParams
+	Locales []string
+}
+
+// StatusSearchParams are LocalizedSearchParams finding the items in one of
+// Statuses instead of the active ones only. The searches take it as well.
+type StatusSearchParams struct {
+	LocalizedSearchParams
+	Statuses []string
+}
+
+// searchQuery is what a search runs with: its SearchParams, the locales the
+// items found are served in, the locale chain names are matched in and the
+// statuses of the items found.
+type searchQuery struct {
+	*SearchParams
+	locales  []string
+	chain    []string
+	statuses []string
+}
+
+func newSearchQuery(pParams interface{}) (*searchQuery, error) {
+	switch params := pParams.(type) {
+	case *SearchParams:
+		return &searchQuery{SearchParams: params}, nil
+	case *LocalizedSearchParams:
+		return localizedSearchQuery(&params.SearchParams, params.Locales), nil
+	case *StatusSearchParams:
+		if err := checkStatuses(params.Statuses); err != nil {
+			return nil, err
+		}
+		q := localizedSearchQuery(&params.SearchParams, params.Locales)
+		q.statuses = params.Statuses
+		return q, nil
+	}
+	return nil, errors.New("invalid args")
+}
+
+// localizedSearchQuery searches the names translated into the locales of
+// locales as well and serves the items found in them.
+func localizedSearchQuery(params *SearchParams, locales []string) *searchQuery {
+	q := &searchQuery{SearchParams: params, locales: locales}
+	if len(locales) > 0 {
+		q.chain = LocaleChain(locales)
+	}
+	return q
+}
+
+// searchMatch matches the items of table that are uid, when it is given, or
+// whose name, stored or translated into a locale of chain, contains name.
+func searchMatch(args *bindArgs, table, uid, name string, chain []string) string {
+	if uid != "" {
+		return table + ".uuid = " + args.add(uid)
+	}
+	return localizedNameCondition(args, table, "%"+name+"%", chain)
+}
+
+// searchCondition is searchMatch for the items of table in one of the
+// statuses of q.
+func searchCondition(args *bindArgs, table, uid, name string, q *searchQuery) string {
+	return andCondition(searchMatch(args, table, uid, name, q.chain), statusCondition(table, q.statuses))
+}
+
+// perfumSearchCondition matches the perfums in one of the statuses of q of
+// the brand, component, country and group search looks for.
+func perfumSearchCondition(args *bindArgs, search *SearchQueryTemplateParams, q *searchQuery) string {
+	condition := statusCondition("parfum_info", q.statuses)
+	refs := []struct {
+		field, table, uid, name string
+	}{
+		{"brand_id", "brands", search.BrandUid, search.Brand},
+		{"country_id", "countries", search.CountryUid, search.Country},
+		{"group_id", "groups", search.GroupUid, search.Group},
+	}
+	for _, ref := range refs {
+		if ref.uid == "" && ref.name == "" {
+			continue
+		}
+		condition = andCondition(condition, "parfum_info."+ref.field+" IN (SELECT "+ref.table+".id FROM "+ref.table+
+			" WHERE "+searchMatch(args, ref.table, ref.uid, ref.name, q.chain)+")")
+	}
+	if search.ComponentUid != "" || search.Component != "" {
+		condition = andCondition(condition, "parfum_info.id IN (SELECT parfums.parfum_info_id FROM parfums"+
+			" JOIN components ON components.id = parfums.component_id"+
+			" WHERE "+searchMatch(args, "components", search.ComponentUid, search.Component, q.chain)+")")
+	}
+	return condition
+}
+
+// searchUuids pages the uuids of the items of table matching condition, in
+// the order of their names.
+func searchUuids(table, condition string, args bindArgs, base *BaseParams) ([]string, error) {
+	dbQuery := NameQueryParams{
+		PageQueryParams:      newPageQueryParams(table, base),
+		WhereConditionString: condition,
+	}
+	query, err := renderQuery("select_uuids_by_name", &dbQuery)
+	if err != nil {
+		return nil, err
+	}
+	var uuids []string
+	if _, err := dbmap.Select(&uuids, query, args...); err != nil {
+		return nil, err
+	}
+	return uuids, nil
+}
+
+// searchCount counts the items of table matching condition.
+func searchCount(table, condition string, args bindArgs) (int64, error) {
+	dbQuery := NameQueryParams{
+		PageQueryParams:      PageQueryParams{Table: table},
+		WhereConditionString: condition,
+	}
+	query, err := renderQuery("select_count_by_name", &dbQuery)
+	if err != nil {
+		return 0, err
+	}
+	return dbmap.SelectInt(query, args...)
+}
+
+// searchedItems loads the items uuids of collection in the locales of q, as
+// listed for its statuses, and indexes them by their uuid.
+func searchedItems(collection Objecter, uuids []string, q *searchQuery) (map[string]interface{}, error) {
+	if len(uuids) == 0 {
+		return map[string]interface{}{}, nil
+	}
+	params := &MakeObjParams{Total: int64(len(uuids)), Locales: q.locales, Statuses: q.statuses}
+	params.Base.Version = "v1"
+	params.Base.Ids.String = strings.Join(uuids, ",")
+	params.Base.Ids.Valid = true
+	params.Base.Limit.Int64 = int64(len(uuids))
+	params.Base.Limit.Valid = true
+	if _, err := collection.MakeObj(params); err != nil {
+		return nil, err
+	}
+	return listItems(collection), nil
+}
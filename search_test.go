@@ -0,0 +1,91 @@
+package objects
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSearchCondition(t *testing.T) {
+	args := bindArgs{}
+	q := &searchQuery{SearchParams: &SearchParams{}}
+	condition := searchCondition(&args, "brands", "", "Chanel", q)
+	if strings.Contains(condition, "Chanel") || len(args) != 1 || args[0] != "%Chanel%" {
+		t.Errorf("name not bound: %s %v", condition, args)
+	}
+	if strings.Contains(condition, "translations") {
+		t.Errorf("translations searched without locales: %s", condition)
+	}
+	if !strings.Contains(condition, "COALESCE(brands.status, 'active') IN ('active')") {
+		t.Errorf("no status condition in %s", condition)
+	}
+}
+
+func TestSearchConditionLocales(t *testing.T) {
+	q, err := newSearchQuery(&LocalizedSearchParams{Locales: []string{"uk"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	args := bindArgs{}
+	condition := searchCondition(&args, "brands", "", "Шанель", q)
+	want := "translations.locale IN ($2, $3, $4)"
+	if !strings.Contains(condition, want) || !strings.Contains(condition, "translations.text ILIKE $1") {
+		t.Errorf("no %q in %s", want, condition)
+	}
+	if len(args) != 4 || args[1] != "uk" || args[2] != "en" || args[3] != "ru" {
+		t.Errorf("args %v, want the pattern and the uk chain", args)
+	}
+
+	// a uuid is matched as it is
+	args = bindArgs{}
+	if condition := searchMatch(&args, "brands", "b1", "Шанель", q.chain); condition != "brands.uuid = $1" || len(args) != 1 {
+		t.Errorf("uuid match %s %v", condition, args)
+	}
+
+	if _, err := newSearchQuery(&MakeObjParams{}); err == nil {
+		t.Error("search of MakeObjParams accepted")
+	}
+}
+
+func TestSearchConditionStatuses(t *testing.T) {
+	q, err := newSearchQuery(&StatusSearchParams{Statuses: []string{StatusArchived}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	args := bindArgs{}
+	condition := searchCondition(&args, "brands", "", "Chanel", q)
+	if !strings.Contains(condition, "COALESCE(brands.status, 'active') IN ('archived')") {
+		t.Errorf("no status condition in %s", condition)
+	}
+	args = bindArgs{}
+	condition = perfumSearchCondition(&args, &SearchQueryTemplateParams{Brand: "Chanel"}, q)
+	if !strings.Contains(condition, "COALESCE(parfum_info.status, 'active') IN ('archived')") {
+		t.Errorf("no status condition in %s", condition)
+	}
+
+	if _, err := newSearchQuery(&StatusSearchParams{Statuses: []string{"gone"}}); err != ErrInvalidStatus {
+		t.Errorf("unknown status: %v", err)
+	}
+}
+
+func TestPerfumSearchCondition(t *testing.T) {
+	args := bindArgs{}
+	q := &searchQuery{SearchParams: &SearchParams{}, chain: []string{"en", "ru"}}
+	search := SearchQueryTemplateParams{BrandUid: "b1", Component: "iris"}
+	condition := perfumSearchCondition(&args, &search, q)
+	for _, want := range []string{
+		"COALESCE(parfum_info.status, 'active') IN ('active')",
+		"parfum_info.brand_id IN (SELECT brands.id FROM brands WHERE brands.uuid = $1)",
+		"parfums.component_id",
+		"translations.uuid = components.uuid",
+	} {
+		if !strings.Contains(condition, want) {
+			t.Errorf("no %q in %s", want, condition)
+		}
+	}
+	if strings.Contains(condition, "countries") || strings.Contains(condition, "groups") {
+		t.Errorf("unrequested condition in %s", condition)
+	}
+	if len(args) != 4 || args[0] != "b1" || args[1] != "%iris%" || args[2] != "en" {
+		t.Errorf("args %v", args)
+	}
+}
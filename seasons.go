@@ -0,0 +1,192 @@
+package objects
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"text/template"
+)
+
+// Ways MakeExtraObj matches perfums against several seasons or times of day.
+const (
+	MatchAny = "any"
+	MatchAll = "all"
+)
+
+var ErrInvalidMatch = errors.New("invalid match")
+
+// TaxonomyItemRefV1 is one of the seasons or times of day of a perfum.
+type TaxonomyItemRefV1 struct {
+	Uuid       string `db:"uuid" json:"id"`
+	Name       string `db:"name" json:"name"`
+	PerfumUuid string `db:"perfum_uuid" json:"-"`
+}
+
+// multiTaxonomy is a taxonomy a perfum may have several items of: the one
+// in parfum_info (Field) stays the primary item v1 has always returned, the
+// others are listed in LinkTable.
+type multiTaxonomy struct {
+	Kind       string
+	Rel        string
+	Table      string
+	Field      string
+	LinkTable  string
+	LinkColumn string
+	// items returns the list of info the items go to.
+	items func(info *PerfumInfoV1) *[]TaxonomyItemRefV1
+}
+
+// MultiTaxonomyQueryParams ...
+type MultiTaxonomyQueryParams struct {
+	Table                string
+	LinkTable            string
+	LinkColumn           string
+	WhereConditionString string
+}
+
+var seasonsTaxonomy = multiTaxonomy{
+	Kind: "season", Rel: "Season", Table: "seasons", Field: "season_id",
+	LinkTable: "perfum_seasons", LinkColumn: "season_uuid",
+	items: func(info *PerfumInfoV1) *[]TaxonomyItemRefV1 { return &info.Seasons },
+}
+
+var timesOfDayTaxonomy = multiTaxonomy{
+	Kind: "timeofday", Rel: "Timeofday", Table: "times_of_day", Field: "tsod_id",
+	LinkTable: "perfum_times_of_day", LinkColumn: "tsod_uuid",
+	items: func(info *PerfumInfoV1) *[]TaxonomyItemRefV1 { return &info.TimesOfDay },
+}
+
+func init() {
+	template.Must(queries.Parse(`
+{{define "select_perfum_taxonomy_items"}}
+SELECT {{.LinkTable}}.perfum_uuid, {{.Table}}.uuid, {{.Table}}.name FROM {{.LinkTable}}
+JOIN {{.Table}} ON {{.Table}}.uuid = {{.LinkTable}}.{{.LinkColumn}}
+WHERE {{.WhereConditionString}}
+ORDER BY {{.Table}}.name
+{{end}}
+`))
+}
+
+// condition selects the perfums having any, or with MatchAll every one, of
+// the items uids, be it as their primary item or a listed one.
+func (m multiTaxonomy) condition(uids []string, match string) (string, error) {
+	switch match {
+	case "", MatchAny:
+		return m.anyCondition(uids)
+	case MatchAll:
+		conditions := make([]string, 0, len(uids))
+		for _, uid := range uids {
+			condition, err := m.anyCondition([]string{uid})
+			if err != nil {
+				return "", err
+			}
+			conditions = append(conditions, condition)
+		}
+		return strings.Join(conditions, " AND "), nil
+	}
+	return "", ErrInvalidMatch
+}
+
+func (m multiTaxonomy) anyCondition(uids []string) (string, error) {
+	dbQuery := QueryTemplateParams{}
+	dbQuery.ConditionTableField = m.Field
+	dbQuery.ConditionTableName = m.Table
+	dbQuery.ConditionUuid = addIdsToQuery(uids, m.Table+".uuid")
+	query := bytes.NewBufferString("")
+	if err := tmpl.ExecuteTemplate(query, "condition_select_id_eq_uuid", &dbQuery); err != nil {
+		return "", err
+	}
+	return "(" + query.String() + " OR parfum_info.uuid IN (SELECT " + m.LinkTable + ".perfum_uuid FROM " +
+		m.LinkTable + " WHERE " + addIdsToQuery(uids, m.LinkTable+"."+m.LinkColumn) + "))", nil
+}
+
+// joinCondition joins the items of the taxonomy to the perfums having them,
+// be it as their primary item or a listed one.
+func (m multiTaxonomy) joinCondition() string {
+	return "(parfum_info." + m.Field + " = " + m.Table + ".id OR parfum_info.uuid IN (SELECT " + m.LinkTable +
+		".perfum_uuid FROM " + m.LinkTable + " WHERE " + m.LinkTable + "." + m.LinkColumn + " = " + m.Table + ".uuid))"
+}
+
+// extraLinks links the items of refs other than the primary one.
+func (m multiTaxonomy) extraLinks(refs []TaxonomyItemRefV1, primary string) []LinkV1 {
+	links := []LinkV1{}
+	for _, ref := range refs {
+		if ref.Uuid == primary {
+			continue
+		}
+		links = append(links,
+			LinkV1{
+				Href:   baseUrl + "/" + m.Kind + "/" + ref.Uuid,
+				Rel:    m.Rel + "Info",
+				Method: "GET",
+			},
+			LinkV1{
+				Href:   baseUrl + "/" + m.Kind + "/" + ref.Uuid + "/perfums",
+				Rel:    m.Rel + "Perfums",
+				Method: "GET",
+			})
+	}
+	return links
+}
+
+// fill lists the items of each perfum of list, the primary one first.
+func (m multiTaxonomy) fill(list []PerfumInfoV1, primary func(info *PerfumInfoV1) TaxonomyItemRefV1) error {
+	uuids := make([]string, 0, len(list))
+	for i := range list {
+		uuids = append(uuids, list[i].Uuid)
+	}
+	queryParams := MultiTaxonomyQueryParams{
+		Table:                m.Table,
+		LinkTable:            m.LinkTable,
+		LinkColumn:           m.LinkColumn,
+		WhereConditionString: addIdsToQuery(uuids, m.LinkTable+".perfum_uuid"),
+	}
+	query, err := renderQuery("select_perfum_taxonomy_items", queryParams)
+	if err != nil {
+		return err
+	}
+	var refs []TaxonomyItemRefV1
+	if _, err := dbmap.Select(&refs, query); err != nil {
+		return err
+	}
+
+	byPerfum := make(map[string][]TaxonomyItemRefV1)
+	for _, ref := range refs {
+		byPerfum[ref.PerfumUuid] = append(byPerfum[ref.PerfumUuid], ref)
+	}
+	for i := range list {
+		info := &list[i]
+		items := []TaxonomyItemRefV1{}
+		first := primary(info)
+		if first.Uuid != "" {
+			items = append(items, first)
+		}
+		for _, ref := range byPerfum[info.Uuid] {
+			if ref.Uuid != first.Uuid {
+				items = append(items, ref)
+			}
+		}
+		*m.items(info) = items
+		info.Links = append(info.Links, m.extraLinks(items, first.Uuid)...)
+	}
+
+	return nil
+}
+
+// fillPerfumSeasons lists the seasons and times of day of each perfum of
+// list; season_id and tsod_id keep the primary ones.
+func fillPerfumSeasons(list []PerfumInfoV1) error {
+	if len(list) == 0 {
+		return nil
+	}
+
+	err := seasonsTaxonomy.fill(list, func(info *PerfumInfoV1) TaxonomyItemRefV1 {
+		return TaxonomyItemRefV1{Uuid: info.SeasonUuid, Name: info.SeasonName}
+	})
+	if err != nil {
+		return err
+	}
+	return timesOfDayTaxonomy.fill(list, func(info *PerfumInfoV1) TaxonomyItemRefV1 {
+		return TaxonomyItemRefV1{Uuid: info.TsodUuid, Name: info.TsodName}
+	})
+}
@@ -0,0 +1,53 @@
+package objects
+
+import (
+	"database/sql/driver"
+	"strings"
+	"testing"
+)
+
+func TestMultiTaxonomyInvalidMatch(t *testing.T) {
+	if _, err := seasonsTaxonomy.condition([]string{"s1"}, "most"); err != ErrInvalidMatch {
+		t.Errorf("err = %v, want %v", err, ErrInvalidMatch)
+	}
+}
+
+func TestFillPerfumSeasons(t *testing.T) {
+	useFakeDB(t, func(query string, args []driver.Value) (*fakeResult, error) {
+		res := &fakeResult{columns: []string{"perfum_uuid", "uuid", "name"}}
+		if strings.Contains(query, "FROM perfum_seasons") {
+			res.rows = [][]driver.Value{{"p1", "s2", "summer"}, {"p1", "s1", "spring"}, {"p2", "s3", "winter"}}
+		}
+		return res, nil
+	})
+
+	list := []PerfumInfoV1{
+		{Uuid: "p1", SeasonUuid: "s1", SeasonName: "spring", TsodUuid: "t1", TsodName: "day"},
+		{Uuid: "p2"},
+	}
+	if err := fillPerfumSeasons(list); err != nil {
+		t.Fatal(err)
+	}
+
+	// the primary season comes first and is not listed twice
+	if s := list[0].Seasons; len(s) != 2 || s[0].Uuid != "s1" || s[1].Uuid != "s2" {
+		t.Errorf("p1 seasons %+v", s)
+	}
+	if s := list[1].Seasons; len(s) != 1 || s[0].Uuid != "s3" {
+		t.Errorf("p2 seasons %+v", s)
+	}
+	if tods := list[0].TimesOfDay; len(tods) != 1 || tods[0].Uuid != "t1" {
+		t.Errorf("p1 times of day %+v", tods)
+	}
+	if tods := list[1].TimesOfDay; tods == nil || len(tods) != 0 {
+		t.Errorf("p2 times of day %+v", tods)
+	}
+
+	// the primary items are linked by v1 already, only the others are added
+	if got, want := linkRels(list[0].Links), "SeasonInfo /season/s2, SeasonPerfums /season/s2/perfums"; got != want {
+		t.Errorf("p1 links %s, want %s", got, want)
+	}
+	if got, want := linkRels(list[1].Links), "SeasonInfo /season/s3, SeasonPerfums /season/s3/perfums"; got != want {
+		t.Errorf("p2 links %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,353 @@
+package objects
+
+import (
+	"bytes"
+	"errors"
+	"github.com/unrolled/render"
+	"net/http"
+	"text/template"
+)
+
+// ShopV1 ...
+type ShopV1 struct {
+	Uuid        string   `db:"uuid" json:"id"`
+	Name        string   `db:"name" json:"name"`
+	Url         string   `db:"url" json:"url"`
+	OffersCount int64    `db:"offers_count" json:"offers_count"`
+	Links       []LinkV1 `db:"-" json:"links"`
+}
+
+type ShopsV1 struct {
+	ObjList []ShopV1 `db:"-" json:"shops_list"`
+	Total   int64    `db:"-" json:"total"`
+	Offset  int64    `db:"-" json:"offset"`
+	Amount  int64    `db:"-" json:"amount"`
+}
+
+// OfferV1 is a perfum sold by a shop; Volume is in ml.
+type OfferV1 struct {
+	Uuid       string   `db:"uuid" json:"id"`
+	PerfumUuid string   `db:"perfum_uuid" json:"perfum_id"`
+	ShopUuid   string   `db:"shop_uuid" json:"shop_id"`
+	ShopName   string   `db:"shop_name" json:"shop_name"`
+	Price      float64  `db:"price" json:"price"`
+	Currency   string   `db:"currency" json:"currency"`
+	Volume     int64    `db:"volume" json:"volume"`
+	Url        string   `db:"url" json:"url"`
+	Links      []LinkV1 `db:"-" json:"links"`
+}
+
+// OffersV1 is a page of the offers of one perfum, cheapest first.
+type OffersV1 struct {
+	ObjList []OfferV1 `db:"-" json:"offers_list"`
+	Total   int64     `db:"-" json:"total"`
+	Offset  int64     `db:"-" json:"offset"`
+	Amount  int64     `db:"-" json:"amount"`
+}
+
+// OfferQueryParams ...
+type OfferQueryParams struct {
+	PageQueryParams
+	WhereConditionString string
+}
+
+func init() {
+	template.Must(queries.Parse(`
+{{define "select_shops"}}
+SELECT shops.uuid, shops.name, shops.url,
+	(SELECT COUNT(*) FROM offers WHERE offers.shop_uuid = shops.uuid) AS offers_count
+FROM shops
+{{if .WhereConditionString}}WHERE {{.WhereConditionString}}{{end}}
+ORDER BY shops.name
+LIMIT {{.Limit}} OFFSET {{.Offset}}
+{{end}}
+
+{{define "select_offers"}}
+SELECT offers.uuid, offers.perfum_uuid, offers.shop_uuid, shops.name AS shop_name,
+	offers.price, offers.currency, offers.volume, offers.url
+FROM offers
+JOIN shops ON shops.uuid = offers.shop_uuid
+WHERE {{.WhereConditionString}}
+ORDER BY offers.price, offers.volume DESC, shops.name
+{{if .Limit}}LIMIT {{.Limit}} OFFSET {{.Offset}}{{end}}
+{{end}}
+
+{{define "select_offers_count"}}
+SELECT COUNT(*) FROM offers
+WHERE offers.perfum_uuid = $1
+{{end}}
+`))
+}
+
+func offerLinks(offer *OfferV1) []LinkV1 {
+	return []LinkV1{
+		LinkV1{
+			Href:   offer.Url,
+			Rel:    "ShopOffer",
+			Method: "GET",
+		},
+		LinkV1{
+			Href:   baseUrl + "/shop/" + offer.ShopUuid,
+			Rel:    "ShopInfo",
+			Method: "GET",
+		},
+		LinkV1{
+			Href:   baseUrl + "/perfum/" + offer.PerfumUuid,
+			Rel:    "PerfumInfo",
+			Method: "GET",
+		},
+	}
+}
+
+// fillPerfumOffers links each perfum of list to its shop and offers, the
+// offers being listed cheapest first.
+func fillPerfumOffers(list []PerfumInfoV1) error {
+	if len(list) == 0 {
+		return nil
+	}
+
+	uuids := make([]string, 0, len(list))
+	for i := range list {
+		uuids = append(uuids, list[i].Uuid)
+	}
+	queryParams := OfferQueryParams{WhereConditionString: addIdsToQuery(uuids, "offers.perfum_uuid")}
+	query, err := renderQuery("select_offers", queryParams)
+	if err != nil {
+		return err
+	}
+	var offers []OfferV1
+	if _, err := dbmap.Select(&offers, query); err != nil {
+		return err
+	}
+
+	byPerfum := make(map[string][]OfferV1)
+	for _, offer := range offers {
+		byPerfum[offer.PerfumUuid] = append(byPerfum[offer.PerfumUuid], offer)
+	}
+	for i := range list {
+		if list[i].ShopUuid.Valid {
+			list[i].Links = append(list[i].Links, LinkV1{
+				Href:   baseUrl + "/shop/" + list[i].ShopUuid.String,
+				Rel:    "ShopInfo",
+				Method: "GET",
+			})
+		}
+
+		perfumOffers := byPerfum[list[i].Uuid]
+		if len(perfumOffers) == 0 {
+			continue
+		}
+		list[i].Links = append(list[i].Links, LinkV1{
+			Href:   baseUrl + "/perfum/" + list[i].Uuid + "/offers",
+			Rel:    "PerfumOffers",
+			Method: "GET",
+		})
+		for _, offer := range perfumOffers {
+			list[i].Links = append(list[i].Links, LinkV1{
+				Href:   offer.Url,
+				Rel:    "ShopOffer",
+				Method: "GET",
+			})
+		}
+	}
+
+	return nil
+}
+
+func NewShopsFactory(version string) (Objecter, error) {
+	return NewObjecter("shops", version)
+}
+
+func (obj *ShopsV1) MakeObj(pParams interface{}) (Objecter, error) {
+	if pParams == nil {
+		return nil, errors.New("invalid args")
+	}
+
+	params := pParams.(*MakeObjParams)
+
+	queryParams := OfferQueryParams{PageQueryParams: newPageQueryParams("shops", &params.Base)}
+	if params.Base.Ids.Valid {
+		queryParams.WhereConditionString = addIdsToQuery(params.Base.Ids.String, "shops.uuid")
+	}
+	query, err := renderQuery("select_shops", queryParams)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := dbmap.Select(&obj.ObjList, query); err != nil {
+		return nil, err
+	}
+
+	obj.Total = params.Total
+	obj.Offset = queryParams.Offset
+	obj.Amount = int64(len(obj.ObjList))
+
+	for i := 0; i < len(obj.ObjList); i++ {
+		obj.ObjList[i].Links = []LinkV1{
+			LinkV1{
+				Href:   baseUrl + "/shop/" + obj.ObjList[i].Uuid,
+				Rel:    "ShopInfo",
+				Method: "GET",
+			},
+			LinkV1{
+				Href:   baseUrl + "/shop/" + obj.ObjList[i].Uuid + "/perfums",
+				Rel:    "ShopPerfums",
+				Method: "GET",
+			},
+		}
+	}
+
+	return obj, nil
+}
+
+// MakeExtraObj lists the perfums offered by the shops uids.
+func (obj *ShopsV1) MakeExtraObj(params *MakeObjParams, uids []string) (Objecter, error) {
+	if params == nil || len(uids) == 0 {
+		return nil, errors.New("invalid args")
+	}
+
+	params.DbQuery.WhereConditionString = "parfum_info.uuid IN (SELECT offers.perfum_uuid FROM offers WHERE " +
+		addIdsToQuery(uids, "offers.shop_uuid") + ")"
+
+	if err := setDbQueryBaseParams(&params.Base, &params.DbQuery); err != nil {
+		return nil, err
+	}
+
+	if params.Base.Ids.Valid {
+		params.DbQuery.AndConditionString = addIdsToQuery(params.Base.Ids.String, "parfum_info.uuid")
+	}
+
+	pinfos, err := NewObjecter("perfums_info", params.Base.Version)
+	if err != nil {
+		return nil, err
+	}
+	return pinfos.MakeObj(params)
+}
+
+func (obj *ShopsV1) Count(pParams interface{}) (int64, error) {
+	if pParams == nil {
+		return 0, errors.New("invalid args")
+	}
+
+	dbQuery := QueryTemplateParams{}
+	dbQuery.FromTableName = "shops"
+	query := bytes.NewBufferString("")
+	if err := tmpl.ExecuteTemplate(query, "select_count", &dbQuery); err != nil {
+		return 0, err
+	}
+
+	count, err := dbmap.SelectInt(query.String())
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (obj *ShopsV1) ExtraCount(uids []string) (int64, error) {
+	return obj.ExtraCountStatuses(uids, nil)
+}
+
+func (obj *ShopsV1) ExtraCountStatuses(uids []string, statuses []string) (int64, error) {
+	if len(uids) == 0 {
+		return 0, errors.New("invalid args")
+	}
+	if err := checkStatuses(statuses); err != nil {
+		return 0, err
+	}
+
+	dbQuery := QueryTemplateParams{}
+	dbQuery.FromTableName = "parfum_info"
+	dbQuery.WhereConditionString = andCondition("parfum_info.uuid IN (SELECT offers.perfum_uuid FROM offers WHERE "+
+		addIdsToQuery(uids, "offers.shop_uuid")+")", perfumsStatusCondition(dbQuery.FromTableName, statuses))
+	query := bytes.NewBufferString("")
+	if err := tmpl.ExecuteTemplate(query, "select_count", &dbQuery); err != nil {
+		return 0, err
+	}
+
+	count, err := dbmap.SelectInt(query.String())
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (obj *ShopsV1) Json(w http.ResponseWriter, status int) error {
+	render := render.New()
+	return render.JSON(w, status, obj)
+}
+
+func NewOffersFactory(version string) (Objecter, error) {
+	return NewObjecter("offers", version)
+}
+
+// MakeObj lists the offers of the perfum params.Id sorted by price.
+func (obj *OffersV1) MakeObj(pParams interface{}) (Objecter, error) {
+	if pParams == nil {
+		return nil, errors.New("invalid args")
+	}
+	params := pParams.(*MakeObjParams)
+	if params.Id == "" {
+		return nil, errors.New("invalid args")
+	}
+
+	queryParams := OfferQueryParams{
+		PageQueryParams:      newPageQueryParams("offers", &params.Base),
+		WhereConditionString: "offers.perfum_uuid = $1",
+	}
+	query, err := renderQuery("select_offers", queryParams)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := dbmap.Select(&obj.ObjList, query, params.Id); err != nil {
+		return nil, err
+	}
+
+	total, err := obj.Count(params)
+	if err != nil {
+		return nil, err
+	}
+
+	obj.Total = total
+	obj.Offset = queryParams.Offset
+	obj.Amount = int64(len(obj.ObjList))
+
+	for i := range obj.ObjList {
+		obj.ObjList[i].Links = offerLinks(&obj.ObjList[i])
+	}
+
+	return obj, nil
+}
+
+func (obj *OffersV1) MakeExtraObj(params *MakeObjParams, uids []string) (Objecter, error) {
+	return nil, errors.New("not supported")
+}
+
+func (obj *OffersV1) Count(pParams interface{}) (int64, error) {
+	if pParams == nil {
+		return 0, errors.New("invalid args")
+	}
+	params := pParams.(*MakeObjParams)
+
+	query, err := renderQuery("select_offers_count", nil)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := dbmap.SelectInt(query, params.Id)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (obj *OffersV1) ExtraCount(uids []string) (int64, error) {
+	return 0, errors.New("not supported")
+}
+
+func (obj *OffersV1) Json(w http.ResponseWriter, status int) error {
+	render := render.New()
+	return render.JSON(w, status, obj)
+}
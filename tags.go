@@ -0,0 +1,395 @@
+package objects
+
+import (
+	"bytes"
+	"errors"
+	"github.com/unrolled/render"
+	"net/http"
+	"strconv"
+	"text/template"
+)
+
+// Kinds of tags editors attach to perfums; a tag may have none.
+const (
+	TagKindAccord   = "accord"
+	TagKindMood     = "mood"
+	TagKindOccasion = "occasion"
+)
+
+// TagV1 is a free-form tag like "gourmand" or "date night", linked to
+// perfums in perfum_tags.
+type TagV1 struct {
+	Id           string            `db:"id" json:"-"`
+	Uuid         string            `db:"tag_uuid" json:"id"`
+	Name         string            `db:"name" json:"name"`
+	Status       string            `db:"-" json:"status"`
+	Kind         string            `db:"kind" json:"kind"`
+	PerfumsCount int64             `db:"perfums_count" json:"perfums_count"`
+	Links        []LinkV1          `db:"-" json:"links"`
+	Locales      map[string]string `db:"-" json:"locales,omitempty"`
+}
+
+// Tags ...
+type TagsV1 struct {
+	ObjList []TagV1 `db:"-" json:"tags_list"`
+	Total   int64   `db:"-" json:"total"`
+	Offset  int64   `db:"-" json:"offset"`
+	Amount  int64   `db:"-" json:"amount"`
+}
+
+// TagCloudItemV1 is a tag weighted by its perfums: Weight is 1 for the tag
+// with the most perfums and proportionally less for the others.
+type TagCloudItemV1 struct {
+	TagV1
+	Weight float64 `db:"-" json:"weight"`
+}
+
+// TagCloudV1 lists the tags with the most perfums, most used first.
+type TagCloudV1 struct {
+	ObjList []TagCloudItemV1 `db:"-" json:"tag_cloud"`
+	Total   int64            `db:"-" json:"total"`
+	Offset  int64            `db:"-" json:"offset"`
+	Amount  int64            `db:"-" json:"amount"`
+}
+
+// TagQueryParams ...
+type TagQueryParams struct {
+	PageQueryParams
+	WhereConditionString string
+	Order                string
+	// PerfumsCondition selects the perfums counted in perfums_count.
+	PerfumsCondition string
+}
+
+func init() {
+	template.Must(queries.Parse(`
+{{define "select_tags"}}
+SELECT tags.id, tags.uuid AS tag_uuid, tags.name, COALESCE(tags.kind, '') AS kind,
+	(SELECT COUNT(*) FROM perfum_tags
+		JOIN parfum_info ON parfum_info.uuid = perfum_tags.perfum_uuid
+		WHERE perfum_tags.tag_uuid = tags.uuid AND {{.PerfumsCondition}}) AS perfums_count
+FROM tags
+{{if .WhereConditionString}}WHERE {{.WhereConditionString}}{{end}}
+ORDER BY {{if .Order}}{{.Order}}{{else}}tags.name{{end}}
+LIMIT {{.Limit}} OFFSET {{.Offset}}
+{{end}}
+
+{{define "select_tags_count"}}
+SELECT COUNT(*) FROM tags
+{{if .WhereConditionString}}WHERE {{.WhereConditionString}}{{end}}
+{{end}}
+
+{{define "select_perfum_tags"}}
+SELECT perfum_tags.perfum_uuid, tags.uuid, tags.name
+FROM perfum_tags
+JOIN tags ON tags.uuid = perfum_tags.tag_uuid
+WHERE {{.WhereConditionString}}
+ORDER BY tags.name
+{{end}}
+`))
+}
+
+// tagCloudCondition selects the active tags used at least once.
+func tagCloudCondition() string {
+	return andCondition("EXISTS (SELECT 1 FROM perfum_tags WHERE perfum_tags.tag_uuid = tags.uuid)",
+		statusCondition("tags", nil))
+}
+
+// tagsPerfumsCondition selects the perfums tagged with any of uids.
+func tagsPerfumsCondition(uids []string) string {
+	return "parfum_info.uuid IN (SELECT perfum_tags.perfum_uuid FROM perfum_tags WHERE " +
+		addIdsToQuery(uids, "perfum_tags.tag_uuid") + ")"
+}
+
+// tagsFilterCondition is tagsPerfumsCondition for uids coming from clients,
+// with MatchAll requiring every tag.
+func tagsFilterCondition(args *bindArgs, uids []string, match string) (string, error) {
+	unique := []string{}
+	for _, uid := range uids {
+		unique = appendUnique(unique, uid)
+	}
+	uids = unique
+
+	condition := "parfum_info.uuid IN (SELECT perfum_tags.perfum_uuid FROM perfum_tags WHERE perfum_tags.tag_uuid IN (" +
+		args.list(uids) + ")"
+	switch match {
+	case "", MatchAny:
+		return condition + ")", nil
+	case MatchAll:
+		return condition + " GROUP BY perfum_tags.perfum_uuid HAVING COUNT(DISTINCT perfum_tags.tag_uuid) = " +
+			strconv.Itoa(len(uids)) + ")", nil
+	}
+	return "", ErrInvalidMatch
+}
+
+func tagLinks(uuid string) []LinkV1 {
+	return []LinkV1{
+		LinkV1{
+			Href:   baseUrl + "/tag/" + uuid,
+			Rel:    "TagInfo",
+			Method: "GET",
+		},
+		LinkV1{
+			Href:   baseUrl + "/tag/" + uuid + "/perfums",
+			Rel:    "TagPerfums",
+			Method: "GET",
+		},
+	}
+}
+
+// fillPerfumTags sets the tags of each perfum of list and links them.
+func fillPerfumTags(list []PerfumInfoV1) error {
+	if len(list) == 0 {
+		return nil
+	}
+
+	uuids := make([]string, 0, len(list))
+	for i := range list {
+		uuids = append(uuids, list[i].Uuid)
+	}
+	queryParams := TagQueryParams{WhereConditionString: addIdsToQuery(uuids, "perfum_tags.perfum_uuid")}
+	query, err := renderQuery("select_perfum_tags", queryParams)
+	if err != nil {
+		return err
+	}
+	var refs []TaxonomyItemRefV1
+	if _, err := dbmap.Select(&refs, query); err != nil {
+		return err
+	}
+
+	byPerfum := make(map[string][]TaxonomyItemRefV1)
+	for _, ref := range refs {
+		byPerfum[ref.PerfumUuid] = append(byPerfum[ref.PerfumUuid], ref)
+	}
+	for i := range list {
+		list[i].Tags = byPerfum[list[i].Uuid]
+		for _, ref := range list[i].Tags {
+			list[i].Links = append(list[i].Links, tagLinks(ref.Uuid)...)
+		}
+	}
+
+	return nil
+}
+
+func NewTagsFactory(version string) (Objecter, error) {
+	return NewObjecter("tags", version)
+}
+
+func (obj *TagsV1) MakeObj(pParams interface{}) (Objecter, error) {
+	if pParams == nil {
+		return nil, errors.New("invalid args")
+	}
+
+	params := pParams.(*MakeObjParams)
+
+	if err := checkStatuses(params.Statuses); err != nil {
+		return nil, err
+	}
+
+	queryParams := TagQueryParams{
+		PageQueryParams:  newPageQueryParams("tags", &params.Base),
+		PerfumsCondition: statusCondition("parfum_info", params.Statuses),
+	}
+	if params.Base.Ids.Valid {
+		queryParams.WhereConditionString = addIdsToQuery(params.Base.Ids.String, "tags.uuid")
+	} else {
+		queryParams.WhereConditionString = statusCondition("tags", params.Statuses)
+	}
+	query, err := renderQuery("select_tags", queryParams)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := dbmap.Select(&obj.ObjList, query); err != nil {
+		return nil, err
+	}
+
+	obj.Total = params.Total
+	obj.Offset = queryParams.Offset
+	obj.Amount = int64(len(obj.ObjList))
+
+	l := newLocalizer(params.Locales)
+	for i := range obj.ObjList {
+		obj.ObjList[i].Links = tagLinks(obj.ObjList[i].Uuid)
+		l.add(&obj.ObjList[i].Locales, "name", obj.ObjList[i].Uuid, "name", &obj.ObjList[i].Name)
+	}
+	if err := l.resolve(); err != nil {
+		return nil, err
+	}
+	if err := fillStatus("tags", &obj.ObjList); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+// MakeExtraObj lists the perfums tagged with any of the tags uids.
+func (obj *TagsV1) MakeExtraObj(params *MakeObjParams, uids []string) (Objecter, error) {
+	if params == nil || len(uids) == 0 {
+		return nil, errors.New("invalid args")
+	}
+
+	params.DbQuery.WhereConditionString = tagsPerfumsCondition(uids)
+
+	if err := setDbQueryBaseParams(&params.Base, &params.DbQuery); err != nil {
+		return nil, err
+	}
+
+	if params.Base.Ids.Valid {
+		params.DbQuery.AndConditionString = addIdsToQuery(params.Base.Ids.String, "parfum_info.uuid")
+	}
+
+	pinfos, err := NewObjecter("perfums_info", params.Base.Version)
+	if err != nil {
+		return nil, err
+	}
+	return pinfos.MakeObj(params)
+}
+
+func (obj *TagsV1) Count(pParams interface{}) (int64, error) {
+	if pParams == nil {
+		return 0, errors.New("invalid args")
+	}
+
+	statuses, err := paramsStatuses(pParams)
+	if err != nil {
+		return 0, err
+	}
+	queryParams := TagQueryParams{WhereConditionString: statusCondition("tags", statuses)}
+	query, err := renderQuery("select_tags_count", queryParams)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := dbmap.SelectInt(query)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (obj *TagsV1) ExtraCount(uids []string) (int64, error) {
+	return obj.ExtraCountStatuses(uids, nil)
+}
+
+func (obj *TagsV1) ExtraCountStatuses(uids []string, statuses []string) (int64, error) {
+	if len(uids) == 0 {
+		return 0, errors.New("invalid args")
+	}
+	if err := checkStatuses(statuses); err != nil {
+		return 0, err
+	}
+
+	dbQuery := QueryTemplateParams{}
+	dbQuery.FromTableName = "parfum_info"
+	dbQuery.WhereConditionString = andCondition(tagsPerfumsCondition(uids), perfumsStatusCondition("parfum_info", statuses))
+	query := bytes.NewBufferString("")
+	if err := tmpl.ExecuteTemplate(query, "select_count", &dbQuery); err != nil {
+		return 0, err
+	}
+
+	count, err := dbmap.SelectInt(query.String())
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (obj *TagsV1) Json(w http.ResponseWriter, status int) error {
+	render := render.New()
+	return render.JSON(w, status, obj)
+}
+
+func NewTagCloudFactory(version string) (Objecter, error) {
+	return NewObjecter("tag_cloud", version)
+}
+
+// MakeObj lists the params.Base.Limit most used tags, or defaultPageLimit
+// of them.
+func (obj *TagCloudV1) MakeObj(pParams interface{}) (Objecter, error) {
+	if pParams == nil {
+		return nil, errors.New("invalid args")
+	}
+
+	params := pParams.(*MakeObjParams)
+
+	queryParams := TagQueryParams{
+		PageQueryParams:      newPageQueryParams("tags", &params.Base),
+		WhereConditionString: tagCloudCondition(),
+		Order:                "perfums_count DESC, tags.name",
+		PerfumsCondition:     statusCondition("parfum_info", nil),
+	}
+	query, err := renderQuery("select_tags", queryParams)
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []TagV1
+	if _, err := dbmap.Select(&tags, query); err != nil {
+		return nil, err
+	}
+
+	var max int64
+	for _, tag := range tags {
+		if tag.PerfumsCount > max {
+			max = tag.PerfumsCount
+		}
+	}
+	for _, tag := range tags {
+		tag.Links = tagLinks(tag.Uuid)
+		item := TagCloudItemV1{TagV1: tag}
+		if max > 0 {
+			item.Weight = float64(tag.PerfumsCount) / float64(max)
+		}
+		obj.ObjList = append(obj.ObjList, item)
+	}
+
+	l := newLocalizer(params.Locales)
+	for i := range obj.ObjList {
+		l.add(&obj.ObjList[i].Locales, "name", obj.ObjList[i].Uuid, "name", &obj.ObjList[i].Name)
+	}
+	if err := l.resolve(); err != nil {
+		return nil, err
+	}
+
+	obj.Total = params.Total
+	obj.Offset = queryParams.Offset
+	obj.Amount = int64(len(obj.ObjList))
+
+	return obj, nil
+}
+
+func (obj *TagCloudV1) MakeExtraObj(params *MakeObjParams, uids []string) (Objecter, error) {
+	return nil, errors.New("not supported")
+}
+
+// Count counts the tags used at least once.
+func (obj *TagCloudV1) Count(pParams interface{}) (int64, error) {
+	if pParams == nil {
+		return 0, errors.New("invalid args")
+	}
+
+	queryParams := TagQueryParams{WhereConditionString: tagCloudCondition()}
+	query, err := renderQuery("select_tags_count", queryParams)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := dbmap.SelectInt(query)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (obj *TagCloudV1) ExtraCount(uids []string) (int64, error) {
+	return 0, errors.New("not supported")
+}
+
+func (obj *TagCloudV1) Json(w http.ResponseWriter, status int) error {
+	render := render.New()
+	return render.JSON(w, status, obj)
+}
@@ -0,0 +1,237 @@
+package objects
+
+import (
+	"context"
+	"errors"
+	"github.com/unrolled/render"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+)
+
+var (
+	ErrUnauthenticated = errors.New("unauthenticated")
+	ErrForbidden       = errors.New("forbidden")
+	ErrPerfumNotFound  = errors.New("perfum not found")
+	ErrInvalidRating   = errors.New("rating must be between 1 and 5")
+)
+
+// UserPerfumReq adds a perfum to the wardrobe of the authenticated user or
+// updates its rating and notes.
+type UserPerfumReq struct {
+	PerfumUuid string  `json:"perfum_id"`
+	Rating     *int64  `json:"rating,omitempty"`
+	Notes      *string `json:"notes,omitempty"`
+}
+
+// UserPerfumV1 is what the user stored about a perfum of the wardrobe.
+type UserPerfumV1 struct {
+	PerfumUuid string    `db:"perfum_uuid" json:"perfum_id"`
+	Rating     *int64    `db:"rating" json:"rating"`
+	Notes      *string   `db:"notes" json:"notes"`
+	AddedAt    time.Time `db:"added_at" json:"added_at"`
+}
+
+// UserPerfumsV1 is a page of a user's wardrobe: the perfums as
+// PerfumsInfoV1 plus the user's rating and notes in the same order:
+// Items[i] is about ObjList[i].
+type UserPerfumsV1 struct {
+	PerfumsInfoV1
+	Items []UserPerfumV1 `db:"-" json:"user_perfums_list"`
+}
+
+func init() {
+	template.Must(queries.Parse(`
+{{define "select_user_perfums"}}
+SELECT user_perfums.perfum_uuid, user_perfums.rating, user_perfums.notes, user_perfums.added_at FROM user_perfums
+JOIN parfum_info ON parfum_info.uuid = user_perfums.perfum_uuid
+WHERE user_perfums.user_uuid = $1
+ORDER BY user_perfums.added_at DESC, user_perfums.perfum_uuid
+LIMIT {{.Limit}} OFFSET {{.Offset}}
+{{end}}
+
+{{define "select_user_perfums_count"}}
+SELECT COUNT(*) FROM user_perfums
+JOIN parfum_info ON parfum_info.uuid = user_perfums.perfum_uuid
+WHERE user_perfums.user_uuid = $1
+{{end}}
+
+{{define "upsert_user_perfum"}}
+INSERT INTO user_perfums (user_uuid, perfum_uuid, rating, notes, added_at)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (user_uuid, perfum_uuid)
+DO UPDATE SET rating = EXCLUDED.rating, notes = EXCLUDED.notes
+{{end}}
+
+{{define "delete_user_perfum"}}
+DELETE FROM user_perfums
+WHERE user_uuid = $1 AND perfum_uuid = $2
+{{end}}
+`))
+}
+
+// AddUserPerfum stores req in the wardrobe of the user whose claims are in
+// ctx. Adding a perfum twice replaces its rating and notes.
+func AddUserPerfum(ctx context.Context, req *UserPerfumReq) error {
+	claims, ok := ClaimsFromContext(ctx)
+	if !ok {
+		return ErrUnauthenticated
+	}
+	if req == nil || req.PerfumUuid == "" {
+		return errors.New("invalid args")
+	}
+	if req.Rating != nil && (*req.Rating < 1 || *req.Rating > 5) {
+		return ErrInvalidRating
+	}
+
+	infos := &PerfumsInfoV1{}
+	count, err := infos.ExtraCount([]string{req.PerfumUuid})
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return ErrPerfumNotFound
+	}
+
+	query, err := renderQuery("upsert_user_perfum", nil)
+	if err != nil {
+		return err
+	}
+	_, err = dbmap.Exec(query, claims.UserId, req.PerfumUuid, req.Rating, req.Notes, time.Now().UTC())
+	return err
+}
+
+// RemoveUserPerfum drops perfumUuid from the wardrobe of the user whose
+// claims are in ctx.
+func RemoveUserPerfum(ctx context.Context, perfumUuid string) error {
+	claims, ok := ClaimsFromContext(ctx)
+	if !ok {
+		return ErrUnauthenticated
+	}
+
+	query, err := renderQuery("delete_user_perfum", nil)
+	if err != nil {
+		return err
+	}
+	_, err = dbmap.Exec(query, claims.UserId, perfumUuid)
+	return err
+}
+
+func NewUserPerfumsFactory(version string) (Objecter, error) {
+	return NewObjecter("user_perfums", version)
+}
+
+// checkWardrobeOwner lets only the user params.Id at its wardrobe.
+func checkWardrobeOwner(params *MakeObjParams) error {
+	if params.Id == "" {
+		return errors.New("invalid args")
+	}
+	if params.Claims == nil {
+		return ErrUnauthenticated
+	}
+	if params.Claims.UserId != params.Id {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// MakeObj lists the wardrobe of the user params.Id, who must be the caller.
+func (obj *UserPerfumsV1) MakeObj(pParams interface{}) (Objecter, error) {
+	if pParams == nil {
+		return nil, errors.New("invalid args")
+	}
+	params := pParams.(*MakeObjParams)
+	if err := checkWardrobeOwner(params); err != nil {
+		return nil, err
+	}
+
+	page := newPageQueryParams("user_perfums", &params.Base)
+	query, err := renderQuery("select_user_perfums", page)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := dbmap.Select(&obj.Items, query, params.Id); err != nil {
+		return nil, err
+	}
+
+	total, err := obj.Count(params)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(obj.Items) > 0 {
+		uuids := make([]string, 0, len(obj.Items))
+		for _, item := range obj.Items {
+			uuids = append(uuids, item.PerfumUuid)
+		}
+
+		infoParams := &MakeObjParams{Total: total}
+		infoParams.Base.Version = params.Base.Version
+		infoParams.Base.Ids.String = strings.Join(uuids, ",")
+		infoParams.Base.Ids.Valid = true
+		infoParams.Base.Limit.Int64 = int64(len(uuids))
+		infoParams.Base.Limit.Valid = true
+
+		infos := &PerfumsInfoV1{ObjList: make([]PerfumInfoV1, 0)}
+		if _, err := infos.MakeObj(infoParams); err != nil {
+			return nil, err
+		}
+
+		// keep the wardrobe order, dropping the items of perfums deleted
+		// in the meantime so that Items stays in step with ObjList
+		byUuid := make(map[string]PerfumInfoV1, len(infos.ObjList))
+		for _, info := range infos.ObjList {
+			byUuid[info.Uuid] = info
+		}
+		items := obj.Items[:0]
+		for _, item := range obj.Items {
+			if info, ok := byUuid[item.PerfumUuid]; ok {
+				obj.ObjList = append(obj.ObjList, info)
+				items = append(items, item)
+			}
+		}
+		obj.Items = items
+	}
+
+	obj.Total = total
+	obj.Offset = page.Offset
+	obj.Amount = int64(len(obj.ObjList))
+
+	return obj, nil
+}
+
+func (obj *UserPerfumsV1) MakeExtraObj(params *MakeObjParams, uids []string) (Objecter, error) {
+	return nil, errors.New("not supported")
+}
+
+func (obj *UserPerfumsV1) Count(pParams interface{}) (int64, error) {
+	if pParams == nil {
+		return 0, errors.New("invalid args")
+	}
+	params := pParams.(*MakeObjParams)
+	if err := checkWardrobeOwner(params); err != nil {
+		return 0, err
+	}
+
+	query, err := renderQuery("select_user_perfums_count", nil)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := dbmap.SelectInt(query, params.Id)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (obj *UserPerfumsV1) ExtraCount(uids []string) (int64, error) {
+	return 0, errors.New("not supported")
+}
+
+func (obj *UserPerfumsV1) Json(w http.ResponseWriter, status int) error {
+	render := render.New()
+	return render.JSON(w, status, obj)
+}
@@ -0,0 +1,24 @@
+package objects
+
+import (
+	"testing"
+)
+
+func TestUserPerfumsOwner(t *testing.T) {
+	tests := []struct {
+		claims *IdTokenClaims
+		err    error
+	}{
+		{nil, ErrUnauthenticated},
+		{&IdTokenClaims{UserId: "bob"}, ErrForbidden},
+	}
+	for _, test := range tests {
+		params := &MakeObjParams{Id: "alice", Claims: test.claims}
+		if _, err := (&UserPerfumsV1{}).MakeObj(params); err != test.err {
+			t.Errorf("MakeObj(claims %+v) = %v, want %v", test.claims, err, test.err)
+		}
+		if _, err := (&UserPerfumsV1{}).Count(params); err != test.err {
+			t.Errorf("Count(claims %+v) = %v, want %v", test.claims, err, test.err)
+		}
+	}
+}
@@ -0,0 +1,621 @@
+package objects
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+)
+
+var (
+	ErrInvalidWebhook  = errors.New("invalid webhook")
+	ErrWebhookNotFound = errors.New("webhook not found")
+)
+
+// States of a webhook delivery. A delivery failing MaxAttempts times is
+// dead-lettered and never tried again.
+const (
+	WebhookPending   = "pending"
+	WebhookDelivered = "delivered"
+	WebhookDead      = "dead"
+)
+
+const (
+	minWebhookSecret          = 16
+	defaultWebhookAttempts    = 8
+	defaultWebhookBackoff     = 30 * time.Second
+	maxWebhookBackoff         = 6 * time.Hour
+	defaultWebhookBatch       = 100
+	defaultWebhookTimeout     = 10 * time.Second
+	webhookSignatureHeader    = "X-Objects-Signature"
+	webhookTimestampHeader    = "X-Objects-Timestamp"
+	webhookDeliveryHeader     = "X-Objects-Delivery"
+	webhookResponseBodyLimit  = 1 << 10
+	webhookSubscriptionFields = "uuid, owner_uuid, url, secret, kinds, brand_uuid, group_uuid, created_at"
+)
+
+// WebhookReq subscribes Url to the changes of the kinds in Kinds, all of
+// them if empty. BrandUuid and GroupUuid narrow it to the brand or group
+// and their perfums. Deliveries are signed with Secret.
+type WebhookReq struct {
+	Url       string   `json:"url"`
+	Secret    string   `json:"secret"`
+	Kinds     []string `json:"kinds"`
+	BrandUuid string   `json:"brand_id"`
+	GroupUuid string   `json:"group_id"`
+}
+
+// WebhookSubscriptionV1 ...
+type WebhookSubscriptionV1 struct {
+	Uuid        string    `db:"uuid" json:"id"`
+	OwnerUuid   string    `db:"owner_uuid" json:"-"`
+	Url         string    `db:"url" json:"url"`
+	Secret      string    `db:"secret" json:"-"`
+	KindsString string    `db:"kinds" json:"-"`
+	Kinds       []string  `db:"-" json:"kinds"`
+	BrandUuid   string    `db:"brand_uuid" json:"brand_id"`
+	GroupUuid   string    `db:"group_uuid" json:"group_id"`
+	CreatedAt   time.Time `db:"created_at" json:"created_at"`
+	Links       []LinkV1  `db:"-" json:"links"`
+}
+
+// WebhookPayloadV1 is the body of a delivery: the event and the item it
+// is about in its V1 shape, a PerfumCompositionV1 for the composition
+// changes of a perfum. Object is null once the item is gone.
+type WebhookPayloadV1 struct {
+	Event  OutboxEventV1 `json:"event"`
+	Object interface{}   `json:"object"`
+}
+
+// WebhookDeliveryV1 is the payload of one event queued for a subscription.
+type WebhookDeliveryV1 struct {
+	Uuid             string    `db:"uuid"`
+	SubscriptionUuid string    `db:"subscription_uuid"`
+	EventSeq         int64     `db:"event_seq"`
+	Url              string    `db:"url"`
+	Secret           string    `db:"secret"`
+	Payload          string    `db:"payload"`
+	Status           string    `db:"status"`
+	Attempts         int64     `db:"attempts"`
+	NextAttemptAt    time.Time `db:"next_attempt_at"`
+	LastError        string    `db:"last_error"`
+}
+
+// WebhookStore keeps the subscriptions and their queued deliveries.
+type WebhookStore interface {
+	Subscriptions(ctx context.Context) ([]WebhookSubscriptionV1, error)
+	// EnqueueDelivery ignores a delivery of an event already queued for the
+	// subscription, so that an event handled twice is delivered once.
+	EnqueueDelivery(ctx context.Context, delivery *WebhookDeliveryV1) error
+	// DueDeliveries returns the pending deliveries due at now, along with
+	// the url and secret of their subscriptions.
+	DueDeliveries(ctx context.Context, now time.Time, limit int) ([]WebhookDeliveryV1, error)
+	UpdateDelivery(ctx context.Context, delivery *WebhookDeliveryV1) error
+}
+
+// WebhookQueryParams ...
+type WebhookQueryParams struct {
+	Fields string
+}
+
+func init() {
+	template.Must(queries.Parse(`
+{{define "insert_webhook"}}
+INSERT INTO webhook_subscriptions ({{.Fields}})
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+{{end}}
+
+{{define "delete_webhook"}}
+DELETE FROM webhook_subscriptions WHERE uuid = $1 AND owner_uuid = $2
+{{end}}
+
+{{define "select_webhooks"}}
+SELECT {{.Fields}} FROM webhook_subscriptions
+ORDER BY webhook_subscriptions.created_at
+{{end}}
+
+{{define "insert_webhook_delivery"}}
+INSERT INTO webhook_deliveries (uuid, subscription_uuid, event_seq, payload, status, attempts, next_attempt_at, last_error)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+ON CONFLICT (subscription_uuid, event_seq) DO NOTHING
+{{end}}
+
+{{define "select_due_webhook_deliveries"}}
+SELECT webhook_deliveries.uuid, webhook_deliveries.subscription_uuid, webhook_deliveries.event_seq,
+	webhook_subscriptions.url, webhook_subscriptions.secret, webhook_deliveries.payload,
+	webhook_deliveries.status, webhook_deliveries.attempts, webhook_deliveries.next_attempt_at,
+	webhook_deliveries.last_error
+FROM webhook_deliveries
+JOIN webhook_subscriptions ON webhook_subscriptions.uuid = webhook_deliveries.subscription_uuid
+WHERE webhook_deliveries.status = 'pending' AND webhook_deliveries.next_attempt_at <= $1
+ORDER BY webhook_deliveries.next_attempt_at, webhook_deliveries.event_seq
+LIMIT $2
+{{end}}
+
+{{define "update_webhook_delivery"}}
+UPDATE webhook_deliveries SET status = $2, attempts = $3, next_attempt_at = $4, last_error = $5
+WHERE webhook_deliveries.uuid = $1
+{{end}}
+
+{{define "select_perfum_owners"}}
+SELECT COALESCE(brands.uuid, '') AS brand_uuid, COALESCE(groups.uuid, '') AS group_uuid
+FROM parfum_info
+LEFT JOIN brands ON brands.id = parfum_info.brand_id
+LEFT JOIN groups ON groups.id = parfum_info.group_id
+WHERE parfum_info.uuid = $1
+{{end}}
+`))
+}
+
+func (req *WebhookReq) check() error {
+	u, err := url.Parse(req.Url)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidWebhook
+	}
+	if len(req.Secret) < minWebhookSecret {
+		return ErrInvalidWebhook
+	}
+	for _, kind := range req.Kinds {
+		if _, ok := kindTables[kind]; !ok {
+			return ErrInvalidWebhook
+		}
+	}
+	return nil
+}
+
+// CreateWebhook subscribes the user of ctx to the changes req asks for.
+func CreateWebhook(ctx context.Context, req *WebhookReq) (*WebhookSubscriptionV1, error) {
+	claims, ok := ClaimsFromContext(ctx)
+	if !ok || claims.UserId == "" {
+		return nil, ErrUnauthenticated
+	}
+	if req == nil {
+		return nil, ErrInvalidWebhook
+	}
+	if err := req.check(); err != nil {
+		return nil, err
+	}
+
+	uuid, err := newUuid()
+	if err != nil {
+		return nil, err
+	}
+	sub := &WebhookSubscriptionV1{
+		Uuid:        uuid,
+		OwnerUuid:   claims.UserId,
+		Url:         req.Url,
+		Secret:      req.Secret,
+		KindsString: strings.Join(req.Kinds, ","),
+		Kinds:       req.Kinds,
+		BrandUuid:   req.BrandUuid,
+		GroupUuid:   req.GroupUuid,
+		CreatedAt:   time.Now().UTC(),
+	}
+	if sub.Kinds == nil {
+		sub.Kinds = []string{}
+	}
+
+	query, err := renderQuery("insert_webhook", WebhookQueryParams{Fields: webhookSubscriptionFields})
+	if err != nil {
+		return nil, err
+	}
+	_, err = dbmap.Exec(query, sub.Uuid, sub.OwnerUuid, sub.Url, sub.Secret, sub.KindsString,
+		sub.BrandUuid, sub.GroupUuid, sub.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	sub.Links = []LinkV1{
+		LinkV1{
+			Href:   baseUrl + "/webhook/" + sub.Uuid,
+			Rel:    "WebhookDelete",
+			Method: "DELETE",
+		},
+	}
+	return sub, nil
+}
+
+// DeleteWebhook drops the subscription uuid of the user of ctx, along with
+// its queued deliveries.
+func DeleteWebhook(ctx context.Context, uuid string) error {
+	claims, ok := ClaimsFromContext(ctx)
+	if !ok || claims.UserId == "" {
+		return ErrUnauthenticated
+	}
+	query, err := renderQuery("delete_webhook", nil)
+	if err != nil {
+		return err
+	}
+	res, err := dbmap.Exec(query, uuid, claims.UserId)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil || n == 0 {
+		if err != nil {
+			return err
+		}
+		return ErrWebhookNotFound
+	}
+	return nil
+}
+
+// DbWebhookStore is a WebhookStore in the database.
+type DbWebhookStore struct{}
+
+func (DbWebhookStore) Subscriptions(ctx context.Context) ([]WebhookSubscriptionV1, error) {
+	query, err := renderQuery("select_webhooks", WebhookQueryParams{Fields: webhookSubscriptionFields})
+	if err != nil {
+		return nil, err
+	}
+	var res []WebhookSubscriptionV1
+	if _, err := dbmap.Select(&res, query); err != nil {
+		return nil, err
+	}
+	for i := range res {
+		res[i].Kinds = []string{}
+		if res[i].KindsString != "" {
+			res[i].Kinds = strings.Split(res[i].KindsString, ",")
+		}
+	}
+	return res, nil
+}
+
+func (DbWebhookStore) EnqueueDelivery(ctx context.Context, d *WebhookDeliveryV1) error {
+	query, err := renderQuery("insert_webhook_delivery", nil)
+	if err != nil {
+		return err
+	}
+	_, err = dbmap.Exec(query, d.Uuid, d.SubscriptionUuid, d.EventSeq, d.Payload, d.Status, d.Attempts,
+		d.NextAttemptAt, d.LastError)
+	return err
+}
+
+func (DbWebhookStore) DueDeliveries(ctx context.Context, now time.Time, limit int) ([]WebhookDeliveryV1, error) {
+	query, err := renderQuery("select_due_webhook_deliveries", nil)
+	if err != nil {
+		return nil, err
+	}
+	var res []WebhookDeliveryV1
+	if _, err := dbmap.Select(&res, query, now, limit); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (DbWebhookStore) UpdateDelivery(ctx context.Context, d *WebhookDeliveryV1) error {
+	query, err := renderQuery("update_webhook_delivery", nil)
+	if err != nil {
+		return err
+	}
+	_, err = dbmap.Exec(query, d.Uuid, d.Status, d.Attempts, d.NextAttemptAt, d.LastError)
+	return err
+}
+
+// MemWebhookStore is a WebhookStore in memory, standing in for
+// DbWebhookStore in tests.
+type MemWebhookStore struct {
+	mu         sync.Mutex
+	subs       []WebhookSubscriptionV1
+	deliveries []WebhookDeliveryV1
+}
+
+// AddSubscription stores sub as CreateWebhook would.
+func (s *MemWebhookStore) AddSubscription(sub WebhookSubscriptionV1) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.subs = append(s.subs, sub)
+}
+
+// Deliveries returns every delivery of the store, in the order queued.
+func (s *MemWebhookStore) Deliveries() []WebhookDeliveryV1 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]WebhookDeliveryV1(nil), s.deliveries...)
+}
+
+func (s *MemWebhookStore) Subscriptions(ctx context.Context) ([]WebhookSubscriptionV1, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]WebhookSubscriptionV1(nil), s.subs...), nil
+}
+
+func (s *MemWebhookStore) EnqueueDelivery(ctx context.Context, d *WebhookDeliveryV1) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, queued := range s.deliveries {
+		if queued.SubscriptionUuid == d.SubscriptionUuid && queued.EventSeq == d.EventSeq {
+			return nil
+		}
+	}
+	s.deliveries = append(s.deliveries, *d)
+	return nil
+}
+
+func (s *MemWebhookStore) DueDeliveries(ctx context.Context, now time.Time, limit int) ([]WebhookDeliveryV1, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	subs := make(map[string]WebhookSubscriptionV1)
+	for _, sub := range s.subs {
+		subs[sub.Uuid] = sub
+	}
+	res := []WebhookDeliveryV1{}
+	for _, d := range s.deliveries {
+		sub, ok := subs[d.SubscriptionUuid]
+		if !ok || d.Status != WebhookPending || d.NextAttemptAt.After(now) {
+			continue
+		}
+		d.Url, d.Secret = sub.Url, sub.Secret
+		res = append(res, d)
+	}
+	sort.SliceStable(res, func(i, j int) bool { return res[i].NextAttemptAt.Before(res[j].NextAttemptAt) })
+	if len(res) > limit {
+		res = res[:limit]
+	}
+	return res, nil
+}
+
+func (s *MemWebhookStore) UpdateDelivery(ctx context.Context, d *WebhookDeliveryV1) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.deliveries {
+		if s.deliveries[i].Uuid == d.Uuid {
+			s.deliveries[i].Status = d.Status
+			s.deliveries[i].Attempts = d.Attempts
+			s.deliveries[i].NextAttemptAt = d.NextAttemptAt
+			s.deliveries[i].LastError = d.LastError
+			return nil
+		}
+	}
+	return errors.New("delivery not found")
+}
+
+// WebhookDeliverer turns the events of the outbox into webhook deliveries
+// and delivers them. HandleEvent is meant as the handler of an
+// OutboxConsumer, DeliverDue to be called on a timer, e.g. through Run.
+type WebhookDeliverer struct {
+	Store WebhookStore
+	// Client sends the deliveries, a client timing out after 10s if nil.
+	Client *http.Client
+	// Version is the version of the payload objects, "v1" if empty.
+	Version string
+	// MaxAttempts is the number of failures dead-lettering a delivery, 8
+	// if zero.
+	MaxAttempts int64
+	// Backoff is the wait before the first retry, doubled on each of the
+	// next ones up to 6h; 30s if zero.
+	Backoff time.Duration
+	// Load returns the item an event is about, LoadBatch if nil.
+	Load func(ctx context.Context, kind, uuid string) (interface{}, error)
+	// Now is used in place of time.Now when set.
+	Now func() time.Time
+}
+
+func (d *WebhookDeliverer) now() time.Time {
+	if d.Now != nil {
+		return d.Now().UTC()
+	}
+	return time.Now().UTC()
+}
+
+func (d *WebhookDeliverer) load(ctx context.Context, kind, uuid string) (interface{}, error) {
+	if d.Load != nil {
+		return d.Load(ctx, kind, uuid)
+	}
+	version := d.Version
+	if version == "" {
+		version = "v1"
+	}
+	found, err := LoadBatch(version, []BatchItemReq{{Kind: kind, Uuid: uuid}})
+	if err != nil {
+		return nil, err
+	}
+	return found[kind][uuid], nil
+}
+
+// HandleEvent queues a delivery of event for each subscription it matches.
+func (d *WebhookDeliverer) HandleEvent(ctx context.Context, event OutboxEventV1) error {
+	subs, err := d.Store.Subscriptions(ctx)
+	if err != nil || len(subs) == 0 {
+		return err
+	}
+
+	kind := event.Kind
+	if kind == "perfum" && event.Operation == AuditComposition {
+		kind = "composition"
+	}
+	var object interface{}
+	if event.Operation != OutboxDelete {
+		if object, err = d.load(ctx, kind, event.Uuid); err != nil {
+			return err
+		}
+	}
+	payload, err := json.Marshal(WebhookPayloadV1{Event: event, Object: object})
+	if err != nil {
+		return err
+	}
+
+	brand, group, err := webhookOwners(event)
+	if err != nil {
+		return err
+	}
+	for _, sub := range subs {
+		if !sub.matches(event.Kind, brand, group) {
+			continue
+		}
+		uuid, err := newUuid()
+		if err != nil {
+			return err
+		}
+		delivery := &WebhookDeliveryV1{
+			Uuid:             uuid,
+			SubscriptionUuid: sub.Uuid,
+			EventSeq:         event.Seq,
+			Payload:          string(payload),
+			Status:           WebhookPending,
+			NextAttemptAt:    d.now(),
+		}
+		if err := d.Store.EnqueueDelivery(ctx, delivery); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// webhookOwners returns the brand and group an event is about: the item
+// itself for a brand or a group, the ones of a perfum. Those of a perfum are
+// read from the database whatever its status, a tombstone having neither.
+func webhookOwners(event OutboxEventV1) (string, string, error) {
+	switch event.Kind {
+	case "brand":
+		return event.Uuid, "", nil
+	case "group":
+		return "", event.Uuid, nil
+	case "perfum":
+		query, err := renderQuery("select_perfum_owners", nil)
+		if err != nil {
+			return "", "", err
+		}
+		owners := []PerfumInfoV1{}
+		if _, err := dbmap.Select(&owners, query, event.Uuid); err != nil || len(owners) == 0 {
+			return "", "", err
+		}
+		return owners[0].BrandUuid, owners[0].GroupUuid, nil
+	}
+	return "", "", nil
+}
+
+func (sub *WebhookSubscriptionV1) matches(kind, brand, group string) bool {
+	if len(sub.Kinds) > 0 && !containsString(sub.Kinds, kind) {
+		return false
+	}
+	return (sub.BrandUuid == "" || sub.BrandUuid == brand) && (sub.GroupUuid == "" || sub.GroupUuid == group)
+}
+
+// SignWebhook is the signature of a delivery of payload at timestamp, the
+// hex HMAC-SHA256 of "<timestamp>.<payload>" keyed by secret. Receivers
+// recompute it from the X-Objects-Timestamp header and the body.
+func SignWebhook(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10) + "."))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// DeliverDue sends the deliveries due and returns how many were sent
+// successfully. A failed one is retried after an exponential backoff and
+// dead-lettered after MaxAttempts failures.
+func (d *WebhookDeliverer) DeliverDue(ctx context.Context) (int, error) {
+	deliveries, err := d.Store.DueDeliveries(ctx, d.now(), defaultWebhookBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for i := range deliveries {
+		delivery := &deliveries[i]
+		if err := d.send(ctx, delivery); err != nil {
+			if ctx.Err() != nil {
+				return sent, ctx.Err()
+			}
+			d.fail(delivery, err)
+		} else {
+			delivery.Status = WebhookDelivered
+			delivery.Attempts++
+			delivery.LastError = ""
+			sent++
+		}
+		if err := d.Store.UpdateDelivery(ctx, delivery); err != nil {
+			return sent, err
+		}
+	}
+	return sent, nil
+}
+
+func (d *WebhookDeliverer) send(ctx context.Context, delivery *WebhookDeliveryV1) error {
+	client := d.Client
+	if client == nil {
+		client = &http.Client{Timeout: defaultWebhookTimeout}
+	}
+
+	payload := []byte(delivery.Payload)
+	timestamp := d.now().Unix()
+	req, err := http.NewRequest(http.MethodPost, delivery.Url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhookDeliveryHeader, delivery.Uuid)
+	req.Header.Set(webhookTimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(webhookSignatureHeader, SignWebhook(delivery.Secret, timestamp, payload))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, webhookResponseBodyLimit))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook answered %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// fail schedules the retry of delivery, or dead-letters it.
+func (d *WebhookDeliverer) fail(delivery *WebhookDeliveryV1, err error) {
+	maxAttempts := d.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultWebhookAttempts
+	}
+	backoff := d.Backoff
+	if backoff <= 0 {
+		backoff = defaultWebhookBackoff
+	}
+
+	delivery.Attempts++
+	delivery.LastError = err.Error()
+	if delivery.Attempts >= maxAttempts {
+		delivery.Status = WebhookDead
+		return
+	}
+	for i := int64(1); i < delivery.Attempts && backoff < maxWebhookBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxWebhookBackoff {
+		backoff = maxWebhookBackoff
+	}
+	delivery.NextAttemptAt = d.now().Add(backoff)
+}
+
+// Run delivers until ctx is done, waiting interval whenever nothing is due.
+func (d *WebhookDeliverer) Run(ctx context.Context, interval time.Duration) error {
+	for ctx.Err() == nil {
+		n, err := d.DeliverDue(ctx)
+		if err != nil {
+			return err
+		}
+		if n > 0 {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(interval):
+		}
+	}
+	return ctx.Err()
+}
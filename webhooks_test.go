@@ -0,0 +1,185 @@
+package objects
+
+import (
+	"context"
+	"database/sql/driver"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const testWebhookSecret = "0123456789abcdef"
+
+func newTestDeliverer(store *MemWebhookStore, clock *testClock) *WebhookDeliverer {
+	return &WebhookDeliverer{
+		Store:       store,
+		MaxAttempts: 3,
+		Backoff:     time.Minute,
+		Load: func(ctx context.Context, kind, uuid string) (interface{}, error) {
+			return PerfumInfoV1{Uuid: uuid}, nil
+		},
+		Now: clock.Now,
+	}
+}
+
+// perfumOwners answers the owner lookups of a test with the brand and group
+// of owners, keyed by perfum.
+func perfumOwners(owners map[string][2]string) func(string, []driver.Value) (*fakeResult, error) {
+	return func(query string, args []driver.Value) (*fakeResult, error) {
+		owner, ok := owners[args[0].(string)]
+		if !strings.Contains(query, "FROM parfum_info") || !ok {
+			return nil, nil
+		}
+		return &fakeResult{
+			columns: []string{"brand_uuid", "group_uuid"},
+			rows:    [][]driver.Value{{owner[0], owner[1]}},
+		}, nil
+	}
+}
+
+func TestWebhookSignedDelivery(t *testing.T) {
+	useFakeDB(t, perfumOwners(map[string][2]string{"p1": {"b1", ""}}))
+	clock := &testClock{now: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	var received int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&received, 1)
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		timestamp, err := strconv.ParseInt(r.Header.Get(webhookTimestampHeader), 10, 64)
+		if err != nil || timestamp != clock.now.Unix() {
+			t.Errorf("timestamp header %q", r.Header.Get(webhookTimestampHeader))
+		}
+		if got, want := r.Header.Get(webhookSignatureHeader), SignWebhook(testWebhookSecret, timestamp, body); got != want {
+			t.Errorf("signature %q, want %q", got, want)
+		}
+		if r.Header.Get(webhookDeliveryHeader) == "" {
+			t.Error("no delivery header")
+		}
+	}))
+	defer server.Close()
+
+	store := &MemWebhookStore{}
+	store.AddSubscription(WebhookSubscriptionV1{Uuid: "s1", Url: server.URL, Secret: testWebhookSecret, BrandUuid: "b1"})
+	store.AddSubscription(WebhookSubscriptionV1{Uuid: "s2", Url: server.URL, Secret: testWebhookSecret, BrandUuid: "b2"})
+	deliverer := newTestDeliverer(store, clock)
+
+	event := OutboxEventV1{Seq: 1, Kind: "perfum", Uuid: "p1", Operation: AuditUpdate, Version: 1}
+	for i := 0; i < 2; i++ {
+		if err := deliverer.HandleEvent(context.Background(), event); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if deliveries := store.Deliveries(); len(deliveries) != 1 || deliveries[0].SubscriptionUuid != "s1" {
+		t.Fatalf("queued %+v, want one delivery to s1", deliveries)
+	}
+
+	if n, err := deliverer.DeliverDue(context.Background()); n != 1 || err != nil {
+		t.Fatalf("DeliverDue = %d, %v, want 1", n, err)
+	}
+	if d := store.Deliveries()[0]; d.Status != WebhookDelivered || d.Attempts != 1 {
+		t.Fatalf("delivery %+v, want delivered", d)
+	}
+	if n := atomic.LoadInt32(&received); n != 1 {
+		t.Fatalf("received %d requests, want 1", n)
+	}
+}
+
+func TestWebhookBackoffDeadLetter(t *testing.T) {
+	useFakeDB(t, perfumOwners(nil))
+	clock := &testClock{now: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	var received int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&received, 1)
+		http.Error(w, "down", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	store := &MemWebhookStore{}
+	store.AddSubscription(WebhookSubscriptionV1{Uuid: "s1", Url: server.URL, Secret: testWebhookSecret})
+	deliverer := newTestDeliverer(store, clock)
+	event := OutboxEventV1{Seq: 1, Kind: "perfum", Uuid: "p1", Operation: AuditUpdate, Version: 1}
+	if err := deliverer.HandleEvent(context.Background(), event); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		wait     time.Duration
+		attempts int64
+		status   string
+		next     time.Duration
+	}{
+		{0, 1, WebhookPending, time.Minute},
+		{time.Minute, 2, WebhookPending, 2 * time.Minute},
+		{2 * time.Minute, 3, WebhookDead, 0},
+	}
+	for _, test := range tests {
+		clock.now = clock.now.Add(test.wait)
+		if n, err := deliverer.DeliverDue(context.Background()); n != 0 || err != nil {
+			t.Fatalf("DeliverDue = %d, %v, want 0", n, err)
+		}
+		d := store.Deliveries()[0]
+		if d.Attempts != test.attempts || d.Status != test.status || d.LastError == "" {
+			t.Fatalf("after %d attempts: %+v", test.attempts, d)
+		}
+		if test.next > 0 && !d.NextAttemptAt.Equal(clock.now.Add(test.next)) {
+			t.Fatalf("after %d attempts next at %v, want %v", test.attempts, d.NextAttemptAt, clock.now.Add(test.next))
+		}
+
+		// nothing is due before the backoff is over
+		if _, err := deliverer.DeliverDue(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		if n := atomic.LoadInt32(&received); int64(n) != test.attempts {
+			t.Fatalf("received %d requests, want %d", n, test.attempts)
+		}
+	}
+
+	clock.now = clock.now.Add(24 * time.Hour)
+	if _, err := deliverer.DeliverDue(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt32(&received); n != 3 {
+		t.Fatalf("dead delivery retried: received %d requests", n)
+	}
+}
+
+func TestWebhookTombstoneOwners(t *testing.T) {
+	fake := useFakeDB(t, perfumOwners(map[string][2]string{"p1": {"b1", "g1"}}))
+	store := &MemWebhookStore{}
+	store.AddSubscription(WebhookSubscriptionV1{Uuid: "s1", Url: "http://example.com", BrandUuid: "b1"})
+	store.AddSubscription(WebhookSubscriptionV1{Uuid: "s2", Url: "http://example.com", GroupUuid: "g1"})
+	store.AddSubscription(WebhookSubscriptionV1{Uuid: "s3", Url: "http://example.com", BrandUuid: "b2"})
+	deliverer := newTestDeliverer(store, &testClock{now: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)})
+	deliverer.Load = func(ctx context.Context, kind, uuid string) (interface{}, error) {
+		return PerfumInfoV1{Uuid: uuid, Status: StatusDeleted}, nil
+	}
+
+	for seq, operation := range []string{AuditStatus, OutboxDelete} {
+		event := OutboxEventV1{Seq: int64(seq + 1), Kind: "perfum", Uuid: "p1", Operation: operation, Version: int64(seq + 1)}
+		if err := deliverer.HandleEvent(context.Background(), event); err != nil {
+			t.Fatal(err)
+		}
+	}
+	subs := map[string]int{}
+	for _, d := range store.Deliveries() {
+		subs[d.SubscriptionUuid]++
+	}
+	if subs["s1"] != 2 || subs["s2"] != 2 || subs["s3"] != 0 {
+		t.Errorf("deliveries by subscription %v, want 2 to s1 and s2", subs)
+	}
+
+	// the owners are read whatever the status of the perfum
+	for _, s := range fake.ran("FROM parfum_info") {
+		if strings.Contains(s.query, "status") {
+			t.Errorf("owners filtered by status: %s", s.query)
+		}
+	}
+}